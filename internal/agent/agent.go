@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -57,12 +58,17 @@ type SessionAgentCall struct {
 	TopK             *int64
 	FrequencyPenalty *float64
 	PresencePenalty  *float64
+	// DisableWebTools withholds the web tools (web_search, fetch, agentic_fetch) from this call
+	DisableWebTools bool
 }
 
 type SessionAgent interface {
 	Run(context.Context, SessionAgentCall) (*fantasy.AgentResult, error)
 	SetModels(large Model, small Model)
 	SetTools(tools []fantasy.AgentTool)
+	Tools() []fantasy.AgentTool
+	SetSystemPrompt(systemPrompt string)
+	SystemPrompt() string
 	Cancel(sessionID string)
 	CancelAll()
 	IsSessionBusy(sessionID string) bool
@@ -71,6 +77,8 @@ type SessionAgent interface {
 	QueuedPromptsList(sessionID string) []string
 	ClearQueue(sessionID string)
 	Summarize(context.Context, string, fantasy.ProviderOptions) error
+	// Complete runs a one-off prompt on the small model, outside of any session
+	Complete(ctx context.Context, prompt string) (string, error)
 	Model() Model
 }
 
@@ -91,6 +99,7 @@ type sessionAgent struct {
 	messages             message.Service
 	disableAutoSummarize bool
 	isYolo               bool
+	secrets              *secretGuard
 
 	messageQueue   *csync.Map[string, []SessionAgentCall]
 	activeRequests *csync.Map[string, context.CancelFunc]
@@ -107,6 +116,8 @@ type SessionAgentOptions struct {
 	Sessions             session.Service
 	Messages             message.Service
 	Tools                []fantasy.AgentTool
+	// Secrets scans tool results before they reach the model; nil sends them as they are
+	Secrets *secretGuard
 }
 
 func NewSessionAgent(
@@ -123,6 +134,7 @@ func NewSessionAgent(
 		disableAutoSummarize: opts.DisableAutoSummarize,
 		tools:                opts.Tools,
 		isYolo:               opts.IsYolo,
+		secrets:              opts.Secrets,
 		messageQueue:         csync.NewMap[string, []SessionAgentCall](),
 		activeRequests:       csync.NewMap[string, context.CancelFunc](),
 	}
@@ -147,15 +159,22 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 		return nil, nil
 	}
 
-	if len(a.tools) > 0 {
-		// Add Anthropic caching to the last tool.
-		a.tools[len(a.tools)-1].SetProviderOptions(a.getCacheControlOptions())
+	agentTools := a.tools
+	if call.DisableWebTools {
+		agentTools = withoutWebTools(agentTools)
 	}
+	if len(agentTools) > 0 {
+		// Add Anthropic caching to the last tool offered, on a copy since concurrent runs share the tools.
+		agentTools = slices.Clone(agentTools)
+		last := len(agentTools) - 1
+		agentTools[last] = &cachedTool{AgentTool: agentTools[last], options: a.getCacheControlOptions()}
+	}
+	agentTools = a.secrets.wrap(agentTools)
 
 	agent := fantasy.NewAgent(
 		a.largeModel.Model,
 		fantasy.WithSystemPrompt(a.systemPrompt),
-		fantasy.WithTools(a.tools...),
+		fantasy.WithTools(agentTools...),
 	)
 
 	sessionLock := sync.Mutex{}
@@ -357,6 +376,9 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 			}
 			a.updateSessionUsage(a.largeModel, &updatedSession, stepResult.Usage, a.openrouterCost(stepResult.ProviderMetadata))
 			_, sessionErr := a.sessions.Save(genCtx, updatedSession)
+			if sessionErr == nil && usedWebTool(stepResult) {
+				_, sessionErr = a.sessions.MarkWebAccessUsed(genCtx, call.SessionID)
+			}
 			sessionLock.Unlock()
 			if sessionErr != nil {
 				return sessionErr
@@ -649,6 +671,20 @@ func (a *sessionAgent) getCacheControlOptions() fantasy.ProviderOptions {
 	}
 }
 
+// cachedTool offers a tool with the cache control options of one run, leaving the shared tool as is
+type cachedTool struct {
+	fantasy.AgentTool
+	options fantasy.ProviderOptions
+}
+
+func (t *cachedTool) ProviderOptions() fantasy.ProviderOptions {
+	return t.options
+}
+
+func (t *cachedTool) SetProviderOptions(opts fantasy.ProviderOptions) {
+	t.options = opts
+}
+
 func (a *sessionAgent) createUserMessage(ctx context.Context, call SessionAgentCall) (message.Message, error) {
 	parts := []message.ContentPart{message.TextContent{Text: call.Prompt}}
 	var attachmentParts []message.ContentPart
@@ -928,6 +964,29 @@ func (a *sessionAgent) QueuedPromptsList(sessionID string) []string {
 	return prompts
 }
 
+func (a *sessionAgent) Complete(ctx context.Context, prompt string) (string, error) {
+	opts := []fantasy.AgentOption{}
+	if prefix := a.promptPrefix(); prefix != "" {
+		opts = append(opts, fantasy.WithSystemPrompt(prefix))
+	}
+	if maxOutput := a.smallModel.CatwalkCfg.DefaultMaxTokens; maxOutput > 0 {
+		opts = append(opts, fantasy.WithMaxOutputTokens(maxOutput))
+	}
+	agent := fantasy.NewAgent(a.smallModel.Model, opts...)
+
+	resp, err := agent.Generate(ctx, fantasy.AgentCall{Prompt: prompt})
+	if err != nil {
+		return "", err
+	}
+
+	text := resp.Response.Content.Text()
+	// Remove thinking tags if present.
+	if idx := strings.Index(text, "</think>"); idx >= 0 {
+		text = text[idx+len("</think>"):]
+	}
+	return strings.TrimSpace(text), nil
+}
+
 func (a *sessionAgent) SetModels(large Model, small Model) {
 	a.largeModel = large
 	a.smallModel = small
@@ -937,6 +996,18 @@ func (a *sessionAgent) SetTools(tools []fantasy.AgentTool) {
 	a.tools = tools
 }
 
+func (a *sessionAgent) Tools() []fantasy.AgentTool {
+	return a.tools
+}
+
+func (a *sessionAgent) SetSystemPrompt(systemPrompt string) {
+	a.systemPrompt = systemPrompt
+}
+
+func (a *sessionAgent) SystemPrompt() string {
+	return a.systemPrompt
+}
+
 func (a *sessionAgent) Model() Model {
 	return a.largeModel
 }
@@ -960,6 +1031,7 @@ func (a *sessionAgent) convertToToolResult(result fantasy.ToolResultContent) mes
 		ToolCallID: result.ToolCallID,
 		Name:       result.ToolName,
 		Metadata:   result.ClientMetadata,
+		Redactions: a.secrets.take(result.ToolCallID),
 	}
 
 	switch result.Result.GetType() {
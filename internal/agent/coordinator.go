@@ -22,12 +22,15 @@ import (
 	"github.com/trankhanh040147/revcli/internal/agent/tools"
 	"github.com/trankhanh040147/revcli/internal/config"
 	"github.com/trankhanh040147/revcli/internal/csync"
+	"github.com/trankhanh040147/revcli/internal/filter"
 	"github.com/trankhanh040147/revcli/internal/history"
 	"github.com/trankhanh040147/revcli/internal/log"
 	"github.com/trankhanh040147/revcli/internal/lsp"
 	"github.com/trankhanh040147/revcli/internal/message"
 	"github.com/trankhanh040147/revcli/internal/permission"
+	reviewprompt "github.com/trankhanh040147/revcli/internal/prompt"
 	"github.com/trankhanh040147/revcli/internal/session"
+	"github.com/trankhanh040147/revcli/internal/shell"
 	"golang.org/x/sync/errgroup"
 
 	"charm.land/fantasy/providers/anthropic"
@@ -55,6 +58,21 @@ type Coordinator interface {
 	Summarize(context.Context, string) error
 	Model() Model
 	UpdateModels(ctx context.Context) error
+	// SetReviewInstructions appends review guidelines (preset, intent) to the review mode system prompt,
+	// or uses them instead of its template when replace is set
+	SetReviewInstructions(ctx context.Context, instructions string, replace bool) error
+	// SystemPrompt returns the system prompt of the current mode
+	SystemPrompt() string
+	// PromptOverhead estimates the tokens the system prompt and tool schemas add to every request
+	PromptOverhead() int
+	// Complete runs a one-off prompt on the small model, outside of any session
+	Complete(ctx context.Context, prompt string) (string, error)
+	// SetMode switches the agent template and tools; session history is kept
+	SetMode(ctx context.Context, mode Mode) error
+	// Mode returns the current agent mode
+	Mode() Mode
+	// SetSecretScanner replaces the scanner applied to tool results, e.g. with one using the repository's baseline
+	SetSecretScanner(scanner *filter.Scanner)
 }
 
 type coordinator struct {
@@ -68,6 +86,16 @@ type coordinator struct {
 	currentAgent SessionAgent
 	agents       map[string]SessionAgent
 
+	// mode selects currentAgent; prompts holds each built mode's template,
+	// and reviewInstructions are appended to the review one (or replace it)
+	mode               Mode
+	prompts            map[Mode]*prompt.Prompt
+	reviewInstructions string
+	replaceTemplate    bool
+
+	// secrets scans the tool results of every agent, sub-agents included
+	secrets *secretGuard
+
 	readyWg errgroup.Group
 }
 
@@ -80,6 +108,10 @@ func NewCoordinator(
 	history history.Service,
 	lspClients *csync.Map[string, *lsp.Client],
 ) (Coordinator, error) {
+	secrets, err := newSecretGuard(cfg.Options.Secrets)
+	if err != nil {
+		return nil, err
+	}
 	c := &coordinator{
 		cfg:         cfg,
 		sessions:    sessions,
@@ -88,17 +120,31 @@ func NewCoordinator(
 		history:     history,
 		lspClients:  lspClients,
 		agents:      make(map[string]SessionAgent),
+		prompts:     make(map[Mode]*prompt.Prompt),
+		secrets:     secrets,
+	}
+
+	agent, err := c.modeAgent(ctx, ModeReview)
+	if err != nil {
+		return nil, err
+	}
+	c.mode = ModeReview
+	c.currentAgent = agent
+	return c, nil
+}
+
+// modeAgent returns the agent for mode, building it on first use
+func (c *coordinator) modeAgent(ctx context.Context, mode Mode) (SessionAgent, error) {
+	if agent, ok := c.agents[mode.agentID()]; ok {
+		return agent, nil
 	}
 
-	agentCfg, ok := cfg.Agents[config.AgentReviewer]
+	agentCfg, ok := c.cfg.Agents[mode.agentID()]
 	if !ok {
-		return nil, errors.New("reviewer agent not configured")
+		return nil, fmt.Errorf("%s agent not configured", mode.agentID())
 	}
 
-	// TODO(PlanC): Make this dynamic when we support multiple agents/modes
-	// TODO(PlanC): Add ReviewMode config (strict/suggestive/interactive)
-	// For now, use reviewer prompt for review mode
-	prompt, err := reviewerPrompt(prompt.WithWorkingDir(c.cfg.WorkingDir()))
+	prompt, err := mode.prompt(prompt.WithWorkingDir(c.cfg.WorkingDir()))
 	if err != nil {
 		return nil, err
 	}
@@ -107,9 +153,9 @@ func NewCoordinator(
 	if err != nil {
 		return nil, err
 	}
-	c.currentAgent = agent
-	c.agents[config.AgentReviewer] = agent
-	return c, nil
+	c.prompts[mode] = prompt
+	c.agents[mode.agentID()] = agent
+	return agent, nil
 }
 
 // Run implements Coordinator.
@@ -140,7 +186,7 @@ func (c *coordinator) Run(ctx context.Context, sessionID string, prompt string,
 		return nil, errors.New("model provider not configured")
 	}
 
-	mergedOptions, temp, topP, topK, freqPenalty, presPenalty := mergeCallOptions(model, providerCfg)
+	mergedOptions, temp, topP, topK, freqPenalty, presPenalty := mergeCallOptions(model, providerCfg, c.cfg.Options.Generation)
 
 	if providerCfg.OAuthToken != nil && providerCfg.OAuthToken.IsExpired() {
 		slog.Info("Token needs to be refreshed", "provider", providerCfg.ID)
@@ -161,6 +207,7 @@ func (c *coordinator) Run(ctx context.Context, sessionID string, prompt string,
 			TopK:             topK,
 			FrequencyPenalty: freqPenalty,
 			PresencePenalty:  presPenalty,
+			DisableWebTools:  !WebAccess(ctx),
 		})
 	}
 	result, originalErr := run()
@@ -307,11 +354,16 @@ func getProviderOptions(model Model, providerCfg config.ProviderConfig) fantasy.
 	return options
 }
 
-func mergeCallOptions(model Model, cfg config.ProviderConfig) (fantasy.ProviderOptions, *float64, *float64, *int64, *float64, *float64) {
+// mergeCallOptions resolves the call options of a model: its own config, then the generation
+// options of the config (for every provider), then the provider's defaults
+func mergeCallOptions(model Model, cfg config.ProviderConfig, gen *config.GenerationOptions) (fantasy.ProviderOptions, *float64, *float64, *int64, *float64, *float64) {
 	modelOptions := getProviderOptions(model, cfg)
-	temp := cmp.Or(model.ModelCfg.Temperature, model.CatwalkCfg.Options.Temperature)
-	topP := cmp.Or(model.ModelCfg.TopP, model.CatwalkCfg.Options.TopP)
-	topK := cmp.Or(model.ModelCfg.TopK, model.CatwalkCfg.Options.TopK)
+	if gen == nil {
+		gen = &config.GenerationOptions{}
+	}
+	temp := cmp.Or(model.ModelCfg.Temperature, gen.Temperature, model.CatwalkCfg.Options.Temperature)
+	topP := cmp.Or(model.ModelCfg.TopP, gen.TopP, model.CatwalkCfg.Options.TopP)
+	topK := cmp.Or(model.ModelCfg.TopK, gen.TopK, model.CatwalkCfg.Options.TopK)
 	freqPenalty := cmp.Or(model.ModelCfg.FrequencyPenalty, model.CatwalkCfg.Options.FrequencyPenalty)
 	presPenalty := cmp.Or(model.ModelCfg.PresencePenalty, model.CatwalkCfg.Options.PresencePenalty)
 	return modelOptions, temp, topP, topK, freqPenalty, presPenalty
@@ -340,6 +392,7 @@ func (c *coordinator) buildAgent(ctx context.Context, prompt *prompt.Prompt, age
 		c.sessions,
 		c.messages,
 		nil,
+		c.secrets,
 	})
 	c.readyWg.Go(func() error {
 		tools, err := c.buildTools(ctx, agent)
@@ -379,14 +432,20 @@ func (c *coordinator) buildTools(ctx context.Context, agent config.Agent) ([]fan
 		}
 	}
 
+	var sandbox *shell.Sandbox
+	if agent.ReadOnlyShell {
+		sandbox = tools.ReviewSandbox()
+	}
+
 	allTools = append(allTools,
-		tools.NewBashTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.Attribution, modelName),
+		tools.NewBashTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.Attribution, modelName, sandbox),
 		tools.NewJobOutputTool(),
 		tools.NewJobKillTool(),
 		tools.NewDownloadTool(c.permissions, c.cfg.WorkingDir(), nil),
 		tools.NewEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 		tools.NewMultiEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 		tools.NewFetchTool(c.permissions, c.cfg.WorkingDir(), nil),
+		tools.NewWebSearchTool(nil),
 		tools.NewGlobTool(c.cfg.WorkingDir()),
 		tools.NewGrepTool(c.cfg.WorkingDir()),
 		tools.NewLsTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Tools.Ls),
@@ -438,7 +497,7 @@ func (c *coordinator) buildTools(ctx context.Context, agent config.Agent) ([]fan
 	slices.SortFunc(filteredTools, func(a, b fantasy.AgentTool) int {
 		return strings.Compare(a.Info().Name, b.Info().Name)
 	})
-	return filteredTools, nil
+	return wrapPolicyTools(c.permissions, filteredTools), nil
 }
 
 // TODO: when we support multiple agents we need to change this so that we pass in the agent specific model config
@@ -797,10 +856,14 @@ func (c *coordinator) UpdateModels(ctx context.Context) error {
 		return err
 	}
 	c.currentAgent.SetModels(large, small)
+	// The template depends on the model, so rebuild it
+	if err := c.refreshSystemPrompt(ctx); err != nil {
+		return err
+	}
 
-	agentCfg, ok := c.cfg.Agents[config.AgentReviewer]
+	agentCfg, ok := c.cfg.Agents[c.mode.agentID()]
 	if !ok {
-		return errors.New("reviewer agent not configured")
+		return fmt.Errorf("%s agent not configured", c.mode.agentID())
 	}
 
 	tools, err := c.buildTools(ctx, agentCfg)
@@ -808,9 +871,102 @@ func (c *coordinator) UpdateModels(ctx context.Context) error {
 		return err
 	}
 	c.currentAgent.SetTools(tools)
+
+	// Agents of other modes are rebuilt with the new models when switched to
+	for id := range c.agents {
+		if id != c.mode.agentID() {
+			delete(c.agents, id)
+		}
+	}
 	return nil
 }
 
+// SetMode implements Coordinator.
+func (c *coordinator) SetMode(ctx context.Context, mode Mode) error {
+	if mode == c.mode {
+		return nil
+	}
+	if c.currentAgent.IsBusy() {
+		return errors.New("cannot switch mode while the agent is busy")
+	}
+
+	agent, err := c.modeAgent(ctx, mode)
+	if err != nil {
+		return fmt.Errorf("failed to switch to %s mode: %w", mode, err)
+	}
+	c.mode = mode
+	c.currentAgent = agent
+	return c.refreshSystemPrompt(ctx)
+}
+
+// Mode implements Coordinator.
+func (c *coordinator) Mode() Mode {
+	return c.mode
+}
+
+// SetSecretScanner implements Coordinator.
+func (c *coordinator) SetSecretScanner(scanner *filter.Scanner) {
+	if c.secrets != nil {
+		c.secrets.scanner.Store(scanner)
+	}
+}
+
+// SetReviewInstructions implements Coordinator.
+func (c *coordinator) SetReviewInstructions(ctx context.Context, instructions string, replace bool) error {
+	c.reviewInstructions = instructions
+	c.replaceTemplate = replace && instructions != ""
+	return c.refreshSystemPrompt(ctx)
+}
+
+// SystemPrompt implements Coordinator.
+func (c *coordinator) SystemPrompt() string {
+	return c.currentAgent.SystemPrompt()
+}
+
+// PromptOverhead implements Coordinator.
+func (c *coordinator) PromptOverhead() int {
+	tokens := reviewprompt.EstimateTokens(c.currentAgent.SystemPrompt())
+	for _, tool := range c.currentAgent.Tools() {
+		info := tool.Info()
+		schema, err := sonic.Marshal(info.Parameters)
+		if err != nil {
+			slog.Warn("Failed to encode tool schema", "tool", info.Name, "error", err)
+		}
+		tokens += reviewprompt.EstimateTokens(info.Name + info.Description + string(schema))
+	}
+	return tokens
+}
+
+// refreshSystemPrompt rebuilds the current mode's prompt for the current model and, in review mode,
+// appends the review instructions
+func (c *coordinator) refreshSystemPrompt(ctx context.Context) error {
+	// Review guidelines shape findings, so fix and ask modes keep their own templates
+	reviewing := c.mode == ModeReview && c.reviewInstructions != ""
+	if reviewing && c.replaceTemplate {
+		c.currentAgent.SetSystemPrompt(c.reviewInstructions)
+		return nil
+	}
+	modePrompt, ok := c.prompts[c.mode]
+	if !ok {
+		return nil
+	}
+	model := c.currentAgent.Model()
+	systemPrompt, err := modePrompt.Build(ctx, model.Model.Provider(), model.Model.Model(), *c.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to build %s prompt: %w", c.mode, err)
+	}
+	if reviewing {
+		systemPrompt += "\n\n<review_guidelines>\n" + c.reviewInstructions + "\n</review_guidelines>\n"
+	}
+	c.currentAgent.SetSystemPrompt(systemPrompt)
+	return nil
+}
+
+// Complete implements Coordinator.
+func (c *coordinator) Complete(ctx context.Context, prompt string) (string, error) {
+	return c.currentAgent.Complete(ctx, prompt)
+}
+
 func (c *coordinator) QueuedPrompts(sessionID string) int {
 	return c.currentAgent.QueuedPrompts(sessionID)
 }
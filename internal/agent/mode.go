@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/agent/prompt"
+	"github.com/trankhanh040147/revcli/internal/config"
+)
+
+// Mode selects the agent template and tool set used for a session
+type Mode string
+
+// Agent modes
+const (
+	// ModeReview analyzes changes without editing files
+	ModeReview Mode = "review"
+	// ModeFix applies the reviewer's suggestions with the edit tools
+	ModeFix Mode = "fix"
+	// ModeAsk answers questions about the codebase with read-only tools
+	ModeAsk Mode = "ask"
+)
+
+// Modes lists the supported modes in display order
+var Modes = []Mode{ModeReview, ModeFix, ModeAsk}
+
+// ParseMode validates a mode name (case-insensitive); empty means review
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return ModeReview, nil
+	case ModeReview, ModeFix, ModeAsk:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: must be one of review, fix, ask", s)
+	}
+}
+
+// agentID returns the configured agent backing the mode
+func (m Mode) agentID() string {
+	switch m {
+	case ModeFix:
+		return config.AgentFixer
+	case ModeAsk:
+		return config.AgentAsker
+	default:
+		return config.AgentReviewer
+	}
+}
+
+// prompt returns the system prompt template for the mode
+func (m Mode) prompt(opts ...prompt.Option) (*prompt.Prompt, error) {
+	switch m {
+	case ModeFix:
+		return fixerPrompt(opts...)
+	case ModeAsk:
+		return askerPrompt(opts...)
+	default:
+		return reviewerPrompt(opts...)
+	}
+}
@@ -11,6 +11,12 @@ import (
 //go:embed templates/reviewer.md.tpl
 var reviewerPromptTmpl []byte
 
+//go:embed templates/fixer.md.tpl
+var fixerPromptTmpl []byte
+
+//go:embed templates/asker.md.tpl
+var askerPromptTmpl []byte
+
 //go:embed templates/coder.md.tpl
 var coderPromptTmpl []byte
 
@@ -32,7 +38,22 @@ func reviewerPrompt(opts ...prompt.Option) (*prompt.Prompt, error) {
 	return systemPrompt, nil
 }
 
-// TODO(PlanC): Rename to builderPrompt for build mode
+func fixerPrompt(opts ...prompt.Option) (*prompt.Prompt, error) {
+	systemPrompt, err := prompt.NewPrompt("fixer", string(fixerPromptTmpl), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return systemPrompt, nil
+}
+
+func askerPrompt(opts ...prompt.Option) (*prompt.Prompt, error) {
+	systemPrompt, err := prompt.NewPrompt("asker", string(askerPromptTmpl), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return systemPrompt, nil
+}
+
 func coderPrompt(opts ...prompt.Option) (*prompt.Prompt, error) {
 	systemPrompt, err := prompt.NewPrompt("coder", string(coderPromptTmpl), opts...)
 	if err != nil {
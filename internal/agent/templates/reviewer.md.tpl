@@ -59,6 +59,7 @@ When reviewing code changes (PR/diff):
 - Identify breaking changes (signature changes, removed exports, etc.)
 - Check if changes affect other files/modules
 - Verify consistency with existing patterns
+- Use Bash for history and checks: `git log`/`git blame` to learn why code is the way it is, `go vet` or `go test -run` on the affected packages. Bash is a read-only sandbox; a refused command returns an error, don't retry it
 
 **Step 4: Categorize Issues**
 - **Security**: Vulnerabilities, secret exposure, injection risks
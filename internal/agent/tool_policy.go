@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"charm.land/fantasy"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/permission"
+)
+
+// wrapPolicyTools makes the calls the project's permission policy denies come back to the agent
+// as tool errors; a denial by the user still ends the run
+func wrapPolicyTools(permissions permission.Service, agentTools []fantasy.AgentTool) []fantasy.AgentTool {
+	return lo.Map(agentTools, func(tool fantasy.AgentTool, _ int) fantasy.AgentTool {
+		return &policyTool{AgentTool: tool, permissions: permissions}
+	})
+}
+
+// policyTool is a tool whose policy denials are reported to the agent
+type policyTool struct {
+	fantasy.AgentTool
+	permissions permission.Service
+}
+
+// Run implements fantasy.AgentTool.
+func (t *policyTool) Run(ctx context.Context, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+	response, err := t.AgentTool.Run(ctx, call)
+	if !errors.Is(err, permission.ErrorPermissionDenied) {
+		return response, err
+	}
+	rule, denied := t.permissions.PolicyDenial(call.ID)
+	if !denied {
+		return response, err
+	}
+	return fantasy.NewTextErrorResponse(fmt.Sprintf(
+		"%s was denied by the project's permission policy (%s). Do not retry it; find another way or tell the user what you needed.",
+		call.Name, rule)), nil
+}
@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"charm.land/fantasy"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/csync"
+	"github.com/trankhanh040147/revcli/internal/filter"
+	"github.com/trankhanh040147/revcli/internal/message"
+)
+
+// secretGuard scans tool results, MCP ones included, for secrets before they are sent to the model
+type secretGuard struct {
+	scanner atomic.Pointer[filter.Scanner]
+	// mode is filter.SecretsModeRedact or filter.SecretsModeBlock
+	mode string
+	// redactions holds what was withheld from each tool call until its result is stored
+	redactions *csync.Map[string, []message.Redaction]
+}
+
+// newSecretGuard creates the guard for the secrets config; nil when tool results are not scanned
+func newSecretGuard(cfg *config.SecretsConfig) (*secretGuard, error) {
+	mode := filter.SecretsModeRedact
+	if cfg != nil && cfg.ToolResults != "" {
+		mode = cfg.ToolResults
+	}
+	switch mode {
+	case filter.SecretsModeOff:
+		return nil, nil
+	case filter.SecretsModeRedact, filter.SecretsModeBlock:
+	default:
+		return nil, fmt.Errorf("invalid options.secrets.tool_results %q (supported: redact, block, off)", mode)
+	}
+
+	scanner, err := filter.NewScanner(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	g := &secretGuard{
+		mode:       mode,
+		redactions: csync.NewMap[string, []message.Redaction](),
+	}
+	g.scanner.Store(scanner)
+	return g, nil
+}
+
+// wrap makes the tools' results pass through the guard
+func (g *secretGuard) wrap(agentTools []fantasy.AgentTool) []fantasy.AgentTool {
+	if g == nil {
+		return agentTools
+	}
+	return lo.Map(agentTools, func(tool fantasy.AgentTool, _ int) fantasy.AgentTool {
+		return &guardedTool{AgentTool: tool, guard: g}
+	})
+}
+
+// check redacts the secrets in a tool response, or withholds the whole response in block mode
+func (g *secretGuard) check(call fantasy.ToolCall, response fantasy.ToolResponse) fantasy.ToolResponse {
+	matches := g.scanner.Load().Scan(call.Name, response.Content)
+	if len(matches) == 0 {
+		return response
+	}
+
+	redactions := lo.Map(matches, func(m filter.SecretMatch, _ int) message.Redaction {
+		return message.Redaction{RuleID: m.RuleID, Match: m.Match, Line: m.Line}
+	})
+	ruleIDs := lo.Uniq(lo.Map(matches, func(m filter.SecretMatch, _ int) string { return m.RuleID }))
+	slog.Warn("Withheld secrets from a tool result", "tool", call.Name, "mode", g.mode, "matches", len(matches), "rules", ruleIDs)
+
+	if g.mode == filter.SecretsModeBlock {
+		redactions = lo.Map(redactions, func(r message.Redaction, _ int) message.Redaction {
+			r.Blocked = true
+			return r
+		})
+		g.redactions.Set(call.ID, redactions)
+		blocked := fantasy.NewTextErrorResponse(fmt.Sprintf(
+			"The output of %s was withheld: it contains %d potential secret(s) (%s). Do not try to read secrets; ask the user if you need the value.",
+			call.Name, len(matches), strings.Join(ruleIDs, ", ")))
+		// The metadata is not sent, but the TUI renders it and the session stores it
+		blocked.Metadata = filter.Redact(response.Metadata, matches)
+		return blocked
+	}
+
+	g.redactions.Set(call.ID, redactions)
+	response.Content = filter.Redact(response.Content, matches)
+	response.Metadata = filter.Redact(response.Metadata, matches)
+	return response
+}
+
+// take returns and forgets what was withheld from a tool call
+func (g *secretGuard) take(toolCallID string) []message.Redaction {
+	if g == nil {
+		return nil
+	}
+	redactions, _ := g.redactions.Take(toolCallID)
+	return redactions
+}
+
+// guardedTool is a tool whose results pass through a secretGuard
+type guardedTool struct {
+	fantasy.AgentTool
+	guard *secretGuard
+}
+
+// Run implements fantasy.AgentTool.
+func (t *guardedTool) Run(ctx context.Context, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+	response, err := t.AgentTool.Run(ctx, call)
+	if err != nil {
+		return response, err
+	}
+	return t.guard.check(call, response), nil
+}
@@ -5,6 +5,7 @@ import (
 	"cmp"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"html/template"
 	"os"
@@ -63,6 +64,8 @@ type bashDescriptionData struct {
 	MaxOutputLength int
 	Attribution     config.Attribution
 	ModelName       string
+	// SandboxCommands lists the allowed commands when the tool runs in a sandbox
+	SandboxCommands []string
 }
 
 var bannedCommands = []string{
@@ -138,15 +141,19 @@ var bannedCommands = []string{
 	"ufw",
 }
 
-func bashDescription(attribution *config.Attribution, modelName string) string {
+func bashDescription(attribution *config.Attribution, modelName string, sandbox *shell.Sandbox) string {
 	bannedCommandsStr := strings.Join(bannedCommands, ", ")
-	var out bytes.Buffer
-	if err := bashDescriptionTpl.Execute(&out, bashDescriptionData{
+	data := bashDescriptionData{
 		BannedCommands:  bannedCommandsStr,
 		MaxOutputLength: MaxOutputLength,
 		Attribution:     *attribution,
 		ModelName:       modelName,
-	}); err != nil {
+	}
+	if sandbox != nil {
+		data.SandboxCommands = sandbox.Commands()
+	}
+	var out bytes.Buffer
+	if err := bashDescriptionTpl.Execute(&out, data); err != nil {
 		// this should never happen.
 		panic("failed to execute bash description template: " + err.Error())
 	}
@@ -185,10 +192,12 @@ func blockFuncs() []shell.BlockFunc {
 	}
 }
 
-func NewBashTool(permissions permission.Service, workingDir string, attribution *config.Attribution, modelName string) fantasy.AgentTool {
+// NewBashTool creates the bash tool. With a sandbox, commands run without permission prompts and
+// the ones the sandbox refuses are returned to the agent as tool errors.
+func NewBashTool(permissions permission.Service, workingDir string, attribution *config.Attribution, modelName string, sandbox *shell.Sandbox) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		constants.BashToolName,
-		string(bashDescription(attribution, modelName)),
+		string(bashDescription(attribution, modelName, sandbox)),
 		func(ctx context.Context, params BashParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Command == "" {
 				return fantasy.NewTextErrorResponse("missing command"), nil
@@ -213,21 +222,24 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			if sessionID == "" {
 				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for executing shell command")
 			}
-			if !isSafeReadOnly {
-				p := permissions.Request(
-					permission.CreatePermissionRequest{
-						SessionID:   sessionID,
-						Path:        execWorkingDir,
-						ToolCallID:  call.ID,
-						ToolName:    constants.BashToolName,
-						Action:      "execute",
-						Description: fmt.Sprintf("Execute command: %s", params.Command),
-						Params:      BashPermissionsParams(params),
-					},
-				)
-				if !p {
-					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
-				}
+			request := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        execWorkingDir,
+				ToolCallID:  call.ID,
+				ToolName:    constants.BashToolName,
+				Action:      "execute",
+				Description: fmt.Sprintf("Execute command: %s", params.Command),
+				Params:      BashPermissionsParams(params),
+			}
+			// Safe and sandboxed commands run without asking, unless the project's policy says otherwise
+			var granted bool
+			if isSafeReadOnly || sandbox != nil {
+				granted = permissions.Check(request)
+			} else {
+				granted = permissions.Request(request)
+			}
+			if !granted {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			// If explicitly requested as background, start immediately with detached context
@@ -236,7 +248,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 				bgManager := shell.GetBackgroundShellManager()
 				bgManager.Cleanup()
 				// Use background context so it continues after tool returns
-				bgShell, err := bgManager.Start(context.Background(), execWorkingDir, blockFuncs(), params.Command, params.Description)
+				bgShell, err := bgManager.StartSandboxed(context.Background(), execWorkingDir, blockFuncs(), sandbox, params.Command, params.Description)
 				if err != nil {
 					return fantasy.ToolResponse{}, fmt.Errorf("error starting background shell: %w", err)
 				}
@@ -248,6 +260,9 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 				if done {
 					// Command failed or completed very quickly
 					bgManager.Remove(bgShell.ID)
+					if violation, ok := sandboxViolation(execErr); ok {
+						return violation, nil
+					}
 
 					interrupted := shell.IsInterrupt(execErr)
 					exitCode := shell.ExitCode(execErr)
@@ -291,7 +306,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			// Start with detached context so it can survive if moved to background
 			bgManager := shell.GetBackgroundShellManager()
 			bgManager.Cleanup()
-			bgShell, err := bgManager.Start(context.Background(), execWorkingDir, blockFuncs(), params.Command, params.Description)
+			bgShell, err := bgManager.StartSandboxed(context.Background(), execWorkingDir, blockFuncs(), sandbox, params.Command, params.Description)
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error starting shell: %w", err)
 			}
@@ -329,6 +344,9 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 				// Remove from background manager since we're returning directly
 				// Don't call Kill() as it cancels the context and corrupts the exit code
 				bgManager.Remove(bgShell.ID)
+				if violation, ok := sandboxViolation(execErr); ok {
+					return violation, nil
+				}
 
 				interrupted := shell.IsInterrupt(execErr)
 				exitCode := shell.ExitCode(execErr)
@@ -367,6 +385,15 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 		})
 }
 
+// sandboxViolation turns a command refused by the sandbox into a tool error for the agent
+func sandboxViolation(execErr error) (fantasy.ToolResponse, bool) {
+	var violation *shell.SandboxError
+	if !errors.As(execErr, &violation) {
+		return fantasy.ToolResponse{}, false
+	}
+	return fantasy.NewTextErrorResponse(violation.Error() + ". Use only the allowed read-only commands listed in the tool description."), true
+}
+
 // formatOutput formats the output of a completed command with error handling
 func formatOutput(stdout, stderr string, execErr error) string {
 	interrupted := shell.IsInterrupt(execErr)
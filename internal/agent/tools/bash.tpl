@@ -6,7 +6,15 @@ Use forward slashes for paths: "ls C:/foo/bar" not "ls C:\foo\bar".
 Common shell builtins and core utils available on Windows.
 </cross_platform>
 
-<execution_steps>
+{{ if .SandboxCommands }}<sandbox>
+Runs in a read-only sandbox: the working tree cannot be changed and nothing asks the user for permission.
+Only these commands may run (builtins like cd, echo and test work too):
+{{ range .SandboxCommands }}- {{ . }}
+{{ end }}Redirections may only write to $TMPDIR, which is removed after each call. Commands run with a fixed environment; don't set variables for them.
+Refused commands return an error - don't retry them, use another tool or command.
+</sandbox>
+
+{{ end }}<execution_steps>
 1. Directory Verification: If creating directories/files, use LS tool to verify parent exists
 2. Security Check: Banned commands ({{ .BannedCommands }}) return error - explain to user. Safe read-only commands execute without prompts
 3. Command Execution: Execute with proper quoting, capture output
@@ -42,7 +50,7 @@ Common shell builtins and core utils available on Windows.
   * Short-lived scripts
 </background_execution>
 
-<git_commits>
+{{ if not .SandboxCommands }}<git_commits>
 When user asks to create git commit:
 
 1. Single message with three tool_use blocks (IMPORTANT for speed):
@@ -135,6 +143,7 @@ Important:
 - Return empty response - user sees gh output
 - Never update git config
 </pull_requests>
+{{ end }}
 
 <examples>
 Good: pytest /foo/bar/tests
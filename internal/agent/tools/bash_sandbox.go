@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"github.com/trankhanh040147/revcli/internal/shell"
+)
+
+// goTestOutputFlags write files, either directly or as test binary flags after -args
+var goTestOutputFlags = []string{"coverprofile", "cpuprofile", "memprofile", "blockprofile", "mutexprofile", "trace", "outputdir"}
+
+// ReviewSandbox is the read-only policy of the reviewer's bash: reading files and history,
+// vetting, and running chosen tests. Module downloads are off, and tests run without network
+// access (only where the OS can isolate them; elsewhere go test is refused).
+func ReviewSandbox() *shell.Sandbox {
+	goTestDenied := []string{"exec", "toolexec", "vettool", "mod", "o", "c", "fuzz", "update"}
+	for _, flag := range goTestOutputFlags {
+		goTestDenied = append(goTestDenied, flag, "test."+flag)
+	}
+
+	return &shell.Sandbox{
+		Rules: []shell.CommandRule{
+			{
+				Command:     "git",
+				Subcommands: []string{"log", "show", "blame", "diff", "shortlog", "ls-files", "rev-parse"},
+				DeniedFlags: []string{"output", "ext-diff"},
+			},
+			{Command: "go", Subcommands: []string{"vet"}, DeniedFlags: []string{"exec", "toolexec", "vettool", "mod"}},
+			{Command: "go", Subcommands: []string{"test"}, RequiredFlags: []string{"run"}, DeniedFlags: goTestDenied, NoNetwork: true},
+			{Command: "grep"},
+			{Command: "rg", DeniedFlags: []string{"pre"}},
+			{Command: "find", DeniedFlags: []string{"exec", "execdir", "ok", "okdir", "delete", "fprint", "fprint0", "fprintf", "fls"}},
+			{Command: "cat"},
+			{Command: "head"},
+			{Command: "tail"},
+			{Command: "wc"},
+			{Command: "ls"},
+			{Command: "diff"},
+		},
+		Env: []string{
+			"GOPROXY=off",
+			"GOFLAGS=",
+			"GOTOOLCHAIN=local",
+			"GIT_PAGER=cat",
+			"PAGER=cat",
+			"GIT_OPTIONAL_LOCKS=0",
+			"GIT_NO_LAZY_FETCH=1",
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trankhanh040147/revcli/internal/shell"
+)
+
+func runSandboxed(t *testing.T, workingDir, command string) (string, error) {
+	t.Helper()
+	sh := shell.NewShell(&shell.Options{WorkingDir: workingDir, Sandbox: ReviewSandbox()})
+	stdout, stderr, err := sh.Exec(context.Background(), command)
+	return stdout + stderr, err
+}
+
+func TestReviewSandbox_AllowsReadOnlyCommands(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "main.go"), []byte("package main\n"), 0o644))
+
+	tests := []string{
+		"cat main.go",
+		"grep -n package main.go | wc -l",
+		"echo note > $TMPDIR/note && cat $TMPDIR/note",
+		"ls > /dev/null",
+		"cd $TMPDIR && echo ok > out.txt",
+	}
+	for _, command := range tests {
+		t.Run(command, func(t *testing.T) {
+			t.Parallel()
+			_, err := runSandboxed(t, workingDir, command)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReviewSandbox_RefusesWrites(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	tests := map[string]string{
+		"redirect into the tree":    "echo x > main.go",
+		"append into the tree":      "echo x >> notes.txt",
+		"command not allowed":       "touch main.go",
+		"git subcommand":            "git commit -m x",
+		"git global option":         "git -c core.pager=sh log",
+		"go test without -run":      "go test ./...",
+		"go test with -exec":        "go test -run TestX -exec sh ./...",
+		"go test output file":       "go test -run TestX -coverprofile=c.out ./...",
+		"find with -delete":         "find . -name '*.go' -delete",
+		"changed environment":       "GOFLAGS=-mod=mod go vet ./...",
+		"exported environment":      "export GIT_EXTERNAL_DIFF=sh; git diff",
+		"absolute path":             "/bin/rm -rf .",
+		"in a command substitution": "echo $(touch main.go)",
+	}
+	for name, command := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := runSandboxed(t, workingDir, command)
+			var violation *shell.SandboxError
+			require.ErrorAs(t, err, &violation)
+		})
+	}
+
+	entries, err := os.ReadDir(workingDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestReviewSandbox_TestsHaveNoNetwork(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "go.mod"), []byte("module example.com/probe\n\ngo 1.21\n"), 0o644))
+	probe := fmt.Sprintf(`package probe
+
+import (
+	"net"
+	"testing"
+)
+
+func TestOffline(t *testing.T) {}
+
+func TestDial(t *testing.T) {
+	conn, err := net.Dial("tcp", %q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
+`, listener.Addr().String())
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "probe_test.go"), []byte(probe), 0o644))
+
+	output, err := runSandboxed(t, workingDir, "go test -run TestDial .")
+	require.Error(t, err, output)
+	var violation *shell.SandboxError
+	if !errors.As(err, &violation) {
+		// Isolation is available: the test ran, and its connection failed
+		require.Contains(t, output, "dial tcp")
+		_, err = runSandboxed(t, workingDir, "go test -run TestOffline .")
+		require.NoError(t, err)
+	}
+
+	accepted := make(chan struct{})
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+			close(accepted)
+		}
+	}()
+	select {
+	case <-accepted:
+		t.Fatal("a sandboxed test reached the listener")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
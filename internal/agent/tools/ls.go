@@ -94,6 +94,16 @@ func NewLsTool(permissions permission.Service, workingDir string, lsConfig confi
 				if !granted {
 					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 				}
+			} else if !permissions.Check(permission.CreatePermissionRequest{
+				SessionID:   GetSessionFromContext(ctx),
+				Path:        absSearchPath,
+				ToolCallID:  call.ID,
+				ToolName:    constants.LSToolName,
+				Action:      "list",
+				Description: fmt.Sprintf("List directory: %s", absSearchPath),
+				Params:      LSPermissionsParams(params),
+			}) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			output, metadata, err := ListDirectoryTree(searchPath, params, lsConfig)
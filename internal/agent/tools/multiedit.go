@@ -116,6 +116,41 @@ func validateEdits(edits []MultiEditOperation) error {
 	return nil
 }
 
+// PreviewMultiEdit applies edits to content without touching the disk (dry run). When the first
+// edit has an empty old_string it creates the file, and content is ignored.
+func PreviewMultiEdit(content string, edits []MultiEditOperation) (string, []FailedEdit, error) {
+	if len(edits) == 0 {
+		return "", nil, fmt.Errorf("at least one edit operation is required")
+	}
+	if err := validateEdits(edits); err != nil {
+		return "", nil, err
+	}
+	if edits[0].OldString == "" {
+		newContent, failedEdits := applyEdits(edits[0].NewString, edits, 1)
+		return newContent, failedEdits, nil
+	}
+	newContent, failedEdits := applyEdits(content, edits, 0)
+	return newContent, failedEdits, nil
+}
+
+// applyEdits applies edits[from:] sequentially, skipping and reporting the ones that fail
+func applyEdits(content string, edits []MultiEditOperation, from int) (string, []FailedEdit) {
+	var failedEdits []FailedEdit
+	for i := from; i < len(edits); i++ {
+		newContent, err := applyEditToContent(content, edits[i])
+		if err != nil {
+			failedEdits = append(failedEdits, FailedEdit{
+				Index: i + 1,
+				Error: err.Error(),
+				Edit:  edits[i],
+			})
+			continue
+		}
+		content = newContent
+	}
+	return content, failedEdits
+}
+
 func processMultiEditWithCreation(edit editContext, params MultiEditParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 	// First edit creates the file
 	firstEdit := params.Edits[0]
@@ -136,24 +171,8 @@ func processMultiEditWithCreation(edit editContext, params MultiEditParams, call
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to create parent directories: %w", err)
 	}
 
-	// Start with the content from the first edit
-	currentContent := firstEdit.NewString
-
-	// Apply remaining edits to the content, tracking failures
-	var failedEdits []FailedEdit
-	for i := 1; i < len(params.Edits); i++ {
-		edit := params.Edits[i]
-		newContent, err := applyEditToContent(currentContent, edit)
-		if err != nil {
-			failedEdits = append(failedEdits, FailedEdit{
-				Index: i + 1,
-				Error: err.Error(),
-				Edit:  edit,
-			})
-			continue
-		}
-		currentContent = newContent
-	}
+	// Start with the content from the first edit and apply the remaining ones, tracking failures
+	currentContent, failedEdits := applyEdits(firstEdit.NewString, params.Edits, 1)
 
 	// Get session and message IDs
 	sessionID := GetSessionFromContext(edit.ctx)
@@ -264,22 +283,9 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 	}
 
 	oldContent, isCrlf := fsext.ToUnixLineEndings(string(content))
-	currentContent := oldContent
 
 	// Apply all edits sequentially, tracking failures
-	var failedEdits []FailedEdit
-	for i, edit := range params.Edits {
-		newContent, err := applyEditToContent(currentContent, edit)
-		if err != nil {
-			failedEdits = append(failedEdits, FailedEdit{
-				Index: i + 1,
-				Error: err.Error(),
-				Edit:  edit,
-			})
-			continue
-		}
-		currentContent = newContent
-	}
+	currentContent, failedEdits := applyEdits(oldContent, params.Edits, 0)
 
 	// Check if content actually changed
 	if oldContent == currentContent {
@@ -22,6 +22,14 @@ func (m *mockPermissionService) Request(req permission.CreatePermissionRequest)
 	return true
 }
 
+func (m *mockPermissionService) Check(req permission.CreatePermissionRequest) bool {
+	return true
+}
+
+func (m *mockPermissionService) PolicyDenial(toolCallID string) (string, bool) {
+	return "", false
+}
+
 func (m *mockPermissionService) Grant(req permission.PermissionRequest) {}
 
 func (m *mockPermissionService) Deny(req permission.PermissionRequest) {}
@@ -223,3 +231,28 @@ func TestMultiEditAllEditsFail(t *testing.T) {
 	require.Len(t, failedEdits, 2)
 	require.Equal(t, content, currentContent, "Content should be unchanged")
 }
+
+func TestPreviewMultiEdit(t *testing.T) {
+	t.Parallel()
+
+	newContent, failedEdits, err := PreviewMultiEdit("line 1\nline 2\n", []MultiEditOperation{
+		{OldString: "line 1", NewString: "LINE 1"},
+		{OldString: "line 99", NewString: "LINE 99"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "LINE 1\nline 2\n", newContent)
+	require.Len(t, failedEdits, 1)
+	require.Equal(t, 2, failedEdits[0].Index)
+
+	// An empty first old_string creates the file from scratch
+	newContent, failedEdits, err = PreviewMultiEdit("ignored", []MultiEditOperation{
+		{OldString: "", NewString: "package main\n"},
+		{OldString: "main", NewString: "util"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, failedEdits)
+	require.Equal(t, "package util\n", newContent)
+
+	_, _, err = PreviewMultiEdit("x", []MultiEditOperation{{OldString: "x", NewString: "y"}, {OldString: "", NewString: "z"}})
+	require.Error(t, err)
+}
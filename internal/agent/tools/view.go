@@ -102,6 +102,16 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 				if !granted {
 					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 				}
+			} else if !permissions.Check(permission.CreatePermissionRequest{
+				SessionID:   GetSessionFromContext(ctx),
+				Path:        absFilePath,
+				ToolCallID:  call.ID,
+				ToolName:    constants.ViewToolName,
+				Action:      "read",
+				Description: fmt.Sprintf("Read file: %s", absFilePath),
+				Params:      ViewPermissionsParams(params),
+			}) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			// Check if file exists
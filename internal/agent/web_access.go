@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"context"
+	"slices"
+
+	"charm.land/fantasy"
+
+	"github.com/trankhanh040147/revcli/internal/agent/tools/constants"
+)
+
+// webToolNames are the tools that reach the web; they are only offered while web access is on
+var webToolNames = []string{constants.WebSearchToolName, constants.FetchToolName, constants.AgenticFetchToolName}
+
+type webAccessKey struct{}
+
+// WithWebAccess returns a context whose agent runs are (or are not) offered the web tools
+func WithWebAccess(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, webAccessKey{}, enabled)
+}
+
+// WebAccess reports whether runs with ctx may use the web tools; on unless turned off with WithWebAccess
+func WebAccess(ctx context.Context) bool {
+	enabled, ok := ctx.Value(webAccessKey{}).(bool)
+	return !ok || enabled
+}
+
+// IsWebTool reports whether the named tool reaches the web
+func IsWebTool(name string) bool {
+	return slices.Contains(webToolNames, name)
+}
+
+// withoutWebTools drops the web tools from a tool set
+func withoutWebTools(agentTools []fantasy.AgentTool) []fantasy.AgentTool {
+	return slices.DeleteFunc(slices.Clone(agentTools), func(tool fantasy.AgentTool) bool {
+		return IsWebTool(tool.Info().Name)
+	})
+}
+
+// usedWebTool reports whether a step called any of the web tools
+func usedWebTool(step fantasy.StepResult) bool {
+	return slices.ContainsFunc(step.Content.ToolCalls(), func(call fantasy.ToolCallContent) bool {
+		return IsWebTool(call.ToolName)
+	})
+}
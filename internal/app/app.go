@@ -34,6 +34,7 @@ import (
 	"github.com/trankhanh040147/revcli/internal/pubsub"
 	"github.com/trankhanh040147/revcli/internal/session"
 	"github.com/trankhanh040147/revcli/internal/shell"
+	"github.com/trankhanh040147/revcli/internal/triage"
 	"github.com/trankhanh040147/revcli/internal/tui/components/anim"
 	"github.com/trankhanh040147/revcli/internal/tui/styles"
 	"github.com/trankhanh040147/revcli/internal/update"
@@ -45,6 +46,9 @@ type App struct {
 	Messages    message.Service
 	History     history.Service
 	Permissions permission.Service
+	// PermissionAudit logs every permission decision
+	PermissionAudit permission.AuditLog
+	Triage          triage.Service
 
 	AgentCoordinator agent.Coordinator
 
@@ -73,13 +77,21 @@ func New(ctx context.Context, conn *sql.DB, cfg *config.Config) (*App, error) {
 	if cfg.Permissions != nil && cfg.Permissions.AllowedTools != nil {
 		allowedTools = cfg.Permissions.AllowedTools
 	}
+	policy, err := permission.LoadPolicy(cfg.WorkingDir())
+	if err != nil {
+		return nil, err
+	}
+	audit := permission.NewAuditLog(q)
 
 	app := &App{
-		Sessions:    sessions,
-		Messages:    messages,
-		History:     files,
-		Permissions: permission.NewPermissionService(cfg.WorkingDir(), skipPermissionsRequests, allowedTools),
-		LSPClients:  csync.NewMap[string, *lsp.Client](),
+		Sessions: sessions,
+		Messages: messages,
+		History:  files,
+		Permissions: permission.NewPermissionService(cfg.WorkingDir(), skipPermissionsRequests, allowedTools,
+			permission.WithPolicy(policy), permission.WithAuditLog(audit)),
+		PermissionAudit: audit,
+		Triage:          triage.NewService(q),
+		LSPClients:      csync.NewMap[string, *lsp.Client](),
 
 		globalCtx: ctx,
 
@@ -95,8 +107,10 @@ func New(ctx context.Context, conn *sql.DB, cfg *config.Config) (*App, error) {
 	// Initialize LSP clients in the background.
 	app.initLSPClients(ctx)
 
-	// Check for updates in the background.
-	go app.checkForUpdates(ctx)
+	// Check for updates in the background; offline mode never does.
+	if !cfg.Options.Offline {
+		go app.checkForUpdates(ctx)
+	}
 
 	go func() {
 		slog.Info("Initializing MCP clients")
@@ -123,8 +137,9 @@ func (app *App) Config() *config.Config {
 }
 
 // RunNonInteractive runs the application in non-interactive mode with the
-// given prompt, printing to stdout.
-func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt string, quiet bool) error {
+// given prompt, printing to stdout. It runs in sessionID, or in a new session
+// when sessionID is empty.
+func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, sessionID, prompt string, quiet bool) error {
 	slog.Info("Running in non-interactive mode")
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -175,22 +190,10 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 	}
 	defer stopSpinner()
 
-	const maxPromptLengthForTitle = 100
-	const titlePrefix = "Non-interactive: "
-	var titleSuffix string
-
-	if len(prompt) > maxPromptLengthForTitle {
-		titleSuffix = prompt[:maxPromptLengthForTitle] + "..."
-	} else {
-		titleSuffix = prompt
-	}
-	title := titlePrefix + titleSuffix
-
-	sess, err := app.Sessions.Create(ctx, title)
+	sess, err := app.nonInteractiveSession(ctx, sessionID, prompt)
 	if err != nil {
-		return fmt.Errorf("failed to create session for non-interactive mode: %w", err)
+		return err
 	}
-	slog.Info("Created session for non-interactive run", "session_id", sess.ID)
 
 	// Automatically approve all permission requests for this non-interactive
 	// session.
@@ -276,6 +279,32 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 	}
 }
 
+// nonInteractiveSession returns the session a non-interactive run uses, creating one titled
+// after the prompt when sessionID is empty
+func (app *App) nonInteractiveSession(ctx context.Context, sessionID, prompt string) (session.Session, error) {
+	if sessionID != "" {
+		return app.Sessions.Get(ctx, sessionID)
+	}
+
+	const maxPromptLengthForTitle = 100
+	const titlePrefix = "Non-interactive: "
+	var titleSuffix string
+
+	if len(prompt) > maxPromptLengthForTitle {
+		titleSuffix = prompt[:maxPromptLengthForTitle] + "..."
+	} else {
+		titleSuffix = prompt
+	}
+	title := titlePrefix + titleSuffix
+
+	sess, err := app.Sessions.Create(ctx, title)
+	if err != nil {
+		return session.Session{}, fmt.Errorf("failed to create session for non-interactive mode: %w", err)
+	}
+	slog.Info("Created session for non-interactive run", "session_id", sess.ID)
+	return sess, nil
+}
+
 func (app *App) UpdateAgentModel(ctx context.Context) error {
 	if app.AgentCoordinator == nil {
 		return fmt.Errorf("agent configuration is missing")
@@ -340,20 +369,26 @@ func (app *App) InitReviewerAgent(ctx context.Context) error {
 		return fmt.Errorf("reviewer agent configuration is missing")
 	}
 	var err error
-	app.AgentCoordinator, err = agent.NewCoordinator(
+	app.AgentCoordinator, err = app.NewCoordinator(ctx, app.config)
+	if err != nil {
+		slog.Error("Failed to create reviewer agent", "err", err)
+		return err
+	}
+	return nil
+}
+
+// NewCoordinator builds a coordinator for cfg that shares the app's services, e.g. to run the same
+// review with another model (see config.WithLargeModel)
+func (app *App) NewCoordinator(ctx context.Context, cfg *config.Config) (agent.Coordinator, error) {
+	return agent.NewCoordinator(
 		ctx,
-		app.config,
+		cfg,
 		app.Sessions,
 		app.Messages,
 		app.Permissions,
 		app.History,
 		app.LSPClients,
 	)
-	if err != nil {
-		slog.Error("Failed to create reviewer agent", "err", err)
-		return err
-	}
-	return nil
 }
 
 // Subscribe sends events to the TUI as tea.Msgs.
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/preset"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+// configCmd groups the commands that manage the revcli config
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the revcli configuration",
+	Long: `revcli reads one layered configuration. Later layers override earlier ones:
+
+  1. ~/.config/revcli/revcli.json        global config
+  2. ~/.local/share/revcli/revcli.json   settings revcli saves (model, telemetry, default preset)
+  3. revcli.json or .revcli.json         project config, looked up from the working directory
+  4. REVCLI_* environment variables      REVCLI_DEFAULT_PRESET, REVCLI_TEMPERATURE, REVCLI_TOP_P, REVCLI_TOP_K, REVCLI_OFFLINE
+  5. flags                               --preset, --model, --temperature, --top-p, --top-k, --offline`,
+}
+
+// configMigrateCmd moves the settings of the legacy YAML config into revcli.json
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move the settings of ~/.config/revcli/config.yaml into revcli.json",
+	Long: `Move the settings of the legacy ~/.config/revcli/config.yaml into the global
+data config (~/.local/share/revcli/revcli.json):
+
+  default_preset               options.default_preset
+  gemini.model_params          options.generation (applied to every provider)
+  gemini.safety_settings       providers.gemini.provider_options.safety_settings
+  secrets                      options.secrets
+
+Settings already in revcli.json are kept. The YAML file is renamed to config.yaml.bak.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+func init() {
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	legacyPath, err := preset.LegacyConfigPath()
+	if err != nil {
+		return err
+	}
+	fields, found, err := preset.LegacyMigration()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", legacyPath, err)
+	}
+	if !found {
+		fmt.Println(ui.RenderSubtitle(fmt.Sprintf("No legacy config at %s; nothing to migrate.", legacyPath)))
+		return nil
+	}
+
+	for _, field := range fields {
+		if config.HasGlobalField(field.Key) {
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("Kept %s: already set in %s", field.Key, config.GlobalConfigData())))
+			continue
+		}
+		if err := config.SetGlobalField(field.Key, field.Value); err != nil {
+			return err
+		}
+		fmt.Println(ui.RenderSuccess("Migrated " + field.Key))
+	}
+
+	backupPath := legacyPath + ".bak"
+	if err := os.Rename(legacyPath, backupPath); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", legacyPath, err)
+	}
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Settings are now in %s; the old file is at %s", config.GlobalConfigData(), backupPath)))
+	return nil
+}
+
+// warnLegacyConfig points to "revcli config migrate" while a legacy YAML config exists, as it is no longer read
+func warnLegacyConfig() {
+	legacyPath, err := preset.LegacyConfigPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(legacyPath); err == nil {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s is no longer read; run 'revcli config migrate' to move its settings into revcli.json", legacyPath)))
+	}
+}
+
+// applyConfigFlags applies the flags that override the config for this run, the last config layer
+func applyConfigFlags(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("model") {
+		id, _ := flags.GetString("model")
+		if err := cfg.SelectLargeModel(id); err != nil {
+			return err
+		}
+	}
+	gen := cfg.Options.Generation
+	if flags.Changed("temperature") {
+		v, _ := flags.GetFloat64("temperature")
+		gen.Temperature = &v
+	}
+	if flags.Changed("top-p") {
+		v, _ := flags.GetFloat64("top-p")
+		gen.TopP = &v
+	}
+	if flags.Changed("top-k") {
+		v, _ := flags.GetInt64("top-k")
+		gen.TopK = &v
+	}
+	return nil
+}
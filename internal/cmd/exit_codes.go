@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Process exit codes, so CI steps and git hooks can tell outcomes apart
+const (
+	ExitOK               = 0
+	ExitFailure          = 1
+	ExitThresholdReached = 2
+	ExitSecretsDetected  = 3
+	ExitNoChanges        = 4
+)
+
+// ErrThresholdReached is returned when review findings hit the --fail-on threshold
+var ErrThresholdReached = fmt.Errorf("review findings reached the fail-on threshold")
+
+// ErrNoChanges is returned when there is nothing to review and --fail-on is set
+var ErrNoChanges = fmt.Errorf("no changes to review")
+
+// exitCode maps a command error onto a process exit code
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrThresholdReached):
+		return ExitThresholdReached
+	case errors.Is(err, ErrSecretsDetected):
+		return ExitSecretsDetected
+	case errors.Is(err, ErrNoChanges):
+		return ExitNoChanges
+	default:
+		return ExitFailure
+	}
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/hook"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+// hookCmd groups the git hook commands
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Review changes in git pre-commit and pre-push hooks",
+	Long: `Install git hooks that review changes before they leave your machine:
+
+  pre-commit  reviews the staged changes (revcli review --staged), by default
+              with the quick preset and the small model
+  pre-push    reviews the commits since the upstream branch
+              (revcli review --base @{upstream})
+
+A hook blocks when findings reach its fail_on severity (default: critical) or
+secrets are detected. A review that fails or times out does not block. Changes
+that passed are remembered, so an unchanged diff is not reviewed twice.
+
+Hooks that are already installed keep running, before the review. Skip the
+review once with REVCLI_SKIP_HOOKS=1 or git's --no-verify.
+
+Settings live in options.hooks of revcli.json:
+
+  {"options": {"hooks": {"pre_commit": {"preset": "quick", "model": "small",
+    "fail_on": "critical", "timeout": "2m"}}}}`,
+}
+
+// hookInstallCmd installs the managed hooks
+var hookInstallCmd = &cobra.Command{
+	Use:       "install [hook...]",
+	Short:     "Install the pre-commit and pre-push hooks (or the ones named)",
+	ValidArgs: hook.Names,
+	RunE:      runHookInstall,
+}
+
+// hookUninstallCmd removes the managed hooks
+var hookUninstallCmd = &cobra.Command{
+	Use:       "uninstall [hook...]",
+	Short:     "Remove the hooks revcli installed and restore the ones they replaced",
+	ValidArgs: hook.Names,
+	RunE:      runHookUninstall,
+}
+
+// hookStatusCmd shows the hooks and their settings
+var hookStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which hooks are installed and how they review",
+	Args:  cobra.NoArgs,
+	RunE:  runHookStatus,
+}
+
+// hookRunCmd is what the installed hooks run
+var hookRunCmd = &cobra.Command{
+	Use:       "run <hook>",
+	Short:     "Run the review of a hook",
+	Hidden:    true,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: hook.Names,
+	RunE:      runHookRun,
+}
+
+func init() {
+	hookCmd.AddCommand(hookInstallCmd, hookUninstallCmd, hookStatusCmd, hookRunCmd)
+}
+
+// hookNames returns the hooks named in args, or all of them
+func hookNames(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return hook.Names, nil
+	}
+	for _, name := range args {
+		if err := hook.ValidName(name); err != nil {
+			return nil, err
+		}
+	}
+	return lo.Uniq(args), nil
+}
+
+func runHookInstall(cmd *cobra.Command, args []string) error {
+	names, err := hookNames(args)
+	if err != nil {
+		return err
+	}
+	dir, err := git.HooksDir()
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the revcli binary: %w", err)
+	}
+	for _, name := range names {
+		status, err := hook.Install(dir, name, executable)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Installed %s (%s)", name, status.Path)
+		if status.Chained != "" {
+			msg += fmt.Sprintf("; the existing hook runs first from %s", filepath.Base(status.Chained))
+		}
+		fmt.Println(ui.RenderSuccess(msg))
+	}
+	return nil
+}
+
+func runHookUninstall(cmd *cobra.Command, args []string) error {
+	names, err := hookNames(args)
+	if err != nil {
+		return err
+	}
+	dir, err := git.HooksDir()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		before, err := hook.Inspect(dir, name)
+		if err != nil {
+			return err
+		}
+		if before.State != hook.StateInstalled {
+			fmt.Println(ui.RenderSubtitle(fmt.Sprintf("%s: not installed by revcli; left alone", name)))
+			continue
+		}
+		status, err := hook.Uninstall(dir, name)
+		if err != nil {
+			return err
+		}
+		msg := "Removed " + name
+		if status.State == hook.StateForeign {
+			msg += "; restored the hook it replaced"
+		}
+		fmt.Println(ui.RenderSuccess(msg))
+	}
+	return nil
+}
+
+func runHookStatus(cmd *cobra.Command, args []string) error {
+	dir, err := git.HooksDir()
+	if err != nil {
+		return err
+	}
+	opts, err := loadHookOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOOK\tSTATE\tCHAINED\tPRESET\tMODEL\tFAIL ON\tTIMEOUT")
+	for _, name := range hook.Names {
+		status, err := hook.Inspect(dir, name)
+		if err != nil {
+			return err
+		}
+		settings, err := hook.Resolve(name, opts.Hooks)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name,
+			status.State,
+			lo.Ternary(status.Chained != "", "yes", "-"),
+			lo.CoalesceOrEmpty(settings.Preset, "default"),
+			lo.CoalesceOrEmpty(settings.Model, "large"),
+			settings.FailOn,
+			settings.Timeout,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Println(ui.RenderSubtitle(fmt.Sprintf("Hooks directory: %s. Skip a review with %s=1 or --no-verify.", dir, hook.SkipEnv)))
+	return nil
+}
+
+// loadHookOptions loads the options without providers, which hooks only need in the review itself
+func loadHookOptions(cmd *cobra.Command) (*config.Options, error) {
+	cwd, err := ResolveCwd(cmd)
+	if err != nil {
+		return nil, err
+	}
+	dataDir, _ := cmd.Flags().GetString("data-dir")
+	return config.LoadOptions(cwd, dataDir)
+}
+
+func runHookRun(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if skip, _ := strconv.ParseBool(os.Getenv(hook.SkipEnv)); skip {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("revcli: %s review skipped (%s is set)", name, hook.SkipEnv)))
+		return nil
+	}
+	opts, err := loadHookOptions(cmd)
+	if err != nil {
+		return err
+	}
+	settings, err := hook.Resolve(name, opts.Hooks)
+	if err != nil {
+		return err
+	}
+	reviewArgs, revisions, err := hookReview(name)
+	if err != nil || reviewArgs == nil {
+		return err
+	}
+
+	cache := hook.NewCache(filepath.Join(opts.DataDirectory, hook.CacheDir))
+	key := hook.Key(append([]string{name, settings.Preset, settings.Model, string(settings.FailOn)}, revisions...)...)
+	if cache.Passed(key) {
+		fmt.Fprintln(os.Stderr, ui.RenderSubtitle(fmt.Sprintf("revcli: %s changes already passed review", name)))
+		return nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the revcli binary: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), settings.Timeout)
+	defer cancel()
+	review := exec.CommandContext(ctx, executable, append(reviewArgs, settings.ReviewArgs()...)...)
+	review.Stdout = os.Stdout
+	review.Stderr = os.Stderr
+
+	err = review.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		if err := cache.MarkPassed(key); err != nil {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(err.Error()))
+		}
+		return nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("revcli: %s review timed out after %s; not blocking", name, settings.Timeout)))
+		return nil
+	case !errors.As(err, &exitErr):
+		return fmt.Errorf("failed to run review: %w", err)
+	}
+	bypass := fmt.Sprintf("fix them, or skip the review with %s=1 or --no-verify", hook.SkipEnv)
+	switch exitErr.ExitCode() {
+	case ExitThresholdReached:
+		return fmt.Errorf("%w; %s", ErrThresholdReached, bypass)
+	case ExitSecretsDetected:
+		return fmt.Errorf("%w; %s", ErrSecretsDetected, bypass)
+	case ExitNoChanges:
+		return nil
+	default:
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("revcli: %s review failed; not blocking", name)))
+		return nil
+	}
+}
+
+// hookReview returns the review command of a hook and the revisions it covers, which key the
+// cache; no command when there is nothing to review
+func hookReview(name string) (args, revisions []string, err error) {
+	switch name {
+	case hook.PreCommit:
+		tree, err := git.IndexTree()
+		if err != nil {
+			return nil, nil, err
+		}
+		// A repository without commits has no HEAD yet
+		head, _ := git.ResolveRef("HEAD")
+		return []string{"review", "--staged"}, []string{tree, head}, nil
+	case hook.PrePush:
+		const upstream = "@{upstream}"
+		base, head, err := git.DiffSource{Base: upstream}.Revisions()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning("revcli: the branch has no upstream yet; pushing without a review"))
+			return nil, nil, nil
+		}
+		if base == head {
+			return nil, nil, nil
+		}
+		return []string{"review", "--base", upstream}, []string{base, head}, nil
+	default:
+		return nil, nil, hook.ValidName(name)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/permission"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var (
+	permissionsAuditSession string
+	permissionsAuditLimit   int64
+)
+
+// permissionsCmd groups the commands about the project permission policy
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Show the project permission policy and the decisions it made",
+	Long: `Agents ask before running commands, writing files or using MCP tools. A
+project can decide these requests itself with a policy checked into the
+repository as .revcli/permissions.json:
+
+  {
+    "default": "ask",
+    "tools": {"fetch": "deny", "view": "allow"},
+    "bash": {"go test ./...": "allow", "git push*": "deny"},
+    "paths": {"write": {"internal/**": "allow", ".github/**": "deny"}},
+    "mcp": {"github": "ask", "github/create_issue": "deny"}
+  }
+
+Every rule is "allow", "deny" or "ask"; ask leaves the request to
+permissions.allowed_tools, --yolo or the prompt. Rules about the command, path
+or MCP tool come first, then the tool name, then the default. When several
+rules match, deny wins over ask and ask over allow. In bash patterns * matches
+any text, and every command of a pipeline or list must be allowed. Path globs
+(with **) are relative to the working directory; the actions are read, list,
+write and download.
+
+Denied calls are returned to the agent as tool errors. Every decision, also
+the ones made by --yolo or an auto-approved session, is kept in an audit log.`,
+}
+
+// permissionsShowCmd prints the loaded policy
+var permissionsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Validate and print the project permission policy",
+	Args:  cobra.NoArgs,
+	RunE:  runPermissionsShow,
+}
+
+// permissionsAuditCmd prints the logged permission decisions
+var permissionsAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List logged permission decisions, newest first",
+	Long: `List the permission decisions of all sessions, newest first, or of one
+review with --session (an ID from "revcli review history", or a unique prefix).
+Each row shows what decided the request: policy, allowed_tools, yolo,
+auto_approve, session (an earlier grant), user, or tool (an action the tool
+takes without asking, like reading a file in the repository).`,
+	Args: cobra.NoArgs,
+	RunE: runPermissionsAudit,
+}
+
+func init() {
+	permissionsAuditCmd.Flags().StringVar(&permissionsAuditSession, "session", "", "Only the decisions of this review")
+	permissionsAuditCmd.Flags().Int64Var(&permissionsAuditLimit, "limit", 50, "Maximum number of decisions listed across sessions")
+	permissionsCmd.AddCommand(permissionsShowCmd, permissionsAuditCmd)
+}
+
+func runPermissionsShow(cmd *cobra.Command, args []string) error {
+	cwd, err := ResolveCwd(cmd)
+	if err != nil {
+		return err
+	}
+	policy, err := permission.LoadPolicy(cwd)
+	if err != nil {
+		return err
+	}
+	path := permission.PolicyPath(cwd)
+	if policy == nil {
+		fmt.Println(ui.RenderSubtitle(fmt.Sprintf("No policy at %s: every request follows permissions.allowed_tools, --yolo and the prompt.", path)))
+		return nil
+	}
+	data, err := sonic.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode permission policy: %w", err)
+	}
+	fmt.Println(ui.RenderSuccess("Policy " + path))
+	fmt.Println(string(data))
+	return nil
+}
+
+func runPermissionsAudit(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	appInstance, err := setupApp(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to setup app: %w", err)
+	}
+	defer appInstance.Shutdown()
+
+	sessionID := ""
+	if permissionsAuditSession != "" {
+		repoRoot, err := git.GetGitRoot()
+		if err != nil {
+			return err
+		}
+		reviews, err := appInstance.Sessions.ListReviews(ctx, repoRoot)
+		if err != nil {
+			return fmt.Errorf("failed to list reviews: %w", err)
+		}
+		review, err := findReview(reviews, permissionsAuditSession)
+		if err != nil {
+			return err
+		}
+		sessionID = review.SessionID
+	}
+
+	entries, err := appInstance.PermissionAudit.List(ctx, sessionID, permissionsAuditLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list permission decisions: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Println(ui.RenderSubtitle("No permission decisions recorded."))
+		return nil
+	}
+	return printPermissionAudit(os.Stdout, entries)
+}
+
+// printPermissionAudit prints one row per decision
+func printPermissionAudit(out io.Writer, entries []permission.AuditEntry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tSESSION\tTOOL\tACTION\tDECISION\tSOURCE\tRULE\tSUBJECT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			time.Unix(e.CreatedAt, 0).Format("2006-01-02 15:04:05"),
+			lo.CoalesceOrEmpty(e.SessionID[:min(shortIDLength, len(e.SessionID))], "-"),
+			e.ToolName,
+			lo.CoalesceOrEmpty(e.Action, "-"),
+			lo.Ternary(e.Granted, "allow", "deny"),
+			e.Source,
+			lo.CoalesceOrEmpty(e.Rule, "-"),
+			lo.Ellipsis(strings.Join(strings.Fields(lo.CoalesceOrEmpty(e.Subject, "-")), " "), 60),
+		)
+	}
+	return w.Flush()
+}
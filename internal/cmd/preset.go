@@ -112,7 +112,10 @@ var presetDefaultCmd = &cobra.Command{
 	Use:   "default [name]",
 	Short: "Set or show the default preset",
 	Long: `Set the default preset to use when --preset flag is not provided, or show the current default preset.
-	
+
+The default is stored as options.default_preset in the global revcli.json; a project's
+revcli.json or REVCLI_DEFAULT_PRESET overrides it.
+
 Examples:
   revcli preset default quick          # Set 'quick' as default
   revcli preset default                # Show current default
@@ -665,6 +668,8 @@ func runPresetPath(cmd *cobra.Command, args []string) error {
 }
 
 func runPresetDefault(cmd *cobra.Command, args []string) error {
+	warnLegacyConfig()
+
 	// Handle --unset flag
 	if presetUnsetFlag {
 		if err := preset.ClearDefaultPreset(); err != nil {
@@ -4,22 +4,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
+	"github.com/trankhanh040147/revcli/internal/agent"
 	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/event"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/triage"
 	"github.com/trankhanh040147/revcli/internal/ui"
 )
 
 var (
 	staged        bool
 	model         string
+	temperature   float64
+	topP          float64
+	topK          int64
 	force         bool
 	interactive   bool
 	baseBranch    string
+	headRef       string
+	commitRef     string
+	revRange      string
+	lastCommits   int
+	stashRef      string
+	untracked     bool
+	maxTokens     int
 	presetName    string
 	presetReplace bool
+	outputFormat  string
+	showPrompt    bool
+	includeGlobs  []string
+	excludeGlobs  []string
+	failOn        string
+	agentMode     string
 )
 
 // reviewCmd represents the review command
@@ -37,32 +60,115 @@ Examples:
   # Review changes against main branch
   revcli review --base main
 
+  # Review a single commit, an explicit range, or the last 3 commits
+  revcli review --commit abc123
+  revcli review --range v1.2.0..v1.3.0
+  revcli review --last 3
+
+  # Review a branch that is not checked out, or a stash entry
+  revcli review --base main --head feature/login
+  revcli review --stash
+
+  # Review only tracked files, leaving out new untracked ones
+  revcli review --untracked=false
+
   # Review all uncommitted changes with a specific model
-  revcli review --model gemini-2.5-pro
+  revcli review --model gemini-2.5-pro --temperature 0.2
+
+  # Review with several models at once; findings they agree on get a higher confidence
+  revcli review --base main --models gpt-5,claude-sonnet-4,gemini-2.5-pro
 
   # Non-interactive mode (just print the review)
   revcli review --no-interactive
 
+  # Fit the prompt into 32k tokens, trimming file context as needed
+  revcli review --base main --max-tokens 32000
+
+  # After addressing feedback, review only the new commits and re-check the last findings
+  revcli review --base main --incremental
+
   # Skip secret detection check
   revcli review --force
 
+  # Redact detected secrets instead of aborting
+  revcli review --redact-secrets
+
+  # Exclude generated code, but keep one test file (gitignore syntax, like .revcli/ignore)
+  revcli review --exclude 'gen/**' --exclude '*_test.go' --include 'auth_test.go'
+
   # Use preset with replace mode (replaces base prompt)
   revcli review --preset quick --preset-replace
-  revcli review -p quick -R`,
+  revcli review -p quick -R
+
+  # Review, then apply the suggestions (each edit asks for permission)
+  revcli review --mode fix
+
+  # Ask questions about the changed code instead of reviewing it
+  revcli review --mode ask
+
+  # Inspect the prompt a preset produces without calling the model
+  revcli review --preset security --show-prompt
+
+  # Structured output for CI (implies --no-interactive)
+  revcli review --base main --output json
+  revcli review --base main -o sarif > revcli.sarif
+
+  # Post findings as inline comments on a pull request (preview with --publish-dry-run)
+  revcli review --base main --publish github --repo acme/app --pr 42
+
+  # Gate CI on findings (implies --no-interactive)
+  revcli review --base main --fail-on critical
+
+Exit codes:
+  0  review completed (threshold not reached)
+  1  error
+  2  findings reached the --fail-on threshold
+  3  potential secrets detected
+  4  no changes to review (only with --fail-on)`,
 	RunE: runReview,
 }
 
 func init() {
 	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.AddCommand(reviewHistoryCmd, reviewResumeCmd, reviewTriageCmd)
+	reviewTriageCmd.AddCommand(reviewTriageClearCmd)
 
 	reviewCmd.Flags().BoolVarP(&staged, "staged", "s", false, "Review only staged changes (git diff --staged)")
 	reviewCmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch/commit to compare against (e.g., main, develop, abc123)")
-	reviewCmd.Flags().StringVarP(&model, "model", "m", "gemini-2.5-pro", "Gemini model to use (gemini-2.5-pro, gemini-2.5-flash, etc.)")
+	reviewCmd.Flags().StringVar(&headRef, "head", "", "Branch/commit compared with --base instead of HEAD (need not be checked out)")
+	reviewCmd.Flags().StringVar(&commitRef, "commit", "", "Review the changes introduced by a single commit")
+	reviewCmd.Flags().StringVar(&revRange, "range", "", "Review a revision range (A..B for the tip difference, A...B for changes since the merge base)")
+	reviewCmd.Flags().IntVar(&lastCommits, "last", 0, "Review the last N commits on HEAD")
+	reviewCmd.Flags().StringVar(&stashRef, "stash", "", "Review a stash entry (default stash@{0} when given without a value)")
+	reviewCmd.Flags().Lookup("stash").NoOptDefVal = "stash@{0}"
+	reviewCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Prompt token budget; file context is trimmed to fit (default: the model's context window)")
+	reviewCmd.Flags().BoolVar(&incremental, "incremental", false, "Review only the commits since the branch's last review, re-checking its findings")
+	reviewCmd.Flags().BoolVar(&untracked, "untracked", true, "Include untracked (new, not ignored) files when reviewing uncommitted changes; use --untracked=false to skip them")
+	reviewCmd.Flags().StringVarP(&model, "model", "m", "", "Model for this review: a model ID, provider/model, or small for the configured small model (default: the configured large model)")
+	reviewCmd.Flags().StringSliceVar(&consensusModels, "models", nil, "Review with several models at once and merge their findings, e.g. gpt-5,claude-sonnet-4 (same forms as --model); implies --no-interactive")
+	reviewCmd.Flags().Float64Var(&temperature, "temperature", 0, "Sampling temperature for this review (overrides options.generation.temperature)")
+	reviewCmd.Flags().Float64Var(&topP, "top-p", 0, "Nucleus sampling for this review (overrides options.generation.top_p)")
+	reviewCmd.Flags().Int64Var(&topK, "top-k", 0, "Top-k sampling for this review (overrides options.generation.top_k)")
 	reviewCmd.Flags().BoolVarP(&force, "force", "f", false, "Skip secret detection and proceed anyway")
+	reviewCmd.Flags().BoolVar(&redactSecrets, "redact-secrets", false, "Replace detected secrets with placeholders and continue instead of aborting")
+	reviewCmd.Flags().BoolVar(&updateSecretsBaseline, "update-secrets-baseline", false, "Add all currently detected secrets to the baseline (known false positives) and exit")
 	reviewCmd.Flags().BoolVarP(&interactive, "interactive", "i", true, "Enable interactive chat mode")
 	reviewCmd.Flags().BoolP("no-interactive", "I", false, "Disable interactive chat mode")
 	reviewCmd.Flags().StringVarP(&presetName, "preset", "p", "", "Review preset (quick, strict, security, performance, logic, style, typo, naming)")
 	reviewCmd.Flags().BoolVarP(&presetReplace, "preset-replace", "R", false, "Replace base prompt with preset prompt instead of appending")
+	reviewCmd.Flags().StringVarP(&outputFormat, "output", "o", string(findings.FormatMarkdown), "Output format (markdown, json, sarif); structured formats imply --no-interactive")
+	reviewCmd.Flags().StringSliceVar(&includeGlobs, "include", nil, "Gitignore-style patterns to review even if ignored (e.g. '*_test.go'); overrides ignore files")
+	reviewCmd.Flags().StringSliceVar(&excludeGlobs, "exclude", nil, "Gitignore-style patterns to exclude from review, in addition to ignore files")
+	reviewCmd.Flags().BoolVar(&showPrompt, "show-prompt", false, "Print the assembled system and review prompts and exit without reviewing")
+	reviewCmd.Flags().StringVar(&agentMode, "mode", string(agent.ModeReview), "Agent mode: review (analysis only), fix (apply suggestions with the edit tools), ask (questions about the codebase); switch in the TUI with /mode")
+	reviewCmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with a non-zero code when findings reach this severity (critical, warning, any); implies --no-interactive")
+	reviewCmd.Flags().StringVar(&publishTarget, "publish", "", "Post findings as inline comments on a pull/merge request (github, gitlab); implies --no-interactive")
+	reviewCmd.Flags().BoolVar(&publishDryRun, "publish-dry-run", false, "Print the --publish API payloads instead of sending them")
+	reviewCmd.Flags().StringVar(&publishRepo, "repo", "", "Repository for --publish (GitHub owner/name or GitLab project path/ID)")
+	reviewCmd.Flags().IntVar(&publishNumber, "pr", 0, "Pull request number (GitHub) or merge request IID (GitLab) for --publish")
+	reviewCmd.Flags().StringVar(&publishAPIURL, "api-url", "", "Forge REST API root for --publish (for GitHub Enterprise or self-hosted GitLab)")
+
+	reviewCmd.MarkFlagsMutuallyExclusive("model", "models")
 }
 
 func runReview(cmd *cobra.Command, args []string) error {
@@ -71,12 +177,67 @@ func runReview(cmd *cobra.Command, args []string) error {
 		interactive = false
 	}
 
+	format, err := findings.ParseFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	threshold, err := findings.ParseThreshold(failOn)
+	if err != nil {
+		return err
+	}
+
+	mode, err := agent.ParseMode(agentMode)
+	if err != nil {
+		return err
+	}
+
+	// Structured output is machine-readable: no TUI, and progress goes to stderr
+	var status io.Writer = os.Stdout
+	if format.IsStructured() {
+		interactive = false
+		status = os.Stderr
+	}
+	// CI gating, publishing and consensus reviews need the review to finish, so they cannot run in the TUI
+	if threshold != findings.ThresholdNone || publishTarget != "" || len(consensusModels) > 0 {
+		interactive = false
+	}
+	// Edits are confirmed in the TUI; non-interactive runs would approve them silently
+	if mode == agent.ModeFix && !interactive {
+		return fmt.Errorf("--mode fix needs the interactive TUI to confirm edits")
+	}
+	if publishDryRun && format.IsStructured() {
+		return fmt.Errorf("cannot use --publish-dry-run with --output %s: both write to stdout", format)
+	}
+
+	// When publishing, stdout is reserved for dry-run payloads
+	reviewOut := io.Writer(os.Stdout)
+	if format.IsStructured() {
+		reviewOut = nil
+	} else if publishTarget != "" {
+		reviewOut = os.Stderr
+		status = os.Stderr
+	}
+	publisher, err := newPublisher(os.Stdout)
+	if err != nil {
+		return err
+	}
+
 	// Create context
 	ctx := context.Background()
 
 	// Validate mutually exclusive flags
-	if staged && baseBranch != "" {
-		return fmt.Errorf("cannot use --staged and --base together. Choose one")
+	source := diffSource()
+	if err := source.Validate(); err != nil {
+		return err
+	}
+	// Uncommitted lines have no commit on the forge to anchor comments to
+	if publishTarget != "" && !source.IsCommitted() {
+		return fmt.Errorf("--publish needs a committed revision range (--base, --commit, --range or --last)")
+	}
+	// Only commits have a head to continue from
+	if incremental && !source.IsCommitted() {
+		return fmt.Errorf("--incremental needs a committed revision range (--base, --commit, --range or --last)")
 	}
 
 	// Setup app instance
@@ -88,11 +249,23 @@ func runReview(cmd *cobra.Command, args []string) error {
 
 	// Check if coordinator is available
 	if appInstance.AgentCoordinator == nil {
-		return fmt.Errorf("agent configuration is missing. Please configure your API keys in ~/.config/revcli/config.yaml")
+		return fmt.Errorf("agent configuration is missing. Please set a provider API key (e.g. GEMINI_API_KEY) or configure a provider in revcli.json")
+	}
+
+	// A consensus review runs every model with the same instructions, mode and secret scanner
+	coordinators := []agent.Coordinator{appInstance.AgentCoordinator}
+	var reviewers []consensusReviewer
+	if len(consensusModels) > 0 {
+		if reviewers, err = newConsensusReviewers(ctx, appInstance, consensusModels); err != nil {
+			return err
+		}
+		coordinators = append(coordinators, lo.Map(reviewers, func(r consensusReviewer, _ int) agent.Coordinator {
+			return r.coordinator
+		})...)
 	}
 
 	// Load preset: use specified preset or default preset
-	activePreset, err := loadActivePreset(presetName, presetReplace)
+	activePreset, err := loadActivePreset(presetName, appInstance.Config().Options.DefaultPreset, presetReplace)
 	if err != nil {
 		return err
 	}
@@ -112,16 +285,72 @@ func runReview(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	repoRoot, err := git.GetGitRoot()
+	if err != nil {
+		return err
+	}
+	// Findings dismissed in earlier reviews of the repository are not to be reported again
+	decisions, err := appInstance.Triage.List(ctx, repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load triaged findings: %w", err)
+	}
+	dismissed := triage.Dismissed(decisions)
+
+	// Preset and intent go into the agent's system prompt (both TUI and non-interactive); it is
+	// set before building the context so the token budget accounts for it
+	guidelines, replace := buildSystemPrompt(withDismissedFindings(intent, dismissed), activePreset)
+	for _, coordinator := range coordinators {
+		if err := coordinator.SetReviewInstructions(ctx, guidelines, replace); err != nil {
+			return fmt.Errorf("failed to apply review instructions: %w", err)
+		}
+		if err := coordinator.SetMode(ctx, mode); err != nil {
+			return err
+		}
+	}
+
 	// Step 1: Build the review context
-	printReviewHeader(os.Stdout, activePreset, baseBranch, staged)
+	printReviewHeader(status, activePreset, source)
 
-	builder := appcontext.NewBuilder(staged, force, baseBranch)
+	branch := reviewBranch(source)
+	var previous *appcontext.PreviousReview
+	if incremental {
+		if source, previous, err = incrementalSource(ctx, appInstance, repoRoot, branch, source); err != nil {
+			return err
+		}
+		fmt.Fprintf(status, "Incremental: only the commits since the last review (%s), re-checking its %d finding(s)\n",
+			shortRev(previous.Head), len(previous.Findings))
+	}
+	ignoreMatcher, err := loadIgnoreMatcher(repoRoot, includeGlobs, excludeGlobs)
+	if err != nil {
+		return err
+	}
+	secrets, err := loadSecretScanner(repoRoot, appInstance.Config().Options.Secrets)
+	if err != nil {
+		return err
+	}
+	// The agent's tool results get the same rules and baseline as the diff
+	for _, coordinator := range coordinators {
+		coordinator.SetSecretScanner(secrets.scanner)
+	}
+	budget := tokenBudget(appInstance, maxTokens)
+	if reviewers != nil {
+		budget = consensusTokenBudget(reviewers, maxTokens)
+	}
+	builder := appcontext.NewBuilder(source, force).
+		WithIgnore(ignoreMatcher).
+		WithSecretScanner(secrets.scanner).
+		WithRedaction(secrets.redact && !updateSecretsBaseline).
+		WithTokenBudget(budget).
+		WithPreviousReview(previous)
 	reviewCtx, err := buildReviewContext(builder, intent)
 	if err != nil {
 		// Check if it's a secrets error using errors.Is/As
 		var secretsErr appcontext.SecretsError
 		if errors.As(err, &secretsErr) {
-			if printErr := printSecretsWarning(os.Stdout, secretsErr.Matches); printErr != nil {
+			if updateSecretsBaseline {
+				return writeSecretsBaseline(status, secrets.baselinePath, secretsErr.Matches)
+			}
+			if printErr := printSecretsWarning(status, secretsErr.Matches); printErr != nil {
 				return printErr
 			}
 			return ErrSecretsDetected
@@ -131,25 +360,53 @@ func runReview(cmd *cobra.Command, args []string) error {
 
 	// Check if there are changes to review
 	if !reviewCtx.HasChanges() {
-		fmt.Println(ui.RenderWarning("No changes detected. Make sure you have uncommitted changes."))
+		if previous != nil {
+			fmt.Fprintln(status, ui.RenderWarning("No new commits since the last review."))
+		} else {
+			fmt.Fprintln(status, ui.RenderWarning("No changes detected. Make sure you have uncommitted changes."))
+		}
+		if threshold != findings.ThresholdNone {
+			return ErrNoChanges
+		}
+		return nil
+	}
+
+	if updateSecretsBaseline {
+		fmt.Fprintln(status, ui.RenderSuccess("No new secrets detected; baseline unchanged."))
 		return nil
 	}
 
 	// Print detailed summary with file list
-	printContextSummary(os.Stdout, reviewCtx)
+	printContextSummary(status, reviewCtx)
+
+	prompt := buildReviewPrompt(reviewCtx)
+	if showPrompt {
+		printPrompts(os.Stdout, appInstance.AgentCoordinator.SystemPrompt(), prompt)
+		return nil
+	}
 
 	// Step 2: Create session
 	sessionTitle := "Code Review"
 	if activePreset != nil {
 		sessionTitle = fmt.Sprintf("Code Review - %s", activePreset.Name)
+		event.SetPreset(activePreset.Name)
 	}
 	session, err := appInstance.Sessions.Create(ctx, sessionTitle)
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
+	event.ReviewStarted(
+		"session id", session.ID,
+		"mode", string(mode),
+		"files", len(reviewCtx.FileContents),
+		"interactive", interactive,
+		"incremental", incremental,
+	)
+	// The review still runs if it cannot be recorded (e.g. a repository without commits)
+	if err := recordReview(ctx, appInstance.Sessions, session.ID, repoRoot, branch, source, reviewCtx, activePreset, intent); err != nil {
+		fmt.Fprintln(status, ui.RenderWarning(fmt.Sprintf("Review will not appear in history: %v", err)))
+	}
 
-	// Build prompt and attachments
-	prompt := buildReviewPrompt(reviewCtx, activePreset)
 	_ = buildAttachments(reviewCtx) // Attachments are built in model_review.go
 
 	// Step 3: Run the review
@@ -159,5 +416,34 @@ func runReview(cmd *cobra.Command, args []string) error {
 	}
 
 	// Non-interactive mode - use app.RunNonInteractive
-	return appInstance.RunNonInteractive(ctx, os.Stdout, prompt, false)
+	opts := nonInteractiveOptions{
+		format:    format,
+		threshold: threshold,
+		publisher: publisher,
+		reviewOut: reviewOut,
+		sessionID: session.ID,
+		rawDiff:   reviewCtx.RawDiff,
+		source:    source,
+		sources:   reviewCtx.Sources,
+		dismissed: triage.Fingerprints(dismissed),
+	}
+	ctx = agent.WithWebAccess(ctx, intent.WebAccess())
+	if reviewers != nil {
+		return runConsensusReview(ctx, appInstance, reviewers, prompt, opts)
+	}
+	return runNonInteractiveReview(ctx, appInstance, prompt, opts)
+}
+
+// diffSource builds the diff source from the revision flags
+func diffSource() git.DiffSource {
+	return git.DiffSource{
+		Staged:    staged,
+		Base:      baseBranch,
+		Head:      headRef,
+		Commit:    commitRef,
+		Range:     revRange,
+		Last:      lastCommits,
+		Stash:     stashRef,
+		Untracked: untracked,
+	}
 }
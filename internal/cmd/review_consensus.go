@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
+	"github.com/trankhanh040147/revcli/internal/app"
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/message"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var consensusModels []string
+
+// consensusReviewer reviews with one of the --models
+type consensusReviewer struct {
+	model       string
+	cfg         *config.Config
+	coordinator agent.Coordinator
+}
+
+// newConsensusReviewers builds a coordinator for each model, sharing the app's services
+func newConsensusReviewers(ctx context.Context, appInstance *app.App, models []string) ([]consensusReviewer, error) {
+	models = lo.Uniq(models)
+	if len(models) < 2 {
+		return nil, fmt.Errorf("--models needs at least two different models; use --model for one")
+	}
+	reviewers := make([]consensusReviewer, 0, len(models))
+	for _, model := range models {
+		cfg, err := appInstance.Config().WithLargeModel(model)
+		if err != nil {
+			return nil, err
+		}
+		coordinator, err := appInstance.NewCoordinator(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up %s: %w", model, err)
+		}
+		reviewers = append(reviewers, consensusReviewer{model: model, cfg: cfg, coordinator: coordinator})
+	}
+	return reviewers, nil
+}
+
+// consensusTokenBudget returns the smallest prompt budget of the models, so every one gets the same context
+func consensusTokenBudget(reviewers []consensusReviewer, maxTokens int) int {
+	return lo.Min(lo.Map(reviewers, func(r consensusReviewer, _ int) int {
+		return modelTokenBudget(r.cfg, r.coordinator, maxTokens)
+	}))
+}
+
+// runConsensusReview runs the review with every model at once, each in a session under the review's
+// session, and reports the merged findings like those of a single review. A model that fails is left
+// out of the consensus.
+func runConsensusReview(ctx context.Context, appInstance *app.App, reviewers []consensusReviewer, prompt string, opts nonInteractiveOptions) error {
+	models := lo.Map(reviewers, func(r consensusReviewer, _ int) string {
+		return r.model
+	})
+	fmt.Fprintln(os.Stderr, ui.RenderSubtitle(fmt.Sprintf("Reviewing with %d models: %s", len(models), strings.Join(models, ", "))))
+
+	reports := make([]*findings.Report, len(reviewers))
+	errs := make([]error, len(reviewers))
+	var wg sync.WaitGroup
+	for i, r := range reviewers {
+		wg.Go(func() {
+			started := time.Now()
+			reports[i], errs[i] = runModelReview(ctx, appInstance, r, prompt, opts.sessionID)
+			if errs[i] != nil {
+				fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s failed, left out of the consensus: %v", r.model, errs[i])))
+				return
+			}
+			fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("%s: %d finding(s) in %s", r.model, len(reports[i].Findings), time.Since(started).Round(time.Second))))
+		})
+	}
+	wg.Wait()
+
+	var completed []findings.ModelReport
+	for i, r := range reviewers {
+		if errs[i] == nil {
+			completed = append(completed, findings.ModelReport{Model: r.model, Report: reports[i]})
+		}
+	}
+	if len(completed) == 0 {
+		return fmt.Errorf("every model failed to review: %w", errors.Join(errs...))
+	}
+
+	report := findings.Merge(completed)
+	// The merged review is kept in the review's session, where history and --incremental read it
+	if err := saveConsensusReview(ctx, appInstance.Messages, opts.sessionID, prompt, report.Markdown()); err != nil {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Review will not appear in history: %v", err)))
+	}
+	if !opts.format.IsStructured() && opts.reviewOut != nil {
+		// Dismissed findings are only known once fingerprinted, so the markdown is written by reportFindings
+		opts.renderMarkdown = true
+	}
+	return reportFindings(ctx, report, opts)
+}
+
+// runModelReview runs the review with one model in a session of its own and parses its findings
+func runModelReview(ctx context.Context, appInstance *app.App, r consensusReviewer, prompt, parentSessionID string) (*findings.Report, error) {
+	sess, err := appInstance.Sessions.CreateTaskSession(ctx, uuid.NewString(), parentSessionID, "Code Review - "+r.model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	appInstance.Permissions.AutoApproveSession(sess.ID)
+	if _, err := r.coordinator.Run(ctx, sess.ID, prompt); err != nil {
+		return nil, err
+	}
+	messages, err := appInstance.Messages.List(ctx, sess.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the review: %w", err)
+	}
+	return findings.Parse(ui.ReviewFromMessages(messages)), nil
+}
+
+// saveConsensusReview stores the prompt and the merged review as the first turn of the session
+func saveConsensusReview(ctx context.Context, messages message.Service, sessionID, prompt, review string) error {
+	if _, err := messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: prompt}},
+	}); err != nil {
+		return err
+	}
+	_, err := messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: review},
+			message.Finish{Reason: message.FinishReasonEndTurn, Time: time.Now().Unix()},
+		},
+	})
+	return err
+}
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"fmt"
 	"path/filepath"
 
+	"github.com/trankhanh040147/revcli/internal/agent"
+	"github.com/trankhanh040147/revcli/internal/app"
+	"github.com/trankhanh040147/revcli/internal/config"
 	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/filter"
 	"github.com/trankhanh040147/revcli/internal/message"
 	"github.com/trankhanh040147/revcli/internal/preset"
 )
 
-// loadActivePreset loads the active preset based on presetName or default preset
-func loadActivePreset(presetName string, presetReplace bool) (*preset.Preset, error) {
+// loadActivePreset loads the active preset based on presetName or the configured default preset
+func loadActivePreset(presetName, defaultPresetName string, presetReplace bool) (*preset.Preset, error) {
 	var activePreset *preset.Preset
 	if presetName != "" {
 		var err error
@@ -17,15 +22,12 @@ func loadActivePreset(presetName string, presetReplace bool) (*preset.Preset, er
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		// Try to load default preset
-		defaultPresetName, err := preset.GetDefaultPreset()
-		if err == nil && defaultPresetName != "" {
-			activePreset, err = preset.Get(defaultPresetName)
-			if err != nil {
-				// Default preset doesn't exist anymore, ignore
-				activePreset = nil
-			}
+	} else if defaultPresetName != "" {
+		var err error
+		activePreset, err = preset.Get(defaultPresetName)
+		if err != nil {
+			// Default preset doesn't exist anymore, ignore
+			activePreset = nil
 		}
 	}
 
@@ -37,6 +39,34 @@ func loadActivePreset(presetName string, presetReplace bool) (*preset.Preset, er
 	return activePreset, nil
 }
 
+// loadIgnoreMatcher builds the ignore matcher from the global and project ignore files and the flags
+func loadIgnoreMatcher(repoRoot string, include, exclude []string) (*filter.Matcher, error) {
+	rules, err := filter.LoadIgnoreRules(repoRoot, include, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ignore rules: %w", err)
+	}
+	return filter.NewMatcher(rules), nil
+}
+
+// tokenBudget returns the review prompt budget: maxTokens, or the large model's context window minus
+// its output reservation, less what the system prompt and tool schemas already take
+func tokenBudget(appInstance *app.App, maxTokens int) int {
+	return modelTokenBudget(appInstance.Config(), appInstance.AgentCoordinator, maxTokens)
+}
+
+// modelTokenBudget returns the prompt token budget of the large model of cfg, run by coordinator
+func modelTokenBudget(cfg *config.Config, coordinator agent.Coordinator, maxTokens int) int {
+	budget := maxTokens
+	if budget <= 0 {
+		budget = appcontext.DefaultTokenBudget
+		if model := cfg.LargeModel(); model != nil && model.ContextWindow > model.DefaultMaxTokens {
+			budget = int(model.ContextWindow - model.DefaultMaxTokens)
+		}
+	}
+	// Keep a positive budget, since 0 disables trimming
+	return max(budget-coordinator.PromptOverhead(), 1)
+}
+
 // buildReviewContext builds the review context from the builder and intent
 func buildReviewContext(builder *appcontext.Builder, intent *appcontext.Intent) (*appcontext.ReviewContext, error) {
 	if intent != nil {
@@ -45,9 +75,19 @@ func buildReviewContext(builder *appcontext.Builder, intent *appcontext.Intent)
 	return builder.Build()
 }
 
-// buildReviewPrompt builds the review prompt from context and preset
-// TODO: using preset later
-func buildReviewPrompt(reviewCtx *appcontext.ReviewContext, preset *preset.Preset) string {
+// buildSystemPrompt builds the review guidelines from the preset and intent, and whether they replace the base template
+func buildSystemPrompt(intent *appcontext.Intent, activePreset *preset.Preset) (string, bool) {
+	var presetPrompt string
+	var replace bool
+	if activePreset != nil {
+		presetPrompt = activePreset.Prompt
+		replace = activePreset.Replace && presetPrompt != ""
+	}
+	return appcontext.GetReviewGuidelines(intent, presetPrompt), replace
+}
+
+// buildReviewPrompt builds the review (user) prompt from context
+func buildReviewPrompt(reviewCtx *appcontext.ReviewContext) string {
 	// Start with the user prompt from context
 	prompt := reviewCtx.UserPrompt
 
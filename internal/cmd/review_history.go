@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
+	"github.com/trankhanh040147/revcli/internal/app"
+	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/preset"
+	"github.com/trankhanh040147/revcli/internal/session"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+// shortIDLength is how much of a session ID the history shows; resume accepts any unique prefix
+const shortIDLength = 8
+
+// reviewHistoryCmd lists the recorded reviews of the repository
+var reviewHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past reviews of this repository",
+	Long: `List the reviews recorded for the current repository, newest first, with the
+branch, revisions and preset each one reviewed, and whether the agent used the
+web. Reopen one with "revcli review resume <id>".`,
+	Args: cobra.NoArgs,
+	RunE: runReviewHistory,
+}
+
+// reviewResumeCmd reopens a recorded review in the TUI
+var reviewResumeCmd = &cobra.Command{
+	Use:   "resume <id>",
+	Short: "Reopen a past review in the TUI",
+	Long: `Reopen a past review with its chat history. <id> is an ID from
+"revcli review history", or any unique prefix of one.
+
+Reviews of commits have their diff rebuilt from the recorded revisions, so the
+file list and pruning work as before. Uncommitted changes cannot be rebuilt;
+those reviews reopen with the conversation only.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewResume,
+}
+
+func runReviewHistory(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	repoRoot, err := git.GetGitRoot()
+	if err != nil {
+		return err
+	}
+	appInstance, err := setupApp(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to setup app: %w", err)
+	}
+	defer appInstance.Shutdown()
+
+	reviews, err := appInstance.Sessions.ListReviews(ctx, repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to list reviews: %w", err)
+	}
+	if len(reviews) == 0 {
+		fmt.Println(ui.RenderSubtitle("No reviews recorded for this repository."))
+		return nil
+	}
+	return printReviewHistory(ctx, os.Stdout, appInstance.Sessions, reviews)
+}
+
+// printReviewHistory prints one row per review
+func printReviewHistory(ctx context.Context, out io.Writer, sessions session.Service, reviews []session.Review) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tBRANCH\tREVISIONS\tPRESET\tMESSAGES\tWEB")
+	for _, r := range reviews {
+		sess, err := sessions.Get(ctx, r.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load session %s: %w", r.SessionID, err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			r.SessionID[:min(shortIDLength, len(r.SessionID))],
+			time.Unix(r.CreatedAt, 0).Format("2006-01-02 15:04"),
+			lo.CoalesceOrEmpty(r.Branch, "-"),
+			reviewRevisions(r),
+			lo.CoalesceOrEmpty(r.Preset, "-"),
+			sess.MessageCount,
+			lo.Ternary(sess.WebAccessUsed, "used", "-"),
+		)
+	}
+	return w.Flush()
+}
+
+// reviewRevisions describes what a review compared
+func reviewRevisions(r session.Review) string {
+	if !r.IsCommitted() {
+		return shortRev(r.HeadCommit) + " + uncommitted"
+	}
+	return shortRev(r.BaseRef) + ".." + shortRev(r.HeadCommit)
+}
+
+// shortRev abbreviates full commit SHAs, leaving ref names as they are
+func shortRev(rev string) string {
+	if len(rev) == 40 && strings.Trim(rev, "0123456789abcdef") == "" {
+		return rev[:7]
+	}
+	return rev
+}
+
+func runReviewResume(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	repoRoot, err := git.GetGitRoot()
+	if err != nil {
+		return err
+	}
+	appInstance, err := setupApp(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to setup app: %w", err)
+	}
+	defer appInstance.Shutdown()
+
+	if appInstance.AgentCoordinator == nil {
+		return fmt.Errorf("agent configuration is missing. Please set a provider API key (e.g. GEMINI_API_KEY) or configure a provider in revcli.json")
+	}
+
+	reviews, err := appInstance.Sessions.ListReviews(ctx, repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to list reviews: %w", err)
+	}
+	review, err := findReview(reviews, args[0])
+	if err != nil {
+		return err
+	}
+
+	activePreset := resumePreset(review)
+	var intent *appcontext.Intent
+	if review.Intent != "" {
+		intent = &appcontext.Intent{}
+		if err := sonic.UnmarshalString(review.Intent, intent); err != nil {
+			return fmt.Errorf("failed to decode review intent: %w", err)
+		}
+	}
+
+	// Follow-ups get the same guidelines the review had
+	guidelines, replace := buildSystemPrompt(intent, activePreset)
+	if err := appInstance.AgentCoordinator.SetReviewInstructions(ctx, guidelines, replace); err != nil {
+		return fmt.Errorf("failed to apply review instructions: %w", err)
+	}
+	if err := appInstance.AgentCoordinator.SetMode(ctx, agent.ModeReview); err != nil {
+		return err
+	}
+
+	secrets, err := loadSecretScanner(repoRoot, appInstance.Config().Options.Secrets)
+	if err != nil {
+		return err
+	}
+	appInstance.AgentCoordinator.SetSecretScanner(secrets.scanner)
+
+	reviewCtx := resumeContext(appInstance, repoRoot, review, intent, secrets)
+	messages, err := appInstance.Messages.List(ctx, review.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to load messages: %w", err)
+	}
+	return ui.Resume(reviewCtx, appInstance, review.SessionID, activePreset, messages)
+}
+
+// findReview finds the review whose session ID starts with id
+func findReview(reviews []session.Review, id string) (session.Review, error) {
+	matches := lo.Filter(reviews, func(r session.Review, _ int) bool {
+		return strings.HasPrefix(r.SessionID, id)
+	})
+	switch len(matches) {
+	case 0:
+		return session.Review{}, fmt.Errorf("no review %q in this repository (see revcli review history)", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return session.Review{}, fmt.Errorf("review ID %q is ambiguous: %d reviews match", id, len(matches))
+	}
+}
+
+// resumePreset loads the preset a review used; a preset deleted since is left out
+func resumePreset(review session.Review) *preset.Preset {
+	if review.Preset == "" {
+		return nil
+	}
+	p, err := preset.Get(review.Preset)
+	if err != nil {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Preset %q is no longer available; resuming without it.", review.Preset)))
+		return nil
+	}
+	p.Replace = review.PresetReplace
+	return p
+}
+
+// resumeContext rebuilds the context of a committed review from its recorded revisions. Uncommitted
+// changes, and commits that no longer exist, resume with an empty context.
+func resumeContext(appInstance *app.App, repoRoot string, review session.Review, intent *appcontext.Intent, secrets *secretScanner) *appcontext.ReviewContext {
+	empty := &appcontext.ReviewContext{
+		FileContents: map[string]string{},
+		PrunedFiles:  map[string]string{},
+		Intent:       intent,
+	}
+	if !review.IsCommitted() {
+		fmt.Println(ui.RenderWarning("Uncommitted changes cannot be rebuilt; resuming with the conversation only."))
+		return empty
+	}
+
+	reviewCtx, err := rebuildReviewContext(appInstance, repoRoot, review, intent, secrets)
+	if err != nil {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Could not rebuild the reviewed diff (%v); resuming with the conversation only.", err)))
+		return empty
+	}
+	if reviewDiffHash(reviewCtx.RawDiff) != review.DiffHash {
+		fmt.Println(ui.RenderWarning("The rebuilt diff differs from the reviewed one (ignore rules or secret redaction changed)."))
+	}
+	return reviewCtx
+}
+
+// rebuildReviewContext builds the review context of the recorded revisions, redacting secrets
+func rebuildReviewContext(appInstance *app.App, repoRoot string, review session.Review, intent *appcontext.Intent, secrets *secretScanner) (*appcontext.ReviewContext, error) {
+	ignoreMatcher, err := loadIgnoreMatcher(repoRoot, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	source := git.DiffSource{Range: review.BaseCommit + ".." + review.HeadCommit}
+	builder := appcontext.NewBuilder(source, false).
+		WithIgnore(ignoreMatcher).
+		WithSecretScanner(secrets.scanner).
+		WithRedaction(true).
+		WithTokenBudget(tokenBudget(appInstance, 0))
+	return buildReviewContext(builder, intent)
+}
+
+// recordReview stores what the session reviews, so it can be listed and resumed
+func recordReview(ctx context.Context, sessions session.Service, sessionID, repoRoot, branch string, source git.DiffSource, reviewCtx *appcontext.ReviewContext, activePreset *preset.Preset, intent *appcontext.Intent) error {
+	base, head, err := source.Revisions()
+	if err != nil {
+		return err
+	}
+
+	review := session.Review{
+		SessionID:  sessionID,
+		Repo:       repoRoot,
+		Branch:     branch,
+		BaseRef:    lo.CoalesceOrEmpty(source.Base, base),
+		BaseCommit: base,
+		HeadCommit: head,
+		DiffHash:   reviewDiffHash(reviewCtx.RawDiff),
+	}
+	if activePreset != nil {
+		review.Preset = activePreset.Name
+		review.PresetReplace = activePreset.Replace
+	}
+	if intent != nil {
+		if review.Intent, err = sonic.MarshalString(intent); err != nil {
+			return fmt.Errorf("failed to encode review intent: %w", err)
+		}
+	}
+	_, err = sessions.CreateReview(ctx, review)
+	return err
+}
+
+// reviewBranch returns the branch a source reviews: --head, or the checked out branch
+func reviewBranch(source git.DiffSource) string {
+	if source.Head != "" {
+		return source.Head
+	}
+	// A detached HEAD has no branch; the review is recorded without one
+	branch, _ := git.CurrentBranch()
+	return branch
+}
+
+// reviewDiffHash identifies a reviewed diff (sha256 hex)
+func reviewDiffHash(diff string) string {
+	sum := sha256.Sum256([]byte(diff))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/trankhanh040147/revcli/internal/app"
+	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var incremental bool
+
+// incrementalSource narrows source to the commits since the branch's last review and loads that review's findings
+func incrementalSource(ctx context.Context, appInstance *app.App, repoRoot, branch string, source git.DiffSource) (git.DiffSource, *appcontext.PreviousReview, error) {
+	if branch == "" {
+		return source, nil, fmt.Errorf("--incremental needs a branch to find the last review: check one out or pass --head")
+	}
+	last, err := appInstance.Sessions.LatestReview(ctx, repoRoot, branch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return source, nil, fmt.Errorf("no previous review of %s to continue; run a full review first", branch)
+	}
+	if err != nil {
+		return source, nil, fmt.Errorf("failed to look up the last review: %w", err)
+	}
+
+	_, head, err := source.Revisions()
+	if err != nil {
+		return source, nil, err
+	}
+	// After a rebase the old head is gone from the branch, and its diff to the new head is meaningless
+	ancestor, err := git.IsAncestor(last.HeadCommit, head)
+	if err != nil {
+		return source, nil, err
+	}
+	if !ancestor {
+		return source, nil, fmt.Errorf("the last review of %s covered %s, which is not an ancestor of %s (was the branch rebased?); run a full review",
+			branch, shortRev(last.HeadCommit), shortRev(head))
+	}
+
+	messages, err := appInstance.Messages.List(ctx, last.SessionID)
+	if err != nil {
+		return source, nil, fmt.Errorf("failed to load the last review: %w", err)
+	}
+	previous := &appcontext.PreviousReview{
+		Head:     last.HeadCommit,
+		Findings: findings.Parse(ui.ReviewFromMessages(messages)).Findings,
+	}
+	return git.DiffSource{Range: last.HeadCommit + ".." + head}, previous, nil
+}
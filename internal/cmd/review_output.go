@@ -1,20 +1,28 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"os"
 
-	"github.com/trankhanh040147/revcli/internal/filter"
+	"github.com/trankhanh040147/revcli/internal/app"
 	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/filter"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/git"
 	"github.com/trankhanh040147/revcli/internal/preset"
+	"github.com/trankhanh040147/revcli/internal/publish"
 	"github.com/trankhanh040147/revcli/internal/ui"
+	"github.com/trankhanh040147/revcli/internal/version"
 )
 
 // ErrSecretsDetected is returned when secrets are detected in the code
 var ErrSecretsDetected = fmt.Errorf("review aborted due to potential secrets")
 
 // printReviewHeader prints the review header with preset and comparison info
-func printReviewHeader(w io.Writer, preset *preset.Preset, baseBranch string, staged bool) {
+func printReviewHeader(w io.Writer, preset *preset.Preset, source git.DiffSource) {
 	fmt.Fprintln(w, ui.RenderTitle("🔍 Code Review"))
 	fmt.Fprintln(w)
 
@@ -26,11 +34,22 @@ func printReviewHeader(w io.Writer, preset *preset.Preset, baseBranch string, st
 		fmt.Fprintf(w, "Using preset: %s (%s) [mode: %s]\n", preset.Name, preset.Description, mode)
 	}
 
-	if baseBranch != "" {
-		fmt.Fprintf(w, "Comparing against: %s\n", baseBranch)
-	} else if staged {
+	switch {
+	case source.Base != "" && source.Head != "":
+		fmt.Fprintf(w, "Comparing %s against: %s\n", source.Head, source.Base)
+	case source.Base != "":
+		fmt.Fprintf(w, "Comparing against: %s\n", source.Base)
+	case source.Commit != "":
+		fmt.Fprintf(w, "Reviewing commit %s...\n", source.Commit)
+	case source.Range != "":
+		fmt.Fprintf(w, "Reviewing range %s...\n", source.Range)
+	case source.Last > 0:
+		fmt.Fprintf(w, "Reviewing the last %d commit(s)...\n", source.Last)
+	case source.Stash != "":
+		fmt.Fprintf(w, "Reviewing stash %s...\n", source.Stash)
+	case source.Staged:
 		fmt.Fprintln(w, "Reviewing staged changes...")
-	} else {
+	default:
 		fmt.Fprintln(w, "Reviewing uncommitted changes...")
 	}
 }
@@ -43,20 +62,112 @@ func printContextSummary(w io.Writer, ctx *appcontext.ReviewContext) {
 	fmt.Fprintln(w)
 }
 
+// printPrompts prints the assembled system and review prompts (for --show-prompt)
+func printPrompts(w io.Writer, systemPrompt, reviewPrompt string) {
+	fmt.Fprintln(w, ui.RenderTitle("System Prompt"))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, systemPrompt)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, ui.RenderTitle("Review Prompt"))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, reviewPrompt)
+}
+
 // printSecretsWarning prints a warning about detected secrets and returns an error
 func printSecretsWarning(w io.Writer, secrets []filter.SecretMatch) error {
 	fmt.Fprintln(w, ui.RenderError("Potential secrets detected in your code!"))
 	fmt.Fprintln(w)
 
 	for _, s := range secrets {
-		fmt.Fprintf(w, "  • %s (line %d): %s\n", s.FilePath, s.Line, s.Match)
+		fmt.Fprintf(w, "  • %s (line %d): %s [%s]\n", s.FilePath, s.Line, s.Match, s.RuleID)
 	}
 
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, ui.RenderWarning("Review aborted to prevent sending secrets to external API."))
+	fmt.Fprintln(w, ui.RenderHelp("Use --redact-secrets to replace them with placeholders and continue"))
+	fmt.Fprintln(w, ui.RenderHelp(fmt.Sprintf("False positive? Add a %q comment on the line, or run with --update-secrets-baseline", filter.AllowSecretAnnotation)))
 	fmt.Fprintln(w, ui.RenderHelp("Use --force to proceed anyway (not recommended)"))
 	fmt.Fprintln(w)
 
 	return ErrSecretsDetected
 }
 
+// nonInteractiveOptions controls how a non-interactive review is reported
+type nonInteractiveOptions struct {
+	format    findings.Format
+	threshold findings.Threshold
+	publisher publish.Publisher
+	// reviewOut receives the streamed markdown review (nil to only buffer it)
+	reviewOut io.Writer
+	// sessionID is the review session the run is recorded in
+	sessionID string
+	// rawDiff is the reviewed diff, used to anchor published comments
+	rawDiff string
+	// source selects the revisions published comments are anchored to
+	source git.DiffSource
+	// sources are the reviewed file contents, used to fingerprint findings
+	sources map[string]string
+	// dismissed holds the fingerprints of dismissed findings, which are left out of the report
+	dismissed map[string]bool
+	// renderMarkdown writes the report as markdown to reviewOut, for reviews that were not streamed
+	renderMarkdown bool
+}
+
+// runNonInteractiveReview runs the review without the TUI. Markdown is streamed as it arrives;
+// structured formats, publishing and the fail-on threshold need the parsed findings.
+func runNonInteractiveReview(ctx context.Context, appInstance *app.App, prompt string, opts nonInteractiveOptions) error {
+	var buf bytes.Buffer
+	var out io.Writer = &buf
+	if opts.reviewOut != nil {
+		out = io.MultiWriter(opts.reviewOut, &buf)
+	}
+	if err := appInstance.RunNonInteractive(ctx, out, opts.sessionID, prompt, false); err != nil {
+		return err
+	}
+
+	if !opts.format.IsStructured() && opts.threshold == findings.ThresholdNone && opts.publisher == nil {
+		return nil
+	}
+
+	return reportFindings(ctx, findings.Parse(buf.String()), opts)
+}
+
+// reportFindings leaves out dismissed findings, then writes the report in a structured format,
+// publishes it and checks the fail-on threshold
+func reportFindings(ctx context.Context, report *findings.Report, opts nonInteractiveOptions) error {
+	report.AddFingerprints(opts.sources)
+	if hidden := report.Exclude(opts.dismissed); hidden > 0 {
+		fmt.Fprintln(os.Stderr, ui.RenderHelp(fmt.Sprintf("%d dismissed finding(s) left out (see revcli review triage)", hidden)))
+	}
+	if opts.renderMarkdown {
+		fmt.Fprint(opts.reviewOut, report.Markdown())
+	}
+	if opts.format.IsStructured() {
+		if len(report.Unparsed) > 0 {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%d section(s) of the review could not be parsed into findings (see \"unparsed\")", len(report.Unparsed))))
+		}
+		if err := report.Write(os.Stdout, opts.format, version.Version); err != nil {
+			return err
+		}
+	}
+	if opts.publisher != nil {
+		if err := publishFindings(ctx, opts.publisher, report, opts.rawDiff, opts.source); err != nil {
+			return err
+		}
+	}
+	return checkThreshold(os.Stderr, report, opts.threshold)
+}
+
+// checkThreshold returns ErrThresholdReached if any finding is at or above the threshold
+func checkThreshold(w io.Writer, report *findings.Report, threshold findings.Threshold) error {
+	hits := report.Hits(threshold)
+	if len(hits) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, ui.RenderError(fmt.Sprintf("Fail-on threshold %q reached: %d critical, %d warning, %d refactoring",
+		threshold,
+		report.Count(findings.SeverityCritical),
+		report.Count(findings.SeverityWarning),
+		report.Count(findings.SeverityRefactoring))))
+	return fmt.Errorf("%w: %d finding(s) at or above %s", ErrThresholdReached, len(hits), threshold)
+}
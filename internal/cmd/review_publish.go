@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/publish"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var (
+	publishTarget string
+	publishDryRun bool
+	publishRepo   string
+	publishNumber int
+	publishAPIURL string
+)
+
+// newPublisher creates the publisher requested by --publish, or nil if publishing is off.
+// Unset flags fall back to the environment variables set by GitHub Actions and GitLab CI.
+func newPublisher(dryRunOut io.Writer) (publish.Publisher, error) {
+	if publishTarget == "" {
+		return nil, nil
+	}
+	target, err := publish.ParseTarget(publishTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := publish.Options{
+		Target:  target,
+		BaseURL: publishAPIURL,
+		Repo:    publishRepo,
+		Number:  publishNumber,
+	}
+	if publishDryRun {
+		opts.DryRun = dryRunOut
+	}
+
+	switch target {
+	case publish.TargetGitHub:
+		opts.Token = os.Getenv(config.EnvGitHubToken)
+		opts.BaseURL = lo.CoalesceOrEmpty(opts.BaseURL, os.Getenv(config.EnvGitHubAPIURL))
+		opts.Repo = lo.CoalesceOrEmpty(opts.Repo, os.Getenv(config.EnvGitHubRepository))
+		if opts.Number == 0 {
+			opts.Number = pullNumberFromRef(os.Getenv(config.EnvGitHubRef))
+		}
+	case publish.TargetGitLab:
+		opts.Token = os.Getenv(config.EnvGitLabToken)
+		opts.BaseURL = lo.CoalesceOrEmpty(opts.BaseURL, os.Getenv(config.EnvGitLabAPIURL))
+		opts.Repo = lo.CoalesceOrEmpty(opts.Repo, os.Getenv(config.EnvGitLabProjectID))
+		if opts.Number == 0 {
+			opts.Number, _ = strconv.Atoi(os.Getenv(config.EnvGitLabMRIID))
+		}
+	}
+
+	return publish.New(opts)
+}
+
+// publishFindings anchors the findings to the reviewed diff and posts them
+func publishFindings(ctx context.Context, publisher publish.Publisher, report *findings.Report, rawDiff string, source git.DiffSource) error {
+	baseSHA, headSHA, err := source.Revisions()
+	if err != nil {
+		return fmt.Errorf("failed to resolve reviewed revisions: %w", err)
+	}
+
+	review := publish.BuildReview(report, rawDiff, baseSHA, headSHA)
+	if err := publisher.Publish(ctx, review); err != nil {
+		return err
+	}
+
+	if !publishDryRun {
+		fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("Published %d inline comment(s) to %s", len(review.Comments), publishTarget)))
+	}
+	return nil
+}
+
+// pullNumberFromRef extracts N from a GitHub Actions ref like "refs/pull/N/merge"
+func pullNumberFromRef(ref string) int {
+	rest, ok := strings.CutPrefix(ref, "refs/pull/")
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSuffix(rest, "/merge"))
+	return n
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/trankhanh040147/revcli/internal/filter"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var (
+	redactSecrets         bool
+	updateSecretsBaseline bool
+)
+
+// secretScanner holds the scanner and settings resolved from the revcli config
+type secretScanner struct {
+	scanner      *filter.Scanner
+	baselinePath string
+	redact       bool
+}
+
+// loadSecretScanner builds the secret scanner from the config's secrets section and the baseline file
+func loadSecretScanner(repoRoot string, secretsCfg *filter.SecretsConfig) (*secretScanner, error) {
+	if secretsCfg == nil {
+		secretsCfg = &filter.SecretsConfig{}
+	}
+
+	switch secretsCfg.Mode {
+	case "", filter.SecretsModeBlock, filter.SecretsModeRedact:
+	default:
+		return nil, fmt.Errorf("invalid options.secrets.mode %q (supported: block, redact)", secretsCfg.Mode)
+	}
+
+	baselinePath := filter.ProjectBaselinePath(repoRoot)
+	if secretsCfg.Baseline != "" {
+		baselinePath = secretsCfg.Baseline
+		if !filepath.IsAbs(baselinePath) {
+			baselinePath = filepath.Join(repoRoot, baselinePath)
+		}
+	}
+	baseline, err := filter.LoadBaseline(baselinePath)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner, err := filter.NewScanner(secretsCfg, baseline)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretScanner{
+		scanner:      scanner,
+		baselinePath: baselinePath,
+		redact:       redactSecrets || secretsCfg.Mode == filter.SecretsModeRedact,
+	}, nil
+}
+
+// writeSecretsBaseline appends the detected secrets to the baseline file
+func writeSecretsBaseline(w io.Writer, path string, matches []filter.SecretMatch) error {
+	if err := filter.AppendBaseline(path, matches); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, ui.RenderSuccess(fmt.Sprintf("Added %d secret match(es) to %s", len(matches), path)))
+	fmt.Fprintln(w, ui.RenderHelp("Commit the baseline so teammates skip the same false positives"))
+	return nil
+}
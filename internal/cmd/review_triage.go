@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/triage"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+const (
+	// maxDismissedConstraints caps how many dismissed findings the review instructions list, newest first
+	maxDismissedConstraints = 50
+	// triageMessageWidth is how much of a finding the triage list shows
+	triageMessageWidth = 60
+)
+
+// reviewTriageCmd lists the triage decisions of the repository
+var reviewTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "List the triaged findings of this repository",
+	Long: `List the findings marked in the review TUI (press "t" once the review is done),
+most recent first.
+
+Dismissed findings are left out of future reviews of the repository: the model is
+told not to report them again, and --output json/sarif, --fail-on and --publish
+drop any that are reported anyway.`,
+	Args: cobra.NoArgs,
+	RunE: runReviewTriage,
+}
+
+// reviewTriageClearCmd forgets a triage decision
+var reviewTriageClearCmd = &cobra.Command{
+	Use:   "clear <fingerprint>",
+	Short: "Forget the triage decision on a finding",
+	Long: `Forget the decision on a finding, so a dismissed finding is reported again.
+<fingerprint> is one from "revcli review triage", or any unique prefix of one.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewTriageClear,
+}
+
+func runReviewTriage(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	repoRoot, err := git.GetGitRoot()
+	if err != nil {
+		return err
+	}
+	appInstance, err := setupApp(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to setup app: %w", err)
+	}
+	defer appInstance.Shutdown()
+
+	decisions, err := appInstance.Triage.List(ctx, repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to list triaged findings: %w", err)
+	}
+	if len(decisions) == 0 {
+		fmt.Println(ui.RenderSubtitle("No triaged findings for this repository."))
+		return nil
+	}
+	return printTriage(os.Stdout, decisions)
+}
+
+// printTriage prints one row per decision
+func printTriage(out io.Writer, decisions []triage.Decision) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FINGERPRINT\tSTATUS\tDATE\tFILE\tFINDING\tREASON")
+	for _, d := range decisions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.Fingerprint,
+			d.Status,
+			time.Unix(d.UpdatedAt, 0).Format("2006-01-02 15:04"),
+			lo.CoalesceOrEmpty(d.File, "-"),
+			lo.Ellipsis(d.Message, triageMessageWidth),
+			lo.CoalesceOrEmpty(d.Reason, "-"),
+		)
+	}
+	return w.Flush()
+}
+
+func runReviewTriageClear(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	repoRoot, err := git.GetGitRoot()
+	if err != nil {
+		return err
+	}
+	appInstance, err := setupApp(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to setup app: %w", err)
+	}
+	defer appInstance.Shutdown()
+
+	decisions, err := appInstance.Triage.List(ctx, repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to list triaged findings: %w", err)
+	}
+	matches := lo.Filter(decisions, func(d triage.Decision, _ int) bool {
+		return strings.HasPrefix(d.Fingerprint, args[0])
+	})
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no triaged finding %q in this repository (see revcli review triage)", args[0])
+	case 1:
+	default:
+		return fmt.Errorf("fingerprint %q is ambiguous: %d findings match", args[0], len(matches))
+	}
+
+	if err := appInstance.Triage.Clear(ctx, repoRoot, matches[0].Fingerprint); err != nil {
+		return fmt.Errorf("failed to clear triage: %w", err)
+	}
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Cleared the %s decision on %s", matches[0].Status, lo.Ellipsis(matches[0].Message, triageMessageWidth))))
+	return nil
+}
+
+// withDismissedFindings returns a copy of intent that tells the model not to report the dismissed findings again
+func withDismissedFindings(intent *appcontext.Intent, dismissed []triage.Decision) *appcontext.Intent {
+	if len(dismissed) == 0 {
+		return intent
+	}
+	withDismissed := appcontext.Intent{WebSearchEnabled: true}
+	if intent != nil {
+		withDismissed = *intent
+	}
+	constraints := lo.Map(dismissed[:min(len(dismissed), maxDismissedConstraints)], func(d triage.Decision, _ int) string {
+		return d.Constraint()
+	})
+	withDismissed.NegativeConstraints = append(slices.Clone(withDismissed.NegativeConstraints), constraints...)
+	return &withDismissed
+}
@@ -9,7 +9,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
@@ -25,6 +24,7 @@ import (
 	"github.com/trankhanh040147/revcli/internal/app"
 	"github.com/trankhanh040147/revcli/internal/config"
 	"github.com/trankhanh040147/revcli/internal/db"
+	"github.com/trankhanh040147/revcli/internal/egress"
 	"github.com/trankhanh040147/revcli/internal/event"
 	"github.com/trankhanh040147/revcli/internal/projects"
 	"github.com/trankhanh040147/revcli/internal/stringext"
@@ -36,6 +36,7 @@ func init() {
 	rootCmd.PersistentFlags().StringP("cwd", "c", "", "Current working directory")
 	rootCmd.PersistentFlags().StringP("data-dir", "D", "", "Custom revcli data directory")
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Debug")
+	rootCmd.PersistentFlags().Bool("offline", false, "Only reach the selected models' providers and options.allowed_hosts")
 	rootCmd.Flags().BoolP("help", "h", false, "Help")
 	rootCmd.Flags().BoolP("yolo", "y", false, "Automatically accept all permissions (dangerous mode)")
 
@@ -43,6 +44,10 @@ func init() {
 		reviewCmd,
 		presetCmd,
 		updateProvidersCmd,
+		telemetryCmd,
+		configCmd,
+		permissionsCmd,
+		hookCmd,
 		// runCmd,
 		// dirsCmd,
 		// projectsCmd,
@@ -127,7 +132,7 @@ func Execute() {
 		fang.WithVersion(version.Version),
 		fang.WithNotifySignal(os.Interrupt),
 	); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -158,6 +163,7 @@ func setupApp(cmd *cobra.Command) (*app.App, error) {
 	debug, _ := cmd.Flags().GetBool("debug")
 	yolo, _ := cmd.Flags().GetBool("yolo")
 	dataDir, _ := cmd.Flags().GetString("data-dir")
+	offline, _ := cmd.Flags().GetBool("offline")
 	ctx := cmd.Context()
 
 	cwd, err := ResolveCwd(cmd)
@@ -165,10 +171,19 @@ func setupApp(cmd *cobra.Command) (*app.App, error) {
 		return nil, err
 	}
 
-	cfg, err := config.Init(cwd, dataDir, debug)
+	warnLegacyConfig()
+	cfg, err := config.Init(cwd, dataDir, debug, offline)
 	if err != nil {
 		return nil, err
 	}
+	if err := applyConfigFlags(cmd, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Options.Offline {
+		hosts := cfg.EgressHosts()
+		egress.Install(hosts)
+		slog.Info("Offline mode", "allowed_hosts", hosts)
+	}
 
 	if cfg.Permissions == nil {
 		cfg.Permissions = &config.Permissions{}
@@ -178,6 +193,7 @@ func setupApp(cmd *cobra.Command) (*app.App, error) {
 	if err := createDotRevcliDir(cfg.Options.DataDirectory); err != nil {
 		return nil, err
 	}
+	setupTelemetry(cfg)
 
 	// Register this project in the centralized projects list.
 	if err := projects.Register(cwd, cfg.Options.DataDirectory); err != nil {
@@ -197,26 +213,9 @@ func setupApp(cmd *cobra.Command) (*app.App, error) {
 		return nil, err
 	}
 
-	if shouldEnableMetrics() {
-		event.Init()
-	}
-
 	return appInstance, nil
 }
 
-func shouldEnableMetrics() bool {
-	if v, _ := strconv.ParseBool(os.Getenv("REVCLI_DISABLE_METRICS")); v {
-		return false
-	}
-	if v, _ := strconv.ParseBool(os.Getenv("DO_NOT_TRACK")); v {
-		return false
-	}
-	if config.Get().Options.DisableMetrics {
-		return false
-	}
-	return true
-}
-
 func MaybeRevcliPrependStdin(prompt string) (string, error) {
 	if term.IsTerminal(os.Stdin.Fd()) {
 		return prompt, nil
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/event"
+	"github.com/trankhanh040147/revcli/internal/ui"
+)
+
+var telemetryLocalFlag bool
+
+// telemetryCmd shows and changes where usage events are recorded
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Show or change where usage metrics are recorded",
+	Long: `Show or change where revcli records usage events (commands run, models, token
+counts and cost; never code, prompts or responses).
+
+  off     record nothing
+  local   append events as JSON lines to events.jsonl in the data directory
+  remote  send anonymous events to PostHog
+
+The choice is asked on the first interactive run and stored in the global data config.
+REVCLI_DISABLE_METRICS, DO_NOT_TRACK, options.disable_metrics and --offline turn remote off.`,
+}
+
+// telemetryShowCmd prints the current telemetry settings
+var telemetryShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show what is recorded and where",
+	Args:  cobra.NoArgs,
+	RunE:  runTelemetryShow,
+}
+
+// telemetryEnableCmd turns telemetry on
+var telemetryEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Record usage events (remote, or local with --local)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode := event.ModeRemote
+		if telemetryLocalFlag {
+			mode = event.ModeLocal
+		}
+		return setTelemetryMode(mode)
+	},
+}
+
+// telemetryDisableCmd turns telemetry off
+var telemetryDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Record no usage events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setTelemetryMode(event.ModeOff)
+	},
+}
+
+func init() {
+	telemetryEnableCmd.Flags().BoolVar(&telemetryLocalFlag, "local", false, "Write events to the data directory only; nothing leaves the machine")
+	telemetryCmd.AddCommand(telemetryShowCmd, telemetryEnableCmd, telemetryDisableCmd)
+}
+
+func runTelemetryShow(cmd *cobra.Command, args []string) error {
+	cwd, err := ResolveCwd(cmd)
+	if err != nil {
+		return err
+	}
+	dataDir, _ := cmd.Flags().GetString("data-dir")
+	opts, err := config.LoadOptions(cwd, dataDir)
+	if err != nil {
+		return err
+	}
+
+	mode := event.Mode(opts.Telemetry)
+	if mode == "" {
+		mode = event.ModeOff
+		fmt.Println(ui.RenderSubtitle("Not chosen yet: nothing is recorded, and you will be asked on the next interactive run."))
+	}
+	fmt.Printf("Mode:        %s\n", mode)
+	remote := "off"
+	if mode == event.ModeRemote {
+		remote = event.Endpoint()
+		if !metricsAllowed(opts) {
+			remote = "off (disabled by environment, options.disable_metrics or offline mode)"
+		}
+	}
+	fmt.Printf("Remote:      %s\n", remote)
+	fmt.Printf("Machine ID:  %s\n", event.MachineID())
+
+	localPath := filepath.Join(opts.DataDirectory, event.LocalFile)
+	count, err := countLines(localPath)
+	switch {
+	case os.IsNotExist(err):
+		fmt.Printf("Local log:   %s (no events)\n", localPath)
+	case err != nil:
+		return fmt.Errorf("failed to read local event log: %w", err)
+	default:
+		fmt.Printf("Local log:   %s (%d events)\n", localPath, count)
+	}
+	return nil
+}
+
+// setTelemetryMode stores the telemetry mode in the global data config
+func setTelemetryMode(mode event.Mode) error {
+	if err := config.SetGlobalOption("telemetry", string(mode)); err != nil {
+		return fmt.Errorf("failed to save telemetry setting: %w", err)
+	}
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Telemetry set to %s in %s", mode, config.GlobalConfigData())))
+	return nil
+}
+
+// setupTelemetry starts the event sinks the user consented to, asking on the first interactive run
+func setupTelemetry(cfg *config.Config) {
+	mode, err := event.ParseMode(cfg.Options.Telemetry)
+	if cfg.Options.Telemetry == "" {
+		mode = askTelemetryConsent(cfg)
+	} else if err != nil {
+		slog.Warn("Ignoring telemetry setting", "error", err)
+		mode = event.ModeOff
+	}
+
+	switch mode {
+	case event.ModeRemote:
+		if metricsAllowed(cfg.Options) {
+			event.Init()
+		}
+	case event.ModeLocal:
+		if err := event.InitLocal(filepath.Join(cfg.Options.DataDirectory, event.LocalFile)); err != nil {
+			slog.Warn("Failed to start local telemetry", "error", err)
+		}
+	}
+}
+
+// askTelemetryConsent asks for and stores the telemetry mode. Without a terminal nothing is
+// recorded and the question waits for an interactive run.
+func askTelemetryConsent(cfg *config.Config) event.Mode {
+	if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		return event.ModeOff
+	}
+	mode, err := ui.AskTelemetryConsent()
+	if err != nil {
+		return event.ModeOff
+	}
+	if err := cfg.SetConfigField("options.telemetry", string(mode)); err != nil {
+		slog.Warn("Failed to save telemetry setting", "error", err)
+	}
+	cfg.Options.Telemetry = string(mode)
+	return mode
+}
+
+// metricsAllowed reports whether events may be sent remotely
+func metricsAllowed(opts *config.Options) bool {
+	if v, _ := strconv.ParseBool(os.Getenv("REVCLI_DISABLE_METRICS")); v {
+		return false
+	}
+	if v, _ := strconv.ParseBool(os.Getenv("DO_NOT_TRACK")); v {
+		return false
+	}
+	return !opts.DisableMetrics
+}
+
+// countLines counts the lines of a file
+func countLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	count := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
@@ -64,7 +64,8 @@ const (
 const (
 	AgentReviewer string = "reviewer" // Changed from AgentCoder
 	AgentTask     string = "task"
-	// TODO(PlanC): Add AgentBuilder, AgentAsker constants for future modes
+	AgentFixer    string = "fixer" // Fix mode: applies review suggestions
+	AgentAsker    string = "asker" // Ask mode: answers questions about the codebase
 )
 
 type SelectedModel struct {
@@ -258,18 +259,32 @@ func (Attribution) JSONSchemaExtend(schema *jsonschema.Schema) {
 }
 
 type Options struct {
-	ContextPaths              []string     `json:"context_paths,omitempty" jsonschema:"description=Paths to files containing context information for the AI,example=.cursorrules,example=AGENTS.md"`
-	SkillsPaths               []string     `json:"skills_paths,omitempty" jsonschema:"description=Paths to directories containing Agent Skills (folders with SKILL.md files),example=~/.config/revcli/skills,example=./skills"`
-	TUI                       *TUIOptions  `json:"tui,omitempty" jsonschema:"description=Terminal user interface options"`
-	Debug                     bool         `json:"debug,omitempty" jsonschema:"description=Enable debug logging,default=false"`
-	DebugLSP                  bool         `json:"debug_lsp,omitempty" jsonschema:"description=Enable debug logging for LSP servers,default=false"`
-	DisableAutoSummarize      bool         `json:"disable_auto_summarize,omitempty" jsonschema:"description=Disable automatic conversation summarization,default=false"`
-	DataDirectory             string       `json:"data_directory,omitempty" jsonschema:"description=Directory for storing application data (relative to working directory),default=.revcli,example=.revcli"` // Relative to the cwd
-	DisabledTools             []string     `json:"disabled_tools,omitempty" jsonschema:"description=List of built-in tools to disable and hide from the agent,example=bash,example=sourcegraph"`
-	DisableProviderAutoUpdate bool         `json:"disable_provider_auto_update,omitempty" jsonschema:"description=Disable providers auto-update,default=false"`
-	Attribution               *Attribution `json:"attribution,omitempty" jsonschema:"description=Attribution settings for generated content"`
-	DisableMetrics            bool         `json:"disable_metrics,omitempty" jsonschema:"description=Disable sending metrics,default=false"`
-	InitializeAs              string       `json:"initialize_as,omitempty" jsonschema:"description=Name of the context file to create/update during project initialization,default=AGENTS.md,example=AGENTS.md,example=CLAUDE.md,example=docs/LLMs.md"`
+	ContextPaths              []string           `json:"context_paths,omitempty" jsonschema:"description=Paths to files containing context information for the AI,example=.cursorrules,example=AGENTS.md"`
+	SkillsPaths               []string           `json:"skills_paths,omitempty" jsonschema:"description=Paths to directories containing Agent Skills (folders with SKILL.md files),example=~/.config/revcli/skills,example=./skills"`
+	TUI                       *TUIOptions        `json:"tui,omitempty" jsonschema:"description=Terminal user interface options"`
+	Debug                     bool               `json:"debug,omitempty" jsonschema:"description=Enable debug logging,default=false"`
+	DebugLSP                  bool               `json:"debug_lsp,omitempty" jsonschema:"description=Enable debug logging for LSP servers,default=false"`
+	DisableAutoSummarize      bool               `json:"disable_auto_summarize,omitempty" jsonschema:"description=Disable automatic conversation summarization,default=false"`
+	DataDirectory             string             `json:"data_directory,omitempty" jsonschema:"description=Directory for storing application data (relative to working directory),default=.revcli,example=.revcli"` // Relative to the cwd
+	DisabledTools             []string           `json:"disabled_tools,omitempty" jsonschema:"description=List of built-in tools to disable and hide from the agent,example=bash,example=sourcegraph"`
+	DisableProviderAutoUpdate bool               `json:"disable_provider_auto_update,omitempty" jsonschema:"description=Disable providers auto-update,default=false"`
+	Attribution               *Attribution       `json:"attribution,omitempty" jsonschema:"description=Attribution settings for generated content"`
+	DisableMetrics            bool               `json:"disable_metrics,omitempty" jsonschema:"description=Disable sending metrics,default=false"`
+	Telemetry                 string             `json:"telemetry,omitempty" jsonschema:"description=Where usage events are recorded: off, local (JSON lines in the data directory) or remote; asked on the first interactive run,enum=off,enum=local,enum=remote"`
+	InitializeAs              string             `json:"initialize_as,omitempty" jsonschema:"description=Name of the context file to create/update during project initialization,default=AGENTS.md,example=AGENTS.md,example=CLAUDE.md,example=docs/LLMs.md"`
+	Offline                   bool               `json:"offline,omitempty" jsonschema:"description=Only reach the selected models' providers and the allowed hosts: disables metrics, update checks, provider auto-update, network tools and remote MCP servers,default=false"`
+	AllowedHosts              []string           `json:"allowed_hosts,omitempty" jsonschema:"description=Hosts that may be reached in offline mode besides the selected models' providers; *.example.com allows its subdomains,example=llm.internal.example.com"`
+	DefaultPreset             string             `json:"default_preset,omitempty" jsonschema:"description=Review preset used when --preset is not given,example=security"`
+	Generation                *GenerationOptions `json:"generation,omitempty" jsonschema:"description=Sampling parameters sent to every provider; a model's own settings take precedence"`
+	Secrets                   *SecretsConfig     `json:"secrets,omitempty" jsonschema:"description=Secret detection run on the diff before it is sent"`
+	Hooks                     *HooksConfig       `json:"hooks,omitempty" jsonschema:"description=Git hooks installed by revcli hook install"`
+}
+
+// GenerationOptions are the sampling parameters of every model call, unless the selected model sets its own
+type GenerationOptions struct {
+	Temperature *float64 `json:"temperature,omitempty" jsonschema:"description=Sampling temperature,minimum=0,maximum=2,example=0.3"`
+	TopP        *float64 `json:"top_p,omitempty" jsonschema:"description=Nucleus sampling probability mass,minimum=0,maximum=1,example=0.95"`
+	TopK        *int64   `json:"top_k,omitempty" jsonschema:"description=Number of most likely tokens sampled from,minimum=1,example=40"`
 }
 
 type MCPs map[string]MCPConfig
@@ -356,6 +371,9 @@ type Agent struct {
 
 	// Overrides the context paths for this agent
 	ContextPaths []string `json:"context_paths,omitempty"`
+
+	// Runs the bash tool in the read-only review sandbox instead of asking for permission
+	ReadOnlyShell bool `json:"read_only_shell,omitempty"`
 }
 
 type Tools struct {
@@ -494,6 +512,57 @@ func (c *Config) UpdatePreferredModel(modelType SelectedModelType, model Selecte
 	return nil
 }
 
+// SelectLargeModel makes id the large model for this run, without saving it. id is a model ID,
+// optionally prefixed with its provider (openai/gpt-5); a bare ID is looked up in the selected
+// large model's provider first, then in the other enabled providers. "small" selects the configured
+// small model, unless a provider has a model of that ID.
+func (c *Config) SelectLargeModel(id string) error {
+	var provider string
+	if p, m, ok := strings.Cut(id, "/"); ok && c.GetModel(p, m) != nil {
+		provider, id = p, m
+	} else if current := c.Models[SelectedModelTypeLarge].Provider; c.GetModel(current, id) != nil {
+		provider = current
+	} else {
+		enabled := c.EnabledProviders()
+		slices.SortFunc(enabled, func(a, b ProviderConfig) int {
+			return strings.Compare(a.ID, b.ID)
+		})
+		for _, p := range enabled {
+			if c.GetModel(p.ID, id) != nil {
+				provider = p.ID
+				break
+			}
+		}
+	}
+	if small, ok := c.Models[SelectedModelTypeSmall]; provider == "" && id == string(SelectedModelTypeSmall) && ok {
+		c.Models[SelectedModelTypeLarge] = small
+		return nil
+	}
+	if provider == "" {
+		return fmt.Errorf("model %q not found in the configured providers", id)
+	}
+
+	model := c.GetModel(provider, id)
+	c.Models[SelectedModelTypeLarge] = SelectedModel{
+		Provider:        provider,
+		Model:           model.ID,
+		MaxTokens:       model.DefaultMaxTokens,
+		ReasoningEffort: model.DefaultReasoningEffort,
+	}
+	return nil
+}
+
+// WithLargeModel returns a copy of the config with id as the large model (see SelectLargeModel).
+// Everything but the selected models is shared, so runs with different models can use copies side by side.
+func (c *Config) WithLargeModel(id string) (*Config, error) {
+	clone := *c
+	clone.Models = maps.Clone(c.Models)
+	if err := clone.SelectLargeModel(id); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
 func (c *Config) HasConfigField(key string) bool {
 	data, err := os.ReadFile(c.dataConfigDir)
 	if err != nil {
@@ -697,6 +766,7 @@ func allToolNames() []string {
 		toolConstants.ReferencesToolName,
 		toolConstants.FetchToolName,
 		toolConstants.AgenticFetchToolName,
+		toolConstants.WebSearchToolName,
 		toolConstants.GlobToolName,
 		toolConstants.GrepToolName,
 		toolConstants.LSToolName,
@@ -729,29 +799,53 @@ func resolveReadOnlyTools(tools []string) []string {
 
 func resolveReviewTools(allowedTools []string) []string {
 	readOnlyTools := []string{
+		// The reviewer's bash runs in the read-only sandbox (Agent.ReadOnlyShell)
 		toolConstants.BashToolName,
-		toolConstants.TodosToolName,
+		toolConstants.JobOutputToolName,
+		toolConstants.JobKillToolName,
+		toolConstants.ViewToolName,
 		toolConstants.GlobToolName,
 		toolConstants.GrepToolName,
 		toolConstants.LSToolName,
 		toolConstants.SourcegraphToolName,
-		toolConstants.ViewToolName,
 		toolConstants.DiagnosticsToolName,
 		toolConstants.ReferencesToolName,
-		toolConstants.JobOutputToolName,
-		toolConstants.JobKillToolName,
+		// Web tools; withheld per request while web access is off
 		toolConstants.FetchToolName,
-		toolConstants.DownloadToolName,
 		toolConstants.AgenticFetchToolName,
-		toolConstants.AgentToolName,
-		// toolConstants.WriteToolName,
-		// toolConstants.EditToolName,
-		// toolConstants.MultiEditToolName,
+		toolConstants.WebSearchToolName,
 	}
 	// filter to only include tools that are in allowedtools (include mode)
 	return filterSlice(allowedTools, readOnlyTools, true)
 }
 
+func resolveFixTools(allowedTools []string) []string {
+	fixTools := append(resolveReviewTools(allowedTools),
+		toolConstants.EditToolName,
+		toolConstants.MultiEditToolName,
+	)
+	// filter to only include tools that are in allowedtools (include mode)
+	return filterSlice(allowedTools, fixTools, true)
+}
+
+func resolveAskTools(allowedTools []string) []string {
+	askTools := []string{
+		toolConstants.GlobToolName,
+		toolConstants.GrepToolName,
+		toolConstants.LSToolName,
+		toolConstants.SourcegraphToolName,
+		toolConstants.ViewToolName,
+		toolConstants.DiagnosticsToolName,
+		toolConstants.ReferencesToolName,
+		toolConstants.AgentToolName,
+		toolConstants.FetchToolName,
+		toolConstants.AgenticFetchToolName,
+		toolConstants.WebSearchToolName,
+	}
+	// filter to only include tools that are in allowedtools (include mode)
+	return filterSlice(allowedTools, askTools, true)
+}
+
 func filterSlice(data []string, mask []string, include bool) []string {
 	filtered := []string{}
 	for _, s := range data {
@@ -764,25 +858,40 @@ func filterSlice(data []string, mask []string, include bool) []string {
 	return filtered
 }
 
-// Tool filtering strategy for future modes:
-// - Review mode: read-only tools by default, write tools optional (user configurable)
-// - Build mode: all tools available (full code generation capability)
-// - Ask mode: read-only tools + specific Q&A tools
-// TODO(PlanC): Implement mode-based tool filtering in coordinator
-// TODO(PlanC): Implement builderPrompt for build mode
-// TODO(PlanC): Implement askerPrompt for ask mode
+// Tool filtering per mode:
+// - Review mode: analysis tools (sandboxed bash, fetch), no file edits
+// - Fix mode: review tools + edit/multiedit to apply suggestions (with permission prompts)
+// - Ask mode: read-only tools for questions about the codebase
 func (c *Config) SetupAgents() {
 	allowedTools := resolveAllowedTools(allToolNames(), c.Options.DisabledTools)
 
 	agents := map[string]Agent{
 		AgentReviewer: {
-			ID:           AgentReviewer,
-			Name:         "Reviewer",
-			Description:  "An agent that reviews code and provides feedback.",
+			ID:            AgentReviewer,
+			Name:          "Reviewer",
+			Description:   "An agent that reviews code and provides feedback.",
+			Model:         SelectedModelTypeLarge,
+			ContextPaths:  c.Options.ContextPaths,
+			AllowedTools:  resolveReviewTools(allowedTools),
+			ReadOnlyShell: true,
+		},
+
+		AgentFixer: {
+			ID:           AgentFixer,
+			Name:         "Fixer",
+			Description:  "An agent that applies code review suggestions.",
+			Model:        SelectedModelTypeLarge,
+			ContextPaths: c.Options.ContextPaths,
+			AllowedTools: resolveFixTools(allowedTools),
+		},
+
+		AgentAsker: {
+			ID:           AgentAsker,
+			Name:         "Asker",
+			Description:  "An agent that answers questions about the codebase.",
 			Model:        SelectedModelTypeLarge,
 			ContextPaths: c.Options.ContextPaths,
-			AllowedTools: resolveReviewTools(allowedTools),
-			// TODO(PlanC): Add Mode field for mode-based tool filtering
+			AllowedTools: resolveAskTools(allowedTools),
 		},
 
 		AgentTask: {
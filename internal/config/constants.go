@@ -4,6 +4,16 @@ package config
 const (
 	EnvGeminiAPIKey = "GEMINI_API_KEY"
 	EnvEditor       = "EDITOR"
+
+	// Forge settings used by review --publish (defaults match GitHub Actions and GitLab CI)
+	EnvGitHubToken      = "GITHUB_TOKEN"
+	EnvGitHubRepository = "GITHUB_REPOSITORY"
+	EnvGitHubAPIURL     = "GITHUB_API_URL"
+	EnvGitHubRef        = "GITHUB_REF"
+	EnvGitLabToken      = "GITLAB_TOKEN"
+	EnvGitLabAPIURL     = "CI_API_V4_URL"
+	EnvGitLabProjectID  = "CI_PROJECT_ID"
+	EnvGitLabMRIID      = "CI_MERGE_REQUEST_IID"
 )
 
 // Model names
@@ -17,4 +27,11 @@ const (
 	ConfigDirName   = ".config"
 	AppDirName      = "revcli"
 	SessionsDirName = "sessions"
+	// ProjectDirName is the per-repository directory (e.g. <repo>/.revcli/ignore)
+	ProjectDirName = ".revcli"
+	IgnoreFileName = "ignore"
+	// SecretsBaselineFileName is the per-repository allowlist of known false-positive secrets
+	SecretsBaselineFileName = "secrets-baseline"
+	// PermissionPolicyFileName is the per-repository permission policy for tools and commands
+	PermissionPolicyFileName = "permissions.json"
 )
@@ -0,0 +1,19 @@
+package config
+
+// HookConfig configures a git hook installed by "revcli hook install"
+type HookConfig struct {
+	// Preset is the review preset (default: quick for pre-commit, default_preset for pre-push)
+	Preset string `json:"preset,omitempty" jsonschema:"description=Review preset the hook runs,example=quick"`
+	// Model is a model ID, provider/model, or small (default: small for pre-commit, the large model for pre-push)
+	Model string `json:"model,omitempty" jsonschema:"description=Model the hook reviews with: a model ID or provider/model; small selects models.small,example=small"`
+	// FailOn is the severity that blocks the commit or push (default: critical)
+	FailOn string `json:"fail_on,omitempty" jsonschema:"description=Severity of findings that blocks the commit or push,enum=critical,enum=warning,enum=any,default=critical"`
+	// Timeout is how long the review may take, e.g. "2m"; a review that takes longer does not block
+	Timeout string `json:"timeout,omitempty" jsonschema:"description=How long the review may take before the hook lets the commit or push through (Go duration),example=2m"`
+}
+
+// HooksConfig configures the git hooks revcli installs
+type HooksConfig struct {
+	PreCommit *HookConfig `json:"pre_commit,omitempty" jsonschema:"description=The pre-commit hook, which reviews the staged changes"`
+	PrePush   *HookConfig `json:"pre_push,omitempty" jsonschema:"description=The pre-push hook, which reviews the commits since the upstream branch"`
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/charmbracelet/catwalk/pkg/catwalk"
 	powernapConfig "github.com/charmbracelet/x/powernap/pkg/config"
+	"github.com/tidwall/sjson"
 	"github.com/trankhanh040147/revcli/internal/agent/hyper"
 	"github.com/trankhanh040147/revcli/internal/csync"
 	"github.com/trankhanh040147/revcli/internal/env"
@@ -44,8 +45,9 @@ func LoadReader(fd io.Reader) (*Config, error) {
 	return &config, err
 }
 
-// Load loads the configuration from the default paths.
-func Load(workingDir, dataDir string, debug bool) (*Config, error) {
+// Load loads the configuration from the default paths. offline turns on offline mode on top of the
+// config and the environment.
+func Load(workingDir, dataDir string, debug, offline bool) (*Config, error) {
 	configPaths := lookupConfigs(workingDir)
 
 	cfg, err := loadFromConfigPaths(configPaths)
@@ -60,6 +62,11 @@ func Load(workingDir, dataDir string, debug bool) (*Config, error) {
 	if debug {
 		cfg.Options.Debug = true
 	}
+	// Offline mode must be settled before providers are loaded, as it disables their auto-update
+	if offline {
+		cfg.Options.Offline = true
+		cfg.applyOffline()
+	}
 
 	// Setup logs
 	log.Setup(
@@ -104,6 +111,83 @@ func Load(workingDir, dataDir string, debug bool) (*Config, error) {
 	return cfg, nil
 }
 
+// LoadOptions loads the options from the default paths without loading providers, for commands
+// that only need settings
+func LoadOptions(workingDir, dataDir string) (*Options, error) {
+	configPaths := lookupConfigs(workingDir)
+	cfg, err := loadFromConfigPaths(configPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from paths %v: %w", configPaths, err)
+	}
+	cfg.setDefaults(workingDir, dataDir)
+	return cfg.Options, nil
+}
+
+// SetGlobalOption sets an option, e.g. "telemetry", in the global data config
+func SetGlobalOption(key string, value any) error {
+	return SetGlobalField("options."+key, value)
+}
+
+// UnsetGlobalOption removes an option from the global data config
+func UnsetGlobalOption(key string) error {
+	path := GlobalConfigData()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	newValue, err := sjson.Delete(string(data), "options."+key)
+	if err != nil {
+		return fmt.Errorf("failed to unset option %s: %w", key, err)
+	}
+	if err := os.WriteFile(path, []byte(newValue), 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// SetGlobalField sets a field, e.g. "options.telemetry", in the global data config
+func SetGlobalField(key string, value any) error {
+	cfg := &Config{dataConfigDir: GlobalConfigData()}
+	return cfg.SetConfigField(key, value)
+}
+
+// HasGlobalField reports whether a field is set in the global data config
+func HasGlobalField(key string) bool {
+	cfg := &Config{dataConfigDir: GlobalConfigData()}
+	return cfg.HasConfigField(key)
+}
+
+// applyEnvOptions applies the REVCLI_* environment variables, which override the config files
+func (c *Config) applyEnvOptions() {
+	if v := os.Getenv("REVCLI_DEFAULT_PRESET"); v != "" {
+		c.Options.DefaultPreset = v
+	}
+	gen := c.Options.Generation
+	for name, field := range map[string]**float64{
+		"REVCLI_TEMPERATURE": &gen.Temperature,
+		"REVCLI_TOP_P":       &gen.TopP,
+	} {
+		if v := os.Getenv(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				slog.Warn("Ignoring invalid environment variable", "name", name, "error", err)
+				continue
+			}
+			*field = &f
+		}
+	}
+	if v := os.Getenv("REVCLI_TOP_K"); v != "" {
+		k, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			slog.Warn("Ignoring invalid environment variable", "name", "REVCLI_TOP_K", "error", err)
+			return
+		}
+		gen.TopK = &k
+	}
+}
+
 func PushPopCrushEnv() func() {
 	found := []string{}
 	for _, ev := range os.Environ() {
@@ -197,6 +281,7 @@ func (c *Config) configureProviders(env env.Env, resolver VariableResolver, know
 			ExtraHeaders:       headers,
 			ExtraBody:          config.ExtraBody,
 			ExtraParams:        make(map[string]string),
+			ProviderOptions:    config.ProviderOptions,
 			Models:             p.Models,
 		}
 
@@ -375,6 +460,18 @@ func (c *Config) setDefaults(workingDir, dataDir string) {
 		c.Options.DisableProviderAutoUpdate, _ = strconv.ParseBool(str)
 	}
 
+	if v, _ := strconv.ParseBool(os.Getenv("REVCLI_OFFLINE")); v {
+		c.Options.Offline = true
+	}
+	if c.Options.Offline {
+		c.applyOffline()
+	}
+
+	if c.Options.Generation == nil {
+		c.Options.Generation = &GenerationOptions{}
+	}
+	c.applyEnvOptions()
+
 	if c.Options.Attribution == nil {
 		c.Options.Attribution = &Attribution{
 			TrailerStyle:  TrailerStyleAssistedBy,
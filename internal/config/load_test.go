@@ -57,6 +57,118 @@ func TestConfig_setDefaults(t *testing.T) {
 	require.Equal(t, "/tmp", cfg.workingDir)
 }
 
+func TestConfig_setDefaultsOffline(t *testing.T) {
+	cfg := &Config{
+		Options: &Options{Offline: true, DisabledTools: []string{"bash", "fetch"}},
+		MCP: map[string]MCPConfig{
+			"local":  {Type: MCPStdio, Command: "server"},
+			"remote": {Type: MCPHttp, URL: "https://mcp.example.com"},
+		},
+	}
+
+	cfg.setDefaults("/tmp", "")
+
+	require.True(t, cfg.Options.DisableMetrics)
+	require.True(t, cfg.Options.DisableProviderAutoUpdate)
+	require.ElementsMatch(t, []string{"bash", "fetch", "agentic_fetch", "web_search", "download", "sourcegraph"}, cfg.Options.DisabledTools)
+	require.False(t, cfg.MCP["local"].Disabled)
+	require.True(t, cfg.MCP["remote"].Disabled)
+}
+
+func TestLoad_Offline(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("REVCLI_OFFLINE", "")
+
+	cfg, err := Load(dir, "", false, true)
+	require.NoError(t, err)
+	require.True(t, cfg.Options.Offline)
+	require.True(t, cfg.Options.DisableProviderAutoUpdate)
+	require.Contains(t, cfg.Options.DisabledTools, "web_search")
+}
+
+func TestConfig_EgressHosts(t *testing.T) {
+	cfg := &Config{
+		Options: &Options{AllowedHosts: []string{"*.corp.example"}},
+		Models: map[SelectedModelType]SelectedModel{
+			SelectedModelTypeLarge: {Provider: "local", Model: "big"},
+			SelectedModelTypeSmall: {Provider: "local", Model: "small"},
+		},
+		Providers: csync.NewMapFrom(map[string]ProviderConfig{
+			"local": {ID: "local", BaseURL: "$LLM_URL"},
+		}),
+		resolver: NewEnvironmentVariableResolver(env.NewFromMap(map[string]string{"LLM_URL": "https://llm.internal.example:8443/v1"})),
+	}
+
+	require.Equal(t, []string{"*.corp.example", "llm.internal.example"}, cfg.EgressHosts())
+}
+
+func TestConfig_setDefaultsEnvOptions(t *testing.T) {
+	t.Setenv("REVCLI_DEFAULT_PRESET", "security")
+	t.Setenv("REVCLI_TEMPERATURE", "0.2")
+	t.Setenv("REVCLI_TOP_K", "not-a-number")
+	topP := 0.9
+	cfg := &Config{
+		Options: &Options{DefaultPreset: "quick", Generation: &GenerationOptions{TopP: &topP}},
+	}
+
+	cfg.setDefaults("/tmp", "")
+
+	require.Equal(t, "security", cfg.Options.DefaultPreset)
+	require.Equal(t, 0.2, *cfg.Options.Generation.Temperature)
+	require.Equal(t, 0.9, *cfg.Options.Generation.TopP)
+	require.Nil(t, cfg.Options.Generation.TopK)
+}
+
+func TestConfig_SelectLargeModel(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Models: map[SelectedModelType]SelectedModel{
+				SelectedModelTypeLarge: {Provider: "openai", Model: "gpt-5"},
+			},
+			Providers: csync.NewMapFrom(map[string]ProviderConfig{
+				"openai":     {ID: "openai", Models: []catwalk.Model{{ID: "gpt-5"}, {ID: "gpt-5-mini", DefaultMaxTokens: 1000}}},
+				"openrouter": {ID: "openrouter", Models: []catwalk.Model{{ID: "openai/gpt-5"}, {ID: "google/gemini-2.5-pro"}}},
+			}),
+		}
+	}
+
+	tests := []struct {
+		id       string
+		provider string
+		model    string
+	}{
+		{"gpt-5-mini", "openai", "gpt-5-mini"},
+		{"openai/gpt-5", "openai", "gpt-5"},
+		{"openrouter/openai/gpt-5", "openrouter", "openai/gpt-5"},
+		{"google/gemini-2.5-pro", "openrouter", "google/gemini-2.5-pro"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			cfg := newConfig()
+			require.NoError(t, cfg.SelectLargeModel(tt.id))
+			large := cfg.Models[SelectedModelTypeLarge]
+			require.Equal(t, tt.provider, large.Provider)
+			require.Equal(t, tt.model, large.Model)
+		})
+	}
+
+	require.ErrorContains(t, newConfig().SelectLargeModel("missing"), `model "missing" not found`)
+
+	cfg := newConfig()
+	cfg.Models[SelectedModelTypeSmall] = SelectedModel{Provider: "openai", Model: "gpt-5-mini"}
+	require.NoError(t, cfg.SelectLargeModel("small"))
+	require.Equal(t, "gpt-5-mini", cfg.Models[SelectedModelTypeLarge].Model)
+
+	cfg = newConfig()
+	clone, err := cfg.WithLargeModel("google/gemini-2.5-pro")
+	require.NoError(t, err)
+	require.Equal(t, "google/gemini-2.5-pro", clone.Models[SelectedModelTypeLarge].Model)
+	require.Equal(t, "gpt-5", cfg.Models[SelectedModelTypeLarge].Model, "the original keeps its model")
+}
+
 func TestConfig_configureProviders(t *testing.T) {
 	knownProviders := []catwalk.Provider{
 		{
@@ -465,7 +577,14 @@ func TestConfig_setupAgentsWithNoDisabledTools(t *testing.T) {
 	cfg.SetupAgents()
 	reviewerAgent, ok := cfg.Agents[AgentReviewer]
 	require.True(t, ok)
-	assert.Equal(t, []string{"glob", "grep", "ls", "sourcegraph", "view"}, reviewerAgent.AllowedTools)
+	assert.Equal(t, []string{"bash", "job_output", "job_kill", "lsp_diagnostics", "lsp_references", "fetch", "agentic_fetch", "web_search", "glob", "grep", "ls", "sourcegraph", "view"}, reviewerAgent.AllowedTools)
+	assert.True(t, reviewerAgent.ReadOnlyShell)
+
+	// The fixer builds and runs the tests to verify its edits, so its bash asks for permission instead
+	fixerAgent, ok := cfg.Agents[AgentFixer]
+	require.True(t, ok)
+	assert.Contains(t, fixerAgent.AllowedTools, "bash")
+	assert.False(t, fixerAgent.ReadOnlyShell)
 
 	taskAgent, ok := cfg.Agents[AgentTask]
 	require.True(t, ok)
@@ -487,7 +606,7 @@ func TestConfig_setupAgentsWithDisabledTools(t *testing.T) {
 	reviewerAgent, ok := cfg.Agents[AgentReviewer]
 	require.True(t, ok)
 
-	assert.Equal(t, []string{"glob", "ls", "sourcegraph", "view"}, reviewerAgent.AllowedTools)
+	assert.Equal(t, []string{"bash", "job_output", "job_kill", "lsp_diagnostics", "lsp_references", "fetch", "agentic_fetch", "web_search", "glob", "ls", "sourcegraph", "view"}, reviewerAgent.AllowedTools)
 
 	taskAgent, ok := cfg.Agents[AgentTask]
 	require.True(t, ok)
@@ -510,7 +629,7 @@ func TestConfig_setupAgentsWithEveryReadOnlyToolDisabled(t *testing.T) {
 	cfg.SetupAgents()
 	reviewerAgent, ok := cfg.Agents[AgentReviewer]
 	require.True(t, ok)
-	assert.Equal(t, []string{}, reviewerAgent.AllowedTools)
+	assert.Equal(t, []string{"bash", "job_output", "job_kill", "lsp_diagnostics", "lsp_references", "fetch", "agentic_fetch", "web_search"}, reviewerAgent.AllowedTools)
 
 	taskAgent, ok := cfg.Agents[AgentTask]
 	require.True(t, ok)
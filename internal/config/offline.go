@@ -0,0 +1,54 @@
+package config
+
+import (
+	"net/url"
+	"slices"
+
+	toolConstants "github.com/trankhanh040147/revcli/internal/agent/tools/constants"
+)
+
+// offlineDisabledTools are the built-in tools that reach the network
+var offlineDisabledTools = []string{
+	toolConstants.FetchToolName,
+	toolConstants.AgenticFetchToolName,
+	toolConstants.WebSearchToolName,
+	toolConstants.DownloadToolName,
+	toolConstants.SourcegraphToolName,
+}
+
+// applyOffline turns off everything that reaches the network besides the model providers
+func (c *Config) applyOffline() {
+	c.Options.DisableMetrics = true
+	c.Options.DisableProviderAutoUpdate = true
+	for _, name := range offlineDisabledTools {
+		if !slices.Contains(c.Options.DisabledTools, name) {
+			c.Options.DisabledTools = append(c.Options.DisabledTools, name)
+		}
+	}
+	for name, mcp := range c.MCP {
+		if mcp.Type == MCPHttp || mcp.Type == MCPSSE {
+			mcp.Disabled = true
+			c.MCP[name] = mcp
+		}
+	}
+}
+
+// EgressHosts returns the hosts offline mode lets requests through to: the allowed hosts and
+// the hosts of the selected models' providers
+func (c *Config) EgressHosts() []string {
+	hosts := slices.Clone(c.Options.AllowedHosts)
+	for _, modelType := range []SelectedModelType{SelectedModelTypeLarge, SelectedModelTypeSmall} {
+		provider := c.GetProviderForModel(modelType)
+		if provider == nil || provider.BaseURL == "" {
+			continue
+		}
+		baseURL, err := c.Resolve(provider.BaseURL)
+		if err != nil {
+			continue
+		}
+		if u, err := url.Parse(baseURL); err == nil && u.Hostname() != "" && !slices.Contains(hosts, u.Hostname()) {
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,29 @@
+package config
+
+// SecretRule is a named regex that detects a kind of secret
+type SecretRule struct {
+	ID          string `json:"id" yaml:"id" jsonschema:"description=Rule ID reported with each match"`
+	Regex       string `json:"regex" yaml:"regex" jsonschema:"description=Regular expression matching the secret"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema:"description=What the rule detects"`
+}
+
+// EntropyConfig configures Shannon-entropy detection of random-looking strings
+type EntropyConfig struct {
+	Enabled   *bool   `json:"enabled,omitempty" yaml:"enabled,omitempty" jsonschema:"description=Flag high-entropy strings,default=true"`
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty" jsonschema:"description=Bits per character above which a string is flagged,default=4.5"`
+	MinLength int     `json:"min_length,omitempty" yaml:"min_length,omitempty" jsonschema:"description=Shortest string checked,default=20"`
+}
+
+// SecretsConfig configures the secret detection that runs before a diff is sent
+type SecretsConfig struct {
+	// Mode is "block" (default) or "redact"
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty" jsonschema:"description=What happens when secrets are found,enum=block,enum=redact,default=block"`
+	// Rules are added to the built-in rules
+	Rules []SecretRule `json:"rules,omitempty" yaml:"rules,omitempty" jsonschema:"description=Rules added to the built-in ones"`
+	// Entropy configures high-entropy string detection
+	Entropy *EntropyConfig `json:"entropy,omitempty" yaml:"entropy,omitempty" jsonschema:"description=High-entropy string detection"`
+	// ToolResults is "redact" (default), "block" or "off" for the output of the agent's tools
+	ToolResults string `json:"tool_results,omitempty" yaml:"tool_results,omitempty" jsonschema:"description=What happens to secrets in the output of the agent's tools (view, grep, bash, fetch, MCP) before it is sent: redact replaces them, block withholds the whole output,enum=redact,enum=block,enum=off,default=redact"`
+	// Baseline is the allowlist file (default: <repo>/.revcli/secrets-baseline)
+	Baseline string `json:"baseline,omitempty" yaml:"baseline,omitempty" jsonschema:"description=Allowlist of known false positives; relative to the repository root,default=.revcli/secrets-baseline"`
+}
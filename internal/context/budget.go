@@ -0,0 +1,227 @@
+package context
+
+import (
+	"cmp"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
+	"github.com/trankhanh040147/revcli/internal/prompt"
+)
+
+// DefaultTokenBudget is used when no budget is given and the model's context window is unknown
+const DefaultTokenBudget = 100000
+
+// Lines of unchanged code kept around each hunk when trimming file context
+const (
+	unchangedRegionPadding = 20
+	hunkWindowPadding      = 5
+)
+
+// TrimLevel describes how much of a file's full content was kept to fit the token budget
+type TrimLevel int
+
+// Trim levels, from least to most aggressive
+const (
+	TrimNone TrimLevel = iota
+	// TrimUnchangedRegions drops large unchanged regions far from any hunk
+	TrimUnchangedRegions
+	// TrimEnclosingFunctions keeps only the functions (or nearby lines) around hunks
+	TrimEnclosingFunctions
+	// TrimDiffOnly drops the full content; only the diff is sent
+	TrimDiffOnly
+)
+
+// String describes the trim level for summaries
+func (l TrimLevel) String() string {
+	switch l {
+	case TrimUnchangedRegions:
+		return "unchanged regions dropped"
+	case TrimEnclosingFunctions:
+		return "enclosing functions only"
+	case TrimDiffOnly:
+		return "diff only"
+	default:
+		return "full"
+	}
+}
+
+// TrimmedFile records how a file's context was reduced to fit the token budget
+type TrimmedFile struct {
+	Path         string
+	Level        TrimLevel
+	TokensBefore int
+	TokensAfter  int
+}
+
+// packContext reduces file contents until the prompt fits budget, degrading the largest
+// files first: unchanged regions, then enclosing functions only, then diff only.
+// contents is updated in place; files trimmed to diff only are removed from it.
+func packContext(files []*diff.FileDiff, contents map[string]string, budget int) []TrimmedFile {
+	// Contents are sent twice: in the prompt and as attachments
+	total := prompt.EstimateTokens(prompt.BuildReviewPrompt(files, contents))
+	for _, content := range contents {
+		total += prompt.EstimateTokens(content)
+	}
+	if budget <= 0 || total <= budget {
+		return nil
+	}
+
+	byPath := make(map[string]*diff.FileDiff, len(files))
+	for _, f := range files {
+		byPath[f.Path()] = f
+	}
+	paths := make([]string, 0, len(contents))
+	for path := range contents {
+		if byPath[path] != nil {
+			paths = append(paths, path)
+		}
+	}
+	// Largest files first, by path for a stable order
+	slices.SortFunc(paths, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(contents[b]), len(contents[a])), cmp.Compare(a, b))
+	})
+
+	// Every level trims the original content, since hunk line numbers refer to it
+	original := maps.Clone(contents)
+	trimmed := make(map[string]*TrimmedFile)
+	for _, level := range []TrimLevel{TrimUnchangedRegions, TrimEnclosingFunctions, TrimDiffOnly} {
+		for _, path := range paths {
+			if total <= budget {
+				break
+			}
+			content, ok := contents[path]
+			if !ok {
+				continue
+			}
+
+			reduced, keep := trimContent(path, original[path], byPath[path], level)
+			after := prompt.EstimateTokens(reduced)
+			if keep && after >= prompt.EstimateTokens(content) {
+				continue
+			}
+			// Only this file's section and attachment change, so adjust the total by their delta
+			total -= prompt.EstimateFileContextTokens(path, content) + prompt.EstimateTokens(content)
+			if keep {
+				total += prompt.EstimateFileContextTokens(path, reduced) + after
+			}
+
+			t, seen := trimmed[path]
+			if !seen {
+				t = &TrimmedFile{Path: path, TokensBefore: prompt.EstimateTokens(original[path])}
+				trimmed[path] = t
+			}
+			t.Level = level
+			if keep {
+				contents[path] = reduced
+				t.TokensAfter = after
+			} else {
+				delete(contents, path)
+				t.TokensAfter = 0
+			}
+		}
+	}
+
+	result := make([]TrimmedFile, 0, len(trimmed))
+	for _, path := range paths {
+		if t, ok := trimmed[path]; ok {
+			result = append(result, *t)
+		}
+	}
+	return result
+}
+
+// trimContent reduces content to the given level; keep is false when the content is dropped
+func trimContent(path, content string, f *diff.FileDiff, level TrimLevel) (reduced string, keep bool) {
+	lines := strings.Split(content, "\n")
+	switch level {
+	case TrimUnchangedRegions:
+		return keepLines(lines, hunkRanges(f, unchangedRegionPadding)), true
+	case TrimEnclosingFunctions:
+		if filepath.Ext(path) == ".go" {
+			if ranges, ok := enclosingDecls(content, f); ok {
+				return keepLines(lines, ranges), true
+			}
+		}
+		return keepLines(lines, hunkRanges(f, hunkWindowPadding)), true
+	default:
+		return "", false
+	}
+}
+
+// lineSpan is an inclusive range of 1-based line numbers
+type lineSpan struct {
+	start, end int
+}
+
+// hunkRanges returns the new-side line ranges of the hunks, widened by padding
+func hunkRanges(f *diff.FileDiff, padding int) []lineSpan {
+	spans := make([]lineSpan, 0, len(f.Hunks))
+	for _, h := range f.Hunks {
+		start, end := h.NewStart, h.NewStart+h.NewLines-1
+		// A deletion-only hunk has no new lines; keep the line it follows (or the first line)
+		if h.NewLines == 0 {
+			start = max(h.NewStart, 1)
+			end = start
+		}
+		spans = append(spans, lineSpan{start: start - padding, end: end + padding})
+	}
+	return spans
+}
+
+// enclosingDecls returns the line ranges of the package clause and of the top-level
+// declarations overlapping a hunk; ok is false if the file does not parse
+func enclosingDecls(content string, f *diff.FileDiff) ([]lineSpan, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return nil, false
+	}
+
+	hunks := hunkRanges(f, 0)
+	pkgLine := fset.Position(file.Package).Line
+	spans := []lineSpan{{start: pkgLine, end: pkgLine}}
+	for _, decl := range file.Decls {
+		span := lineSpan{start: fset.Position(decl.Pos()).Line, end: fset.Position(decl.End()).Line}
+		if slices.ContainsFunc(hunks, span.overlaps) {
+			spans = append(spans, span)
+		}
+	}
+	return spans, true
+}
+
+// overlaps reports whether two spans share a line
+func (s lineSpan) overlaps(o lineSpan) bool {
+	return s.start <= o.end && o.start <= s.end
+}
+
+// keepLines keeps the lines inside spans and replaces each omitted run with a marker
+func keepLines(lines []string, spans []lineSpan) string {
+	keep := make([]bool, len(lines))
+	for _, s := range spans {
+		for i := max(s.start, 1); i <= min(s.end, len(lines)); i++ {
+			keep[i-1] = true
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(lines); {
+		if keep[i] {
+			sb.WriteString(lines[i])
+			sb.WriteByte('\n')
+			i++
+			continue
+		}
+		start := i
+		for i < len(lines) && !keep[i] {
+			i++
+		}
+		sb.WriteString(fmt.Sprintf("// ... (lines %d-%d unchanged, omitted) ...\n", start+1, i))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
+	"github.com/trankhanh040147/revcli/internal/prompt"
+)
+
+// largeGoFile returns a Go file with n functions and a diff changing the last one
+func largeGoFile(t *testing.T, n int) (string, *diff.FileDiff) {
+	t.Helper()
+
+	var sb strings.Builder
+	sb.WriteString("package big\n")
+	for i := range n {
+		fmt.Fprintf(&sb, "\nfunc f%d() int {\n\treturn %d\n}\n", i, i)
+	}
+	content := sb.String()
+	lines := strings.Count(content, "\n")
+
+	raw := fmt.Sprintf("diff --git a/big.go b/big.go\n--- a/big.go\n+++ b/big.go\n@@ -%d,1 +%d,1 @@\n-\treturn 0\n+\treturn %d\n", lines-1, lines-1, n-1)
+	files, err := diff.Parse(raw)
+	require.NoError(t, err)
+	return content, files[0]
+}
+
+func TestPackContextWithinBudget(t *testing.T) {
+	t.Parallel()
+
+	content, f := largeGoFile(t, 10)
+	contents := map[string]string{"big.go": content}
+
+	require.Empty(t, packContext([]*diff.FileDiff{f}, contents, 100000))
+	require.Equal(t, content, contents["big.go"])
+}
+
+func TestEnclosingDeclsDeletionOnly(t *testing.T) {
+	t.Parallel()
+
+	content := "package p\n\nfunc a() {\n}\n\nfunc b() {\n\tx := 1\n\t_ = x\n}\n"
+	// Deletes a line after line 7, inside b
+	files, err := diff.Parse("diff --git a/p.go b/p.go\n--- a/p.go\n+++ b/p.go\n@@ -8 +7,0 @@\n-\tprintln(x)\n")
+	require.NoError(t, err)
+
+	require.Equal(t, []lineSpan{{start: 7, end: 7}}, hunkRanges(files[0], 0))
+
+	spans, ok := enclosingDecls(content, files[0])
+	require.True(t, ok)
+	require.Equal(t, []lineSpan{{start: 1, end: 1}, {start: 6, end: 9}}, spans)
+}
+
+func TestPackContextDegrades(t *testing.T) {
+	t.Parallel()
+
+	content, f := largeGoFile(t, 2000)
+	files := []*diff.FileDiff{f}
+
+	// The budget for each level is the prompt size that level produces, which the previous level exceeds
+	budgetFor := func(level TrimLevel) int {
+		contents := map[string]string{}
+		attached := 0
+		if reduced, keep := trimContent("big.go", content, f, level); keep {
+			contents["big.go"] = reduced
+			attached = prompt.EstimateTokens(reduced)
+		}
+		return prompt.EstimateTokens(prompt.BuildReviewPrompt(files, contents)) + attached
+	}
+
+	tests := []struct {
+		name  string
+		level TrimLevel
+	}{
+		{name: "unchanged regions", level: TrimUnchangedRegions},
+		{name: "enclosing functions", level: TrimEnclosingFunctions},
+		{name: "diff only", level: TrimDiffOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			contents := map[string]string{"big.go": content}
+
+			budget := budgetFor(tt.level)
+
+			trimmed := packContext(files, contents, budget)
+			require.Len(t, trimmed, 1)
+			require.Equal(t, tt.level, trimmed[0].Level)
+			require.LessOrEqual(t, prompt.EstimateTokens(prompt.BuildReviewPrompt(files, contents)), budget)
+
+			if tt.level == TrimDiffOnly {
+				require.NotContains(t, contents, "big.go")
+				return
+			}
+			require.Contains(t, contents["big.go"], "func f1999() int {")
+			require.Contains(t, contents["big.go"], "unchanged, omitted")
+		})
+	}
+}
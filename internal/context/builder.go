@@ -2,8 +2,12 @@ package context
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 
+	"github.com/trankhanh040147/revcli/internal/diff"
 	"github.com/trankhanh040147/revcli/internal/filter"
+	"github.com/trankhanh040147/revcli/internal/findings"
 	"github.com/trankhanh040147/revcli/internal/git"
 	"github.com/trankhanh040147/revcli/internal/preset"
 	"github.com/trankhanh040147/revcli/internal/prompt"
@@ -13,12 +17,18 @@ import (
 type ReviewContext struct {
 	// RawDiff is the filtered git diff
 	RawDiff string
+	// Files is the parsed filtered diff, one entry per reviewed file (including deleted and binary files)
+	Files []*diff.FileDiff
 	// FileContents maps file paths to their content
 	FileContents map[string]string
-	// IgnoredFiles lists files that were filtered out
-	IgnoredFiles []string
+	// Sources maps file paths to their untrimmed content, for locating findings
+	Sources map[string]string
+	// IgnoredFiles lists files that were filtered out and the rule that excluded each
+	IgnoredFiles []filter.IgnoredFile
 	// SecretsFound contains any potential secrets detected
 	SecretsFound []filter.SecretMatch
+	// SecretsRedacted is true if SecretsFound were replaced with placeholders in RawDiff and FileContents
+	SecretsRedacted bool
 	// UserPrompt is the assembled prompt for the LLM
 	UserPrompt string
 	// EstimatedTokens is the rough token count
@@ -27,23 +37,48 @@ type ReviewContext struct {
 	Intent *Intent
 	// PrunedFiles maps file paths to their summaries (for token optimization)
 	PrunedFiles map[string]string
+	// TokenBudget is the prompt budget in tokens (0 for no budget)
+	TokenBudget int
+	// Trimmed lists files whose context was reduced to fit TokenBudget
+	Trimmed []TrimmedFile
+	// Previous is the review an incremental review continues (nil for a full review)
+	Previous *PreviousReview
+}
+
+// PreviousReview is the last review of a branch, whose findings an incremental review re-checks
+type PreviousReview struct {
+	// Head is the commit the previous review covered up to
+	Head     string
+	Findings []findings.Finding
+}
+
+// ReviewPrompt builds the review prompt, with pruned files summarized and previous findings appended
+func (rc *ReviewContext) ReviewPrompt() string {
+	reviewPrompt := prompt.BuildReviewPromptWithPruning(rc.Files, rc.FileContents, rc.PrunedFiles)
+	if rc.Previous != nil {
+		reviewPrompt += prompt.BuildPreviousFindingsSection(rc.Previous.Head, rc.Previous.Findings)
+	}
+	return reviewPrompt
 }
 
 // Builder constructs the review context from git changes
 type Builder struct {
-	staged     bool
-	force      bool
-	baseBranch string
-	intent     *Intent
+	source  git.DiffSource
+	force   bool
+	intent  *Intent
+	ignore  *filter.Matcher
+	scanner *filter.Scanner
+	redact  bool
+	budget  int
+	prev    *PreviousReview
 }
 
-// NewBuilder creates a new context builder
-func NewBuilder(staged, force bool, baseBranch string) *Builder {
+// NewBuilder creates a new context builder for the changes selected by source
+func NewBuilder(source git.DiffSource, force bool) *Builder {
 	return &Builder{
-		staged:     staged,
-		force:      force,
-		baseBranch: baseBranch,
-		intent:     nil,
+		source: source,
+		force:  force,
+		intent: nil,
 	}
 }
 
@@ -53,58 +88,128 @@ func (b *Builder) WithIntent(intent *Intent) *Builder {
 	return b
 }
 
+// WithIgnore sets the matcher deciding which files are excluded (defaults to the built-in rules)
+func (b *Builder) WithIgnore(matcher *filter.Matcher) *Builder {
+	b.ignore = matcher
+	return b
+}
+
+// WithSecretScanner sets the secret scanner (defaults to the built-in rules)
+func (b *Builder) WithSecretScanner(scanner *filter.Scanner) *Builder {
+	b.scanner = scanner
+	return b
+}
+
+// WithRedaction makes Build redact detected secrets and continue instead of failing
+func (b *Builder) WithRedaction(redact bool) *Builder {
+	b.redact = redact
+	return b
+}
+
+// WithTokenBudget makes Build trim file context until the prompt fits maxTokens (0 disables)
+func (b *Builder) WithTokenBudget(maxTokens int) *Builder {
+	b.budget = maxTokens
+	return b
+}
+
+// WithPreviousReview makes the review incremental: the previous findings are re-checked against the changes
+func (b *Builder) WithPreviousReview(prev *PreviousReview) *Builder {
+	b.prev = prev
+	return b
+}
+
 // Build gathers git changes and assembles the review context
 func (b *Builder) Build() (*ReviewContext, error) {
+	matcher := b.ignore
+	if matcher == nil {
+		matcher = filter.DefaultMatcher()
+	}
+	scanner := b.scanner
+	if scanner == nil {
+		scanner = filter.DefaultScanner()
+	}
+
 	// Step 1: Get git diff and file contents
-	diffResult, err := git.GetDiff(b.staged, b.baseBranch)
+	diffResult, err := git.GetDiff(b.source)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git diff: %w", err)
 	}
 
 	// Step 2: Filter files and scan for secrets
-	filterResult := filter.Filter(diffResult.ModifiedFiles, diffResult.RawDiff)
+	filterResult := filter.Filter(diffResult.ModifiedFiles, diffResult.Files, matcher, scanner)
 
 	// Step 3: Check for secrets (unless force is enabled)
-	if filterResult.HasSecrets() && !b.force {
+	if filterResult.HasSecrets() && !b.redact && !b.force {
 		return nil, SecretsError{Matches: filterResult.SecretsFound}
 	}
 
-	// Step 4: Filter the diff to remove ignored files
-	filteredDiff := filter.FilterDiff(diffResult.RawDiff)
+	// Step 4: Use the diff without ignored files
+	files := filterResult.Diff
 
-	// Step 5: Build the prompt (with pruning support)
-	userPrompt := prompt.BuildReviewPromptWithPruning(filteredDiff, filterResult.FilteredFiles, nil)
+	// Redact secrets before anything is sent to the model
+	redacted := b.redact && filterResult.HasSecrets()
+	if redacted {
+		if files, err = diff.Parse(filter.Redact(diff.Format(files), filterResult.SecretsFound)); err != nil {
+			return nil, fmt.Errorf("failed to parse redacted diff: %w", err)
+		}
+		for path, content := range filterResult.FilteredFiles {
+			filterResult.FilteredFiles[path] = filter.Redact(content, filterResult.SecretsFound)
+		}
+	}
 
-	// Step 6: Estimate tokens
-	estimatedTokens := prompt.EstimateTokens(userPrompt)
+	// Degrade file context until the prompt fits the token budget, less the previous findings
+	budget := b.budget
+	if budget > 0 && b.prev != nil {
+		budget = max(budget-prompt.EstimateTokens(prompt.BuildPreviousFindingsSection(b.prev.Head, b.prev.Findings)), 1)
+	}
+	sources := maps.Clone(filterResult.FilteredFiles)
+	trimmed := packContext(files, filterResult.FilteredFiles, budget)
 
-	return &ReviewContext{
-		RawDiff:         filteredDiff,
+	reviewCtx := &ReviewContext{
+		RawDiff:         diff.Format(files),
+		Files:           files,
 		FileContents:    filterResult.FilteredFiles,
+		Sources:         sources,
 		IgnoredFiles:    filterResult.IgnoredFiles,
 		SecretsFound:    filterResult.SecretsFound,
-		UserPrompt:      userPrompt,
-		EstimatedTokens: estimatedTokens,
+		SecretsRedacted: redacted,
 		Intent:          b.intent,
 		PrunedFiles:     make(map[string]string),
-	}, nil
+		TokenBudget:     b.budget,
+		Trimmed:         trimmed,
+		Previous:        b.prev,
+	}
+
+	// Step 5: Build the prompt (with pruning support)
+	reviewCtx.UserPrompt = reviewCtx.ReviewPrompt()
+
+	// Step 6: Estimate tokens
+	reviewCtx.EstimatedTokens = prompt.EstimateTokens(reviewCtx.UserPrompt)
+
+	return reviewCtx, nil
 }
 
 // BuildFromDiff creates a review context from an existing diff string
-func BuildFromDiff(rawDiff string, files map[string]string) *ReviewContext {
-	filterResult := filter.Filter(files, rawDiff)
-	filteredDiff := filter.FilterDiff(rawDiff)
-	userPrompt := prompt.BuildReviewPrompt(filteredDiff, filterResult.FilteredFiles)
+func BuildFromDiff(rawDiff string, files map[string]string) (*ReviewContext, error) {
+	diffFiles, err := diff.Parse(rawDiff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff: %w", err)
+	}
+	filterResult := filter.Filter(files, diffFiles, filter.DefaultMatcher(), filter.DefaultScanner())
+	userPrompt := prompt.BuildReviewPrompt(filterResult.Diff, filterResult.FilteredFiles)
 	estimatedTokens := prompt.EstimateTokens(userPrompt)
 
 	return &ReviewContext{
-		RawDiff:         filteredDiff,
+		RawDiff:         diff.Format(filterResult.Diff),
+		Files:           filterResult.Diff,
 		FileContents:    filterResult.FilteredFiles,
+		Sources:         filterResult.FilteredFiles,
 		IgnoredFiles:    filterResult.IgnoredFiles,
 		SecretsFound:    filterResult.SecretsFound,
 		UserPrompt:      userPrompt,
 		EstimatedTokens: estimatedTokens,
-	}
+		PrunedFiles:     make(map[string]string),
+	}, nil
 }
 
 // GetSystemPrompt returns the system prompt for the LLM
@@ -128,35 +233,21 @@ func GetSystemPromptWithPreset(presetPrompt string, replace bool) string {
 	return prompt.SystemPrompt + "\n\n---\n\n" + presetPrompt
 }
 
-// GetSystemPromptWithIntent returns the system prompt incorporating intent and preset
-func GetSystemPromptWithIntent(intent *Intent, presetPrompt string, presetReplace bool) string {
-	// Start with base prompt or preset
-	var basePrompt string
-	if presetReplace && presetPrompt != "" {
-		basePrompt = presetPrompt
-	} else {
-		basePrompt = GetSystemPrompt()
-		if presetPrompt != "" && !presetReplace {
-			basePrompt = basePrompt + "\n\n---\n\n" + presetPrompt
-		}
-	}
-
-	// If no intent, return base prompt
-	if intent == nil {
-		return basePrompt
-	}
-
-	// Get focus area presets
+// GetReviewGuidelines returns the preset prompt and intent additions, without any base prompt
+func GetReviewGuidelines(intent *Intent, presetPrompt string) string {
 	focusPresets, err := GetFocusAreaPresets()
 	if err != nil {
-		// If we can't get presets, just use base prompt with intent
-		return BuildSystemPromptWithIntent(basePrompt, intent, nil)
+		// If we can't get presets, skip the focus area prompts
+		focusPresets = nil
 	}
-
-	return BuildSystemPromptWithIntent(basePrompt, intent, focusPresets)
+	guidelines := BuildSystemPromptWithIntent(presetPrompt, intent, focusPresets)
+	if presetPrompt == "" {
+		guidelines = strings.TrimPrefix(guidelines, "\n\n---\n\n")
+	}
+	return strings.TrimSpace(guidelines)
 }
 
 // HasChanges returns true if there are changes to review
 func (rc *ReviewContext) HasChanges() bool {
-	return len(rc.FileContents) > 0 || rc.RawDiff != ""
+	return len(rc.FileContents) > 0 || len(rc.Files) > 0
 }
@@ -0,0 +1,26 @@
+package context
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/prompt"
+)
+
+func TestGetReviewGuidelines(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, GetReviewGuidelines(nil, ""))
+	require.Equal(t, "Be strict.", GetReviewGuidelines(nil, "Be strict."))
+
+	guidelines := GetReviewGuidelines(&Intent{
+		CustomInstruction:   "Check the retry loop",
+		NegativeConstraints: []string{"formatting"},
+	}, "")
+	require.True(t, strings.HasPrefix(guidelines, "## Custom Instructions"), "unexpected prefix: %q", guidelines)
+	require.Contains(t, guidelines, "Check the retry loop")
+	require.Contains(t, guidelines, "- formatting")
+	require.NotContains(t, guidelines, prompt.SystemPrompt)
+}
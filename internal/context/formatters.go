@@ -9,7 +9,7 @@ import (
 
 // Summary returns a summary of what will be reviewed
 func (rc *ReviewContext) Summary() string {
-	fileCount := len(rc.FileContents)
+	fileCount := len(rc.Files)
 	ignoredCount := len(rc.IgnoredFiles)
 
 	summary := "📋 Review Context:\n"
@@ -20,7 +20,7 @@ func (rc *ReviewContext) Summary() string {
 	summary += fmt.Sprintf("   • Estimated tokens: ~%d\n", rc.EstimatedTokens)
 
 	// Token warning
-	if warning := prompt.MaxTokenWarning(rc.UserPrompt, 100000); warning != "" {
+	if warning := prompt.MaxTokenWarning(rc.UserPrompt, rc.tokenLimit()); warning != "" {
 		summary += fmt.Sprintf("   ⚠️  %s\n", warning)
 	}
 
@@ -36,23 +36,39 @@ func (rc *ReviewContext) DetailedSummary() string {
 
 	// Files to review
 	sb.WriteString("📁 Files to review:\n")
-	if len(rc.FileContents) == 0 {
+	if len(rc.Files) == 0 {
 		sb.WriteString("   (none)\n")
 	} else {
 		totalSize := 0
-		for path, content := range rc.FileContents {
-			size := len(content)
+		for _, f := range rc.Files {
+			size := len(rc.FileContents[f.Path()])
 			totalSize += size
-			sb.WriteString(fmt.Sprintf("   • %s (%s)\n", path, formatBytes(size)))
+			sb.WriteString(fmt.Sprintf("   • %s (%s, %s)\n", f.Path(), f.Describe(), formatBytes(size)))
 		}
-		sb.WriteString(fmt.Sprintf("\n   Total: %d files, %s\n", len(rc.FileContents), formatBytes(totalSize)))
+		sb.WriteString(fmt.Sprintf("\n   Total: %d files, %s\n", len(rc.Files), formatBytes(totalSize)))
 	}
 
 	// Ignored files
 	if len(rc.IgnoredFiles) > 0 {
 		sb.WriteString("\n🚫 Ignored files:\n")
-		for _, path := range rc.IgnoredFiles {
-			sb.WriteString(fmt.Sprintf("   • %s\n", path))
+		for _, f := range rc.IgnoredFiles {
+			sb.WriteString(fmt.Sprintf("   • %s ← %s\n", f.Path, f.Rule))
+		}
+	}
+
+	// Redacted secrets
+	if rc.SecretsRedacted {
+		sb.WriteString(fmt.Sprintf("\n🔒 Redacted secrets (%d):\n", len(rc.SecretsFound)))
+		for _, s := range rc.SecretsFound {
+			sb.WriteString(fmt.Sprintf("   • %s:%d %s [%s]\n", s.FilePath, s.Line, s.Match, s.RuleID))
+		}
+	}
+
+	// Context trimmed to fit the budget
+	if len(rc.Trimmed) > 0 {
+		sb.WriteString(fmt.Sprintf("\n✂️  Trimmed to fit the %d-token budget:\n", rc.TokenBudget))
+		for _, t := range rc.Trimmed {
+			sb.WriteString(fmt.Sprintf("   • %s: %s (~%d → ~%d tokens)\n", t.Path, t.Level, t.TokensBefore, t.TokensAfter))
 		}
 	}
 
@@ -60,13 +76,21 @@ func (rc *ReviewContext) DetailedSummary() string {
 	sb.WriteString(fmt.Sprintf("\n📊 Token Estimate: ~%d tokens\n", rc.EstimatedTokens))
 
 	// Token warning
-	if warning := prompt.MaxTokenWarning(rc.UserPrompt, 100000); warning != "" {
+	if warning := prompt.MaxTokenWarning(rc.UserPrompt, rc.tokenLimit()); warning != "" {
 		sb.WriteString(fmt.Sprintf("⚠️  %s\n", warning))
 	}
 
 	return sb.String()
 }
 
+// tokenLimit returns the budget used for token warnings
+func (rc *ReviewContext) tokenLimit() int {
+	if rc.TokenBudget > 0 {
+		return rc.TokenBudget
+	}
+	return DefaultTokenBudget
+}
+
 // formatBytes formats bytes into human readable format
 func formatBytes(bytes int) string {
 	const unit = 1024
@@ -80,4 +104,3 @@ func formatBytes(bytes int) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
-
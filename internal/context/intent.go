@@ -10,13 +10,18 @@ import (
 // Intent represents user's review intent and focus areas
 type Intent struct {
 	// CustomInstruction is optional user-provided custom instruction
-	CustomInstruction string
+	CustomInstruction string `json:"custom_instruction,omitempty"`
 	// FocusAreas are selected focus areas (security, performance, logic, style, typo, naming)
-	FocusAreas []string
+	FocusAreas []string `json:"focus_areas,omitempty"`
 	// NegativeConstraints are things the user wants to ignore
-	NegativeConstraints []string
-	// WebSearchEnabled controls whether web search is enabled for Gemini requests (default: true)
-	WebSearchEnabled bool
+	NegativeConstraints []string `json:"negative_constraints,omitempty"`
+	// WebSearchEnabled controls whether the agent is offered the web tools (default: true)
+	WebSearchEnabled bool `json:"web_search_enabled"`
+}
+
+// WebAccess reports whether the review may use the web tools; reviews without an intent may
+func (i *Intent) WebAccess() bool {
+	return i == nil || i.WebSearchEnabled
 }
 
 // BuildSystemPromptWithIntent builds the system prompt incorporating intent
@@ -83,4 +88,3 @@ func GetFocusAreaPresets() (map[string]*preset.Preset, error) {
 
 	return presets, nil
 }
-
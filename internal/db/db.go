@@ -30,12 +30,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createMessageStmt, err = db.PrepareContext(ctx, createMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateMessage: %w", err)
 	}
+	if q.createPermissionAuditStmt, err = db.PrepareContext(ctx, createPermissionAudit); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePermissionAudit: %w", err)
+	}
 	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
 	}
+	if q.createSessionReviewStmt, err = db.PrepareContext(ctx, createSessionReview); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSessionReview: %w", err)
+	}
 	if q.deleteFileStmt, err = db.PrepareContext(ctx, deleteFile); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFile: %w", err)
 	}
+	if q.deleteFindingTriageStmt, err = db.PrepareContext(ctx, deleteFindingTriage); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFindingTriage: %w", err)
+	}
 	if q.deleteMessageStmt, err = db.PrepareContext(ctx, deleteMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteMessage: %w", err)
 	}
@@ -54,18 +63,27 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFileByPathAndSessionStmt, err = db.PrepareContext(ctx, getFileByPathAndSession); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFileByPathAndSession: %w", err)
 	}
+	if q.getLatestSessionReviewStmt, err = db.PrepareContext(ctx, getLatestSessionReview); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestSessionReview: %w", err)
+	}
 	if q.getMessageStmt, err = db.PrepareContext(ctx, getMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMessage: %w", err)
 	}
 	if q.getSessionByIDStmt, err = db.PrepareContext(ctx, getSessionByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSessionByID: %w", err)
 	}
+	if q.getSessionReviewStmt, err = db.PrepareContext(ctx, getSessionReview); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSessionReview: %w", err)
+	}
 	if q.listFilesByPathStmt, err = db.PrepareContext(ctx, listFilesByPath); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFilesByPath: %w", err)
 	}
 	if q.listFilesBySessionStmt, err = db.PrepareContext(ctx, listFilesBySession); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFilesBySession: %w", err)
 	}
+	if q.listFindingTriageStmt, err = db.PrepareContext(ctx, listFindingTriage); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFindingTriage: %w", err)
+	}
 	if q.listLatestSessionFilesStmt, err = db.PrepareContext(ctx, listLatestSessionFiles); err != nil {
 		return nil, fmt.Errorf("error preparing query ListLatestSessionFiles: %w", err)
 	}
@@ -75,9 +93,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listNewFilesStmt, err = db.PrepareContext(ctx, listNewFiles); err != nil {
 		return nil, fmt.Errorf("error preparing query ListNewFiles: %w", err)
 	}
+	if q.listPermissionAuditStmt, err = db.PrepareContext(ctx, listPermissionAudit); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPermissionAudit: %w", err)
+	}
+	if q.listPermissionAuditBySessionStmt, err = db.PrepareContext(ctx, listPermissionAuditBySession); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPermissionAuditBySession: %w", err)
+	}
+	if q.listSessionReviewsStmt, err = db.PrepareContext(ctx, listSessionReviews); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSessionReviews: %w", err)
+	}
 	if q.listSessionsStmt, err = db.PrepareContext(ctx, listSessions); err != nil {
 		return nil, fmt.Errorf("error preparing query ListSessions: %w", err)
 	}
+	if q.markSessionWebAccessUsedStmt, err = db.PrepareContext(ctx, markSessionWebAccessUsed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkSessionWebAccessUsed: %w", err)
+	}
 	if q.updateMessageStmt, err = db.PrepareContext(ctx, updateMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateMessage: %w", err)
 	}
@@ -87,6 +117,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateSessionTitleAndUsageStmt, err = db.PrepareContext(ctx, updateSessionTitleAndUsage); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateSessionTitleAndUsage: %w", err)
 	}
+	if q.upsertFindingTriageStmt, err = db.PrepareContext(ctx, upsertFindingTriage); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertFindingTriage: %w", err)
+	}
 	return &q, nil
 }
 
@@ -102,16 +135,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createMessageStmt: %w", cerr)
 		}
 	}
+	if q.createPermissionAuditStmt != nil {
+		if cerr := q.createPermissionAuditStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPermissionAuditStmt: %w", cerr)
+		}
+	}
 	if q.createSessionStmt != nil {
 		if cerr := q.createSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
 		}
 	}
+	if q.createSessionReviewStmt != nil {
+		if cerr := q.createSessionReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSessionReviewStmt: %w", cerr)
+		}
+	}
 	if q.deleteFileStmt != nil {
 		if cerr := q.deleteFileStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFileStmt: %w", cerr)
 		}
 	}
+	if q.deleteFindingTriageStmt != nil {
+		if cerr := q.deleteFindingTriageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFindingTriageStmt: %w", cerr)
+		}
+	}
 	if q.deleteMessageStmt != nil {
 		if cerr := q.deleteMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteMessageStmt: %w", cerr)
@@ -142,6 +190,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFileByPathAndSessionStmt: %w", cerr)
 		}
 	}
+	if q.getLatestSessionReviewStmt != nil {
+		if cerr := q.getLatestSessionReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestSessionReviewStmt: %w", cerr)
+		}
+	}
 	if q.getMessageStmt != nil {
 		if cerr := q.getMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMessageStmt: %w", cerr)
@@ -152,6 +205,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getSessionByIDStmt: %w", cerr)
 		}
 	}
+	if q.getSessionReviewStmt != nil {
+		if cerr := q.getSessionReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionReviewStmt: %w", cerr)
+		}
+	}
 	if q.listFilesByPathStmt != nil {
 		if cerr := q.listFilesByPathStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listFilesByPathStmt: %w", cerr)
@@ -162,6 +220,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listFilesBySessionStmt: %w", cerr)
 		}
 	}
+	if q.listFindingTriageStmt != nil {
+		if cerr := q.listFindingTriageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFindingTriageStmt: %w", cerr)
+		}
+	}
 	if q.listLatestSessionFilesStmt != nil {
 		if cerr := q.listLatestSessionFilesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listLatestSessionFilesStmt: %w", cerr)
@@ -177,11 +240,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listNewFilesStmt: %w", cerr)
 		}
 	}
+	if q.listPermissionAuditStmt != nil {
+		if cerr := q.listPermissionAuditStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPermissionAuditStmt: %w", cerr)
+		}
+	}
+	if q.listPermissionAuditBySessionStmt != nil {
+		if cerr := q.listPermissionAuditBySessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPermissionAuditBySessionStmt: %w", cerr)
+		}
+	}
+	if q.listSessionReviewsStmt != nil {
+		if cerr := q.listSessionReviewsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSessionReviewsStmt: %w", cerr)
+		}
+	}
 	if q.listSessionsStmt != nil {
 		if cerr := q.listSessionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listSessionsStmt: %w", cerr)
 		}
 	}
+	if q.markSessionWebAccessUsedStmt != nil {
+		if cerr := q.markSessionWebAccessUsedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markSessionWebAccessUsedStmt: %w", cerr)
+		}
+	}
 	if q.updateMessageStmt != nil {
 		if cerr := q.updateMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateMessageStmt: %w", cerr)
@@ -197,6 +280,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateSessionTitleAndUsageStmt: %w", cerr)
 		}
 	}
+	if q.upsertFindingTriageStmt != nil {
+		if cerr := q.upsertFindingTriageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertFindingTriageStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -234,55 +322,77 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                             DBTX
-	tx                             *sql.Tx
-	createFileStmt                 *sql.Stmt
-	createMessageStmt              *sql.Stmt
-	createSessionStmt              *sql.Stmt
-	deleteFileStmt                 *sql.Stmt
-	deleteMessageStmt              *sql.Stmt
-	deleteSessionStmt              *sql.Stmt
-	deleteSessionFilesStmt         *sql.Stmt
-	deleteSessionMessagesStmt      *sql.Stmt
-	getFileStmt                    *sql.Stmt
-	getFileByPathAndSessionStmt    *sql.Stmt
-	getMessageStmt                 *sql.Stmt
-	getSessionByIDStmt             *sql.Stmt
-	listFilesByPathStmt            *sql.Stmt
-	listFilesBySessionStmt         *sql.Stmt
-	listLatestSessionFilesStmt     *sql.Stmt
-	listMessagesBySessionStmt      *sql.Stmt
-	listNewFilesStmt               *sql.Stmt
-	listSessionsStmt               *sql.Stmt
-	updateMessageStmt              *sql.Stmt
-	updateSessionStmt              *sql.Stmt
-	updateSessionTitleAndUsageStmt *sql.Stmt
+	db                               DBTX
+	tx                               *sql.Tx
+	createFileStmt                   *sql.Stmt
+	createMessageStmt                *sql.Stmt
+	createPermissionAuditStmt        *sql.Stmt
+	createSessionStmt                *sql.Stmt
+	createSessionReviewStmt          *sql.Stmt
+	deleteFileStmt                   *sql.Stmt
+	deleteFindingTriageStmt          *sql.Stmt
+	deleteMessageStmt                *sql.Stmt
+	deleteSessionStmt                *sql.Stmt
+	deleteSessionFilesStmt           *sql.Stmt
+	deleteSessionMessagesStmt        *sql.Stmt
+	getFileStmt                      *sql.Stmt
+	getFileByPathAndSessionStmt      *sql.Stmt
+	getLatestSessionReviewStmt       *sql.Stmt
+	getMessageStmt                   *sql.Stmt
+	getSessionByIDStmt               *sql.Stmt
+	getSessionReviewStmt             *sql.Stmt
+	listFilesByPathStmt              *sql.Stmt
+	listFilesBySessionStmt           *sql.Stmt
+	listFindingTriageStmt            *sql.Stmt
+	listLatestSessionFilesStmt       *sql.Stmt
+	listMessagesBySessionStmt        *sql.Stmt
+	listNewFilesStmt                 *sql.Stmt
+	listPermissionAuditStmt          *sql.Stmt
+	listPermissionAuditBySessionStmt *sql.Stmt
+	listSessionReviewsStmt           *sql.Stmt
+	listSessionsStmt                 *sql.Stmt
+	markSessionWebAccessUsedStmt     *sql.Stmt
+	updateMessageStmt                *sql.Stmt
+	updateSessionStmt                *sql.Stmt
+	updateSessionTitleAndUsageStmt   *sql.Stmt
+	upsertFindingTriageStmt          *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                             tx,
-		tx:                             tx,
-		createFileStmt:                 q.createFileStmt,
-		createMessageStmt:              q.createMessageStmt,
-		createSessionStmt:              q.createSessionStmt,
-		deleteFileStmt:                 q.deleteFileStmt,
-		deleteMessageStmt:              q.deleteMessageStmt,
-		deleteSessionStmt:              q.deleteSessionStmt,
-		deleteSessionFilesStmt:         q.deleteSessionFilesStmt,
-		deleteSessionMessagesStmt:      q.deleteSessionMessagesStmt,
-		getFileStmt:                    q.getFileStmt,
-		getFileByPathAndSessionStmt:    q.getFileByPathAndSessionStmt,
-		getMessageStmt:                 q.getMessageStmt,
-		getSessionByIDStmt:             q.getSessionByIDStmt,
-		listFilesByPathStmt:            q.listFilesByPathStmt,
-		listFilesBySessionStmt:         q.listFilesBySessionStmt,
-		listLatestSessionFilesStmt:     q.listLatestSessionFilesStmt,
-		listMessagesBySessionStmt:      q.listMessagesBySessionStmt,
-		listNewFilesStmt:               q.listNewFilesStmt,
-		listSessionsStmt:               q.listSessionsStmt,
-		updateMessageStmt:              q.updateMessageStmt,
-		updateSessionStmt:              q.updateSessionStmt,
-		updateSessionTitleAndUsageStmt: q.updateSessionTitleAndUsageStmt,
+		db:                               tx,
+		tx:                               tx,
+		createFileStmt:                   q.createFileStmt,
+		createMessageStmt:                q.createMessageStmt,
+		createPermissionAuditStmt:        q.createPermissionAuditStmt,
+		createSessionStmt:                q.createSessionStmt,
+		createSessionReviewStmt:          q.createSessionReviewStmt,
+		deleteFileStmt:                   q.deleteFileStmt,
+		deleteFindingTriageStmt:          q.deleteFindingTriageStmt,
+		deleteMessageStmt:                q.deleteMessageStmt,
+		deleteSessionStmt:                q.deleteSessionStmt,
+		deleteSessionFilesStmt:           q.deleteSessionFilesStmt,
+		deleteSessionMessagesStmt:        q.deleteSessionMessagesStmt,
+		getFileStmt:                      q.getFileStmt,
+		getFileByPathAndSessionStmt:      q.getFileByPathAndSessionStmt,
+		getLatestSessionReviewStmt:       q.getLatestSessionReviewStmt,
+		getMessageStmt:                   q.getMessageStmt,
+		getSessionByIDStmt:               q.getSessionByIDStmt,
+		getSessionReviewStmt:             q.getSessionReviewStmt,
+		listFilesByPathStmt:              q.listFilesByPathStmt,
+		listFilesBySessionStmt:           q.listFilesBySessionStmt,
+		listFindingTriageStmt:            q.listFindingTriageStmt,
+		listLatestSessionFilesStmt:       q.listLatestSessionFilesStmt,
+		listMessagesBySessionStmt:        q.listMessagesBySessionStmt,
+		listNewFilesStmt:                 q.listNewFilesStmt,
+		listPermissionAuditStmt:          q.listPermissionAuditStmt,
+		listPermissionAuditBySessionStmt: q.listPermissionAuditBySessionStmt,
+		listSessionReviewsStmt:           q.listSessionReviewsStmt,
+		listSessionsStmt:                 q.listSessionsStmt,
+		markSessionWebAccessUsedStmt:     q.markSessionWebAccessUsedStmt,
+		updateMessageStmt:                q.updateMessageStmt,
+		updateSessionStmt:                q.updateSessionStmt,
+		updateSessionTitleAndUsageStmt:   q.updateSessionTitleAndUsageStmt,
+		upsertFindingTriageStmt:          q.upsertFindingTriageStmt,
 	}
 }
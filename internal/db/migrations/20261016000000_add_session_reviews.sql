@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What each review session reviewed, so it can be listed and resumed
+CREATE TABLE IF NOT EXISTS session_reviews (
+    session_id TEXT PRIMARY KEY,
+    repo TEXT NOT NULL,
+    branch TEXT NOT NULL DEFAULT '',
+    base_ref TEXT NOT NULL,
+    base_commit TEXT NOT NULL,
+    head_commit TEXT NOT NULL,
+    diff_hash TEXT NOT NULL,
+    preset TEXT NOT NULL DEFAULT '',
+    preset_replace INTEGER NOT NULL DEFAULT 0,
+    intent TEXT,
+    created_at INTEGER NOT NULL,  -- Unix timestamp in seconds
+    FOREIGN KEY (session_id) REFERENCES sessions (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_reviews_repo_created_at ON session_reviews (repo, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_session_reviews_repo_created_at;
+DROP TABLE IF EXISTS session_reviews;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Triage decisions on review findings, by repository and finding fingerprint
+CREATE TABLE IF NOT EXISTS finding_triage (
+    repo TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('dismissed', 'acknowledged', 'fixed')),
+    reason TEXT NOT NULL DEFAULT '',
+    file TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    created_at INTEGER NOT NULL,  -- Unix timestamp in seconds
+    updated_at INTEGER NOT NULL,  -- Unix timestamp in seconds
+    PRIMARY KEY (repo, fingerprint)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS finding_triage;
+-- +goose StatementEnd
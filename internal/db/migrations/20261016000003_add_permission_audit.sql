@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every permission decision on a tool call, and what made it. Rows outlive their session.
+CREATE TABLE IF NOT EXISTS permission_audit (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    tool_call_id TEXT NOT NULL DEFAULT '',
+    tool_name TEXT NOT NULL,
+    action TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',  -- the command, file or path the request was about
+    decision TEXT NOT NULL CHECK (decision IN ('allow', 'deny')),
+    source TEXT NOT NULL,
+    rule TEXT NOT NULL DEFAULT '',     -- the policy rule that matched, if any
+    created_at INTEGER NOT NULL  -- Unix timestamp in seconds
+);
+
+CREATE INDEX IF NOT EXISTS idx_permission_audit_session_id ON permission_audit (session_id);
+CREATE INDEX IF NOT EXISTS idx_permission_audit_created_at ON permission_audit (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_permission_audit_created_at;
+DROP INDEX IF EXISTS idx_permission_audit_session_id;
+DROP TABLE IF EXISTS permission_audit;
+-- +goose StatementEnd
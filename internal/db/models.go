@@ -18,6 +18,17 @@ type File struct {
 	UpdatedAt int64  `json:"updated_at"`
 }
 
+type FindingTriage struct {
+	Repo        string `json:"repo"`
+	Fingerprint string `json:"fingerprint"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+	File        string `json:"file"`
+	Message     string `json:"message"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
 type Message struct {
 	ID               string         `json:"id"`
 	SessionID        string         `json:"session_id"`
@@ -31,6 +42,19 @@ type Message struct {
 	IsSummaryMessage int64          `json:"is_summary_message"`
 }
 
+type PermissionAudit struct {
+	ID         string `json:"id"`
+	SessionID  string `json:"session_id"`
+	ToolCallID string `json:"tool_call_id"`
+	ToolName   string `json:"tool_name"`
+	Action     string `json:"action"`
+	Subject    string `json:"subject"`
+	Decision   string `json:"decision"`
+	Source     string `json:"source"`
+	Rule       string `json:"rule"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
 type Session struct {
 	ID               string         `json:"id"`
 	ParentSessionID  sql.NullString `json:"parent_session_id"`
@@ -43,4 +67,19 @@ type Session struct {
 	CreatedAt        int64          `json:"created_at"`
 	SummaryMessageID sql.NullString `json:"summary_message_id"`
 	Todos            sql.NullString `json:"todos"`
+	WebAccessUsed    int64          `json:"web_access_used"`
+}
+
+type SessionReview struct {
+	SessionID     string         `json:"session_id"`
+	Repo          string         `json:"repo"`
+	Branch        string         `json:"branch"`
+	BaseRef       string         `json:"base_ref"`
+	BaseCommit    string         `json:"base_commit"`
+	HeadCommit    string         `json:"head_commit"`
+	DiffHash      string         `json:"diff_hash"`
+	Preset        string         `json:"preset"`
+	PresetReplace int64          `json:"preset_replace"`
+	Intent        sql.NullString `json:"intent"`
+	CreatedAt     int64          `json:"created_at"`
 }
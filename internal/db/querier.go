@@ -11,25 +11,36 @@ import (
 type Querier interface {
 	CreateFile(ctx context.Context, arg CreateFileParams) (File, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	CreatePermissionAudit(ctx context.Context, arg CreatePermissionAuditParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSessionReview(ctx context.Context, arg CreateSessionReviewParams) (SessionReview, error)
 	DeleteFile(ctx context.Context, id string) error
+	DeleteFindingTriage(ctx context.Context, arg DeleteFindingTriageParams) error
 	DeleteMessage(ctx context.Context, id string) error
 	DeleteSession(ctx context.Context, id string) error
 	DeleteSessionFiles(ctx context.Context, sessionID string) error
 	DeleteSessionMessages(ctx context.Context, sessionID string) error
 	GetFile(ctx context.Context, id string) (File, error)
 	GetFileByPathAndSession(ctx context.Context, arg GetFileByPathAndSessionParams) (File, error)
+	GetLatestSessionReview(ctx context.Context, arg GetLatestSessionReviewParams) (SessionReview, error)
 	GetMessage(ctx context.Context, id string) (Message, error)
 	GetSessionByID(ctx context.Context, id string) (Session, error)
+	GetSessionReview(ctx context.Context, sessionID string) (SessionReview, error)
 	ListFilesByPath(ctx context.Context, path string) ([]File, error)
 	ListFilesBySession(ctx context.Context, sessionID string) ([]File, error)
+	ListFindingTriage(ctx context.Context, repo string) ([]FindingTriage, error)
 	ListLatestSessionFiles(ctx context.Context, sessionID string) ([]File, error)
 	ListMessagesBySession(ctx context.Context, sessionID string) ([]Message, error)
 	ListNewFiles(ctx context.Context) ([]File, error)
+	ListPermissionAudit(ctx context.Context, limit int64) ([]PermissionAudit, error)
+	ListPermissionAuditBySession(ctx context.Context, sessionID string) ([]PermissionAudit, error)
+	ListSessionReviews(ctx context.Context, repo string) ([]SessionReview, error)
 	ListSessions(ctx context.Context) ([]Session, error)
+	MarkSessionWebAccessUsed(ctx context.Context, id string) (Session, error)
 	UpdateMessage(ctx context.Context, arg UpdateMessageParams) error
 	UpdateSession(ctx context.Context, arg UpdateSessionParams) (Session, error)
 	UpdateSessionTitleAndUsage(ctx context.Context, arg UpdateSessionTitleAndUsageParams) error
+	UpsertFindingTriage(ctx context.Context, arg UpsertFindingTriageParams) (FindingTriage, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -33,7 +33,7 @@ INSERT INTO sessions (
     null,
     strftime('%s', 'now'),
     strftime('%s', 'now')
-) RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos
+) RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos, web_access_used
 `
 
 type CreateSessionParams struct {
@@ -69,6 +69,78 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.CreatedAt,
 		&i.SummaryMessageID,
 		&i.Todos,
+		&i.WebAccessUsed,
+	)
+	return i, err
+}
+
+const createSessionReview = `-- name: CreateSessionReview :one
+INSERT INTO session_reviews (
+    session_id,
+    repo,
+    branch,
+    base_ref,
+    base_commit,
+    head_commit,
+    diff_hash,
+    preset,
+    preset_replace,
+    intent,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    strftime('%s', 'now')
+) RETURNING session_id, repo, branch, base_ref, base_commit, head_commit, diff_hash, preset, preset_replace, intent, created_at
+`
+
+type CreateSessionReviewParams struct {
+	SessionID     string         `json:"session_id"`
+	Repo          string         `json:"repo"`
+	Branch        string         `json:"branch"`
+	BaseRef       string         `json:"base_ref"`
+	BaseCommit    string         `json:"base_commit"`
+	HeadCommit    string         `json:"head_commit"`
+	DiffHash      string         `json:"diff_hash"`
+	Preset        string         `json:"preset"`
+	PresetReplace int64          `json:"preset_replace"`
+	Intent        sql.NullString `json:"intent"`
+}
+
+func (q *Queries) CreateSessionReview(ctx context.Context, arg CreateSessionReviewParams) (SessionReview, error) {
+	row := q.queryRow(ctx, q.createSessionReviewStmt, createSessionReview,
+		arg.SessionID,
+		arg.Repo,
+		arg.Branch,
+		arg.BaseRef,
+		arg.BaseCommit,
+		arg.HeadCommit,
+		arg.DiffHash,
+		arg.Preset,
+		arg.PresetReplace,
+		arg.Intent,
+	)
+	var i SessionReview
+	err := row.Scan(
+		&i.SessionID,
+		&i.Repo,
+		&i.Branch,
+		&i.BaseRef,
+		&i.BaseCommit,
+		&i.HeadCommit,
+		&i.DiffHash,
+		&i.Preset,
+		&i.PresetReplace,
+		&i.Intent,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -83,8 +155,40 @@ func (q *Queries) DeleteSession(ctx context.Context, id string) error {
 	return err
 }
 
+const getLatestSessionReview = `-- name: GetLatestSessionReview :one
+SELECT session_id, repo, branch, base_ref, base_commit, head_commit, diff_hash, preset, preset_replace, intent, created_at
+FROM session_reviews
+WHERE repo = ? AND branch = ? AND base_commit != head_commit
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetLatestSessionReviewParams struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+}
+
+func (q *Queries) GetLatestSessionReview(ctx context.Context, arg GetLatestSessionReviewParams) (SessionReview, error) {
+	row := q.queryRow(ctx, q.getLatestSessionReviewStmt, getLatestSessionReview, arg.Repo, arg.Branch)
+	var i SessionReview
+	err := row.Scan(
+		&i.SessionID,
+		&i.Repo,
+		&i.Branch,
+		&i.BaseRef,
+		&i.BaseCommit,
+		&i.HeadCommit,
+		&i.DiffHash,
+		&i.Preset,
+		&i.PresetReplace,
+		&i.Intent,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getSessionByID = `-- name: GetSessionByID :one
-SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos
+SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos, web_access_used
 FROM sessions
 WHERE id = ? LIMIT 1
 `
@@ -104,12 +208,80 @@ func (q *Queries) GetSessionByID(ctx context.Context, id string) (Session, error
 		&i.CreatedAt,
 		&i.SummaryMessageID,
 		&i.Todos,
+		&i.WebAccessUsed,
+	)
+	return i, err
+}
+
+const getSessionReview = `-- name: GetSessionReview :one
+SELECT session_id, repo, branch, base_ref, base_commit, head_commit, diff_hash, preset, preset_replace, intent, created_at
+FROM session_reviews
+WHERE session_id = ? LIMIT 1
+`
+
+func (q *Queries) GetSessionReview(ctx context.Context, sessionID string) (SessionReview, error) {
+	row := q.queryRow(ctx, q.getSessionReviewStmt, getSessionReview, sessionID)
+	var i SessionReview
+	err := row.Scan(
+		&i.SessionID,
+		&i.Repo,
+		&i.Branch,
+		&i.BaseRef,
+		&i.BaseCommit,
+		&i.HeadCommit,
+		&i.DiffHash,
+		&i.Preset,
+		&i.PresetReplace,
+		&i.Intent,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const listSessionReviews = `-- name: ListSessionReviews :many
+SELECT session_id, repo, branch, base_ref, base_commit, head_commit, diff_hash, preset, preset_replace, intent, created_at
+FROM session_reviews
+WHERE repo = ?
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListSessionReviews(ctx context.Context, repo string) ([]SessionReview, error) {
+	rows, err := q.query(ctx, q.listSessionReviewsStmt, listSessionReviews, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SessionReview{}
+	for rows.Next() {
+		var i SessionReview
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Repo,
+			&i.Branch,
+			&i.BaseRef,
+			&i.BaseCommit,
+			&i.HeadCommit,
+			&i.DiffHash,
+			&i.Preset,
+			&i.PresetReplace,
+			&i.Intent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessions = `-- name: ListSessions :many
-SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos
+SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos, web_access_used
 FROM sessions
 WHERE parent_session_id is NULL
 ORDER BY updated_at DESC
@@ -136,6 +308,7 @@ func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
 			&i.CreatedAt,
 			&i.SummaryMessageID,
 			&i.Todos,
+			&i.WebAccessUsed,
 		); err != nil {
 			return nil, err
 		}
@@ -150,6 +323,33 @@ func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
 	return items, nil
 }
 
+const markSessionWebAccessUsed = `-- name: MarkSessionWebAccessUsed :one
+UPDATE sessions
+SET web_access_used = 1
+WHERE id = ?
+RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos, web_access_used
+`
+
+func (q *Queries) MarkSessionWebAccessUsed(ctx context.Context, id string) (Session, error) {
+	row := q.queryRow(ctx, q.markSessionWebAccessUsedStmt, markSessionWebAccessUsed, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.ParentSessionID,
+		&i.Title,
+		&i.MessageCount,
+		&i.PromptTokens,
+		&i.CompletionTokens,
+		&i.Cost,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.SummaryMessageID,
+		&i.Todos,
+		&i.WebAccessUsed,
+	)
+	return i, err
+}
+
 const updateSession = `-- name: UpdateSession :one
 UPDATE sessions
 SET
@@ -160,7 +360,7 @@ SET
     cost = ?,
     todos = ?
 WHERE id = ?
-RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos
+RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, todos, web_access_used
 `
 
 type UpdateSessionParams struct {
@@ -196,6 +396,7 @@ func (q *Queries) UpdateSession(ctx context.Context, arg UpdateSessionParams) (S
 		&i.CreatedAt,
 		&i.SummaryMessageID,
 		&i.Todos,
+		&i.WebAccessUsed,
 	)
 	return i, err
 }
@@ -0,0 +1,36 @@
+-- name: CreatePermissionAudit :exec
+INSERT INTO permission_audit (
+    id,
+    session_id,
+    tool_call_id,
+    tool_name,
+    action,
+    subject,
+    decision,
+    source,
+    rule,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    strftime('%s', 'now')
+);
+
+-- name: ListPermissionAudit :many
+SELECT *
+FROM permission_audit
+ORDER BY created_at DESC, rowid DESC
+LIMIT ?;
+
+-- name: ListPermissionAuditBySession :many
+SELECT *
+FROM permission_audit
+WHERE session_id = ?
+ORDER BY created_at DESC, rowid DESC;
@@ -46,6 +46,12 @@ SET
 WHERE id = ?
 RETURNING *;
 
+-- name: MarkSessionWebAccessUsed :one
+UPDATE sessions
+SET web_access_used = 1
+WHERE id = ?
+RETURNING *;
+
 -- name: UpdateSessionTitleAndUsage :exec
 UPDATE sessions
 SET
@@ -59,3 +65,48 @@ WHERE id = ?;
 -- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE id = ?;
+
+-- name: CreateSessionReview :one
+INSERT INTO session_reviews (
+    session_id,
+    repo,
+    branch,
+    base_ref,
+    base_commit,
+    head_commit,
+    diff_hash,
+    preset,
+    preset_replace,
+    intent,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    strftime('%s', 'now')
+) RETURNING *;
+
+-- name: GetSessionReview :one
+SELECT *
+FROM session_reviews
+WHERE session_id = ? LIMIT 1;
+
+-- name: GetLatestSessionReview :one
+SELECT *
+FROM session_reviews
+WHERE repo = ? AND branch = ? AND base_commit != head_commit
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListSessionReviews :many
+SELECT *
+FROM session_reviews
+WHERE repo = ?
+ORDER BY created_at DESC;
@@ -0,0 +1,37 @@
+-- name: UpsertFindingTriage :one
+INSERT INTO finding_triage (
+    repo,
+    fingerprint,
+    status,
+    reason,
+    file,
+    message,
+    created_at,
+    updated_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    strftime('%s', 'now'),
+    strftime('%s', 'now')
+)
+ON CONFLICT (repo, fingerprint) DO UPDATE SET
+    status = excluded.status,
+    reason = excluded.reason,
+    file = excluded.file,
+    message = excluded.message,
+    updated_at = excluded.updated_at
+RETURNING *;
+
+-- name: ListFindingTriage :many
+SELECT *
+FROM finding_triage
+WHERE repo = ?
+ORDER BY updated_at DESC;
+
+-- name: DeleteFindingTriage :exec
+DELETE FROM finding_triage
+WHERE repo = ? AND fingerprint = ?;
@@ -0,0 +1,190 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status describes how a file changed
+type Status string
+
+// File statuses
+const (
+	StatusModified Status = "modified"
+	StatusAdded    Status = "added"
+	StatusDeleted  Status = "deleted"
+	StatusRenamed  Status = "renamed"
+	StatusCopied   Status = "copied"
+)
+
+// LineKind is the type of a line inside a hunk
+type LineKind int
+
+// Hunk line kinds
+const (
+	LineContext LineKind = iota
+	LineAdded
+	LineRemoved
+	// LineNoNewline is the "\ No newline at end of file" marker
+	LineNoNewline
+)
+
+// FileDiff is the diff of a single file
+type FileDiff struct {
+	// OldPath is the path before the change (empty for added files)
+	OldPath string
+	// NewPath is the path after the change (empty for deleted files)
+	NewPath string
+	Status  Status
+	// OldMode and NewMode are the file modes, set when git reports them
+	OldMode string
+	NewMode string
+	// Similarity is the rename/copy similarity percentage
+	Similarity int
+	// Binary is true if git reported the file as binary (no hunks)
+	Binary bool
+	// Header holds the raw header lines, from "diff --git" up to the first hunk
+	Header []string
+	Hunks  []*Hunk
+}
+
+// Hunk is a "@@ -a,b +c,d @@" section of a file diff
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	// Section is the text after the closing "@@" (usually the enclosing function)
+	Section string
+	Lines   []Line
+}
+
+// Line is a single line of a hunk
+type Line struct {
+	Kind    LineKind
+	Content string
+	// OldLine and NewLine are the line numbers on each side (0 if absent on that side)
+	OldLine int
+	NewLine int
+}
+
+// Path returns the path that identifies the file: the new path, or the old one for deletions
+func (f *FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// IsModeChange returns true if only the file mode changed
+func (f *FileDiff) IsModeChange() bool {
+	return f.OldMode != "" && f.NewMode != "" && f.OldMode != f.NewMode && len(f.Hunks) == 0 && !f.Binary
+}
+
+// Stats returns the number of added and removed lines
+func (f *FileDiff) Stats() (added, removed int) {
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			switch l.Kind {
+			case LineAdded:
+				added++
+			case LineRemoved:
+				removed++
+			}
+		}
+	}
+	return added, removed
+}
+
+// Describe returns a short change description, e.g. "renamed from a.go, +3 -1"
+func (f *FileDiff) Describe() string {
+	var parts []string
+	switch f.Status {
+	case StatusRenamed, StatusCopied:
+		parts = append(parts, fmt.Sprintf("%s from %s", f.Status, f.OldPath))
+	case StatusAdded, StatusDeleted:
+		parts = append(parts, string(f.Status))
+	}
+	switch {
+	case f.Binary:
+		parts = append(parts, "binary")
+	case f.IsModeChange():
+		parts = append(parts, fmt.Sprintf("mode %s → %s", f.OldMode, f.NewMode))
+	case len(f.Hunks) > 0:
+		added, removed := f.Stats()
+		parts = append(parts, fmt.Sprintf("+%d -%d", added, removed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String renders the file diff back to unified diff text
+func (f *FileDiff) String() string {
+	var sb strings.Builder
+	f.writeTo(&sb)
+	return sb.String()
+}
+
+// writeTo renders the file diff into sb
+func (f *FileDiff) writeTo(sb *strings.Builder) {
+	for _, line := range f.Header {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	for _, h := range f.Hunks {
+		sb.WriteString(h.HeaderLine())
+		sb.WriteByte('\n')
+		for _, l := range h.Lines {
+			sb.WriteString(l.String())
+			sb.WriteByte('\n')
+		}
+	}
+}
+
+// HeaderLine renders the "@@ -a,b +c,d @@ section" line
+func (h *Hunk) HeaderLine() string {
+	line := fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+	if h.Section != "" {
+		line += " " + h.Section
+	}
+	return line
+}
+
+// hunkRange formats a hunk range, omitting a length of 1 like git does
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
+
+// String renders the line with its diff prefix
+func (l Line) String() string {
+	switch l.Kind {
+	case LineAdded:
+		return "+" + l.Content
+	case LineRemoved:
+		return "-" + l.Content
+	case LineNoNewline:
+		return `\` + l.Content
+	default:
+		return " " + l.Content
+	}
+}
+
+// Format renders file diffs back to unified diff text
+func Format(files []*FileDiff) string {
+	var sb strings.Builder
+	for _, f := range files {
+		f.writeTo(&sb)
+	}
+	return sb.String()
+}
+
+// Paths returns the identifying path of each file
+func Paths(files []*FileDiff) []string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path())
+	}
+	return paths
+}
@@ -0,0 +1,250 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeaderPattern matches "@@ -a,b +c,d @@ section"
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// devNull is the path git uses for the missing side of added and deleted files
+const devNull = "/dev/null"
+
+// parser holds the state while reading a unified diff
+type parser struct {
+	files   []*FileDiff
+	file    *FileDiff
+	hunk    *Hunk
+	oldLeft int
+	newLeft int
+	oldLine int
+	newLine int
+}
+
+// Parse parses unified git diff output. Text before the first "diff --git" line is ignored.
+func Parse(raw string) ([]*FileDiff, error) {
+	p := &parser{}
+	for lineNum, line := range strings.Split(strings.TrimSuffix(raw, "\n"), "\n") {
+		if err := p.parseLine(line); err != nil {
+			return nil, fmt.Errorf("diff line %d: %w", lineNum+1, err)
+		}
+	}
+	return p.files, nil
+}
+
+// parseLine consumes a single line of diff output
+func (p *parser) parseLine(line string) error {
+	// Hunk bodies are consumed by count so "--- " and "+++ " content lines are not misread
+	if p.hunk != nil && (p.oldLeft > 0 || p.newLeft > 0) {
+		return p.parseHunkLine(line)
+	}
+	if p.hunk != nil && strings.HasPrefix(line, `\`) {
+		p.hunk.Lines = append(p.hunk.Lines, Line{Kind: LineNoNewline, Content: line[1:]})
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		p.startFile(line)
+	case p.file == nil:
+		// Preamble such as a commit message
+	case strings.HasPrefix(line, "@@ "):
+		return p.startHunk(line)
+	default:
+		p.parseHeaderLine(line)
+	}
+	return nil
+}
+
+// startFile begins a new file from its "diff --git a/x b/y" line
+func (p *parser) startFile(line string) {
+	oldPath, newPath := parseGitHeaderPaths(strings.TrimPrefix(line, "diff --git "))
+	p.file = &FileDiff{
+		OldPath: oldPath,
+		NewPath: newPath,
+		Status:  StatusModified,
+		Header:  []string{line},
+	}
+	p.hunk = nil
+	p.files = append(p.files, p.file)
+}
+
+// parseHeaderLine handles extended header lines between "diff --git" and the first hunk
+func (p *parser) parseHeaderLine(line string) {
+	f := p.file
+	f.Header = append(f.Header, line)
+
+	switch {
+	case strings.HasPrefix(line, "new file mode "):
+		f.Status = StatusAdded
+		f.NewMode = strings.TrimPrefix(line, "new file mode ")
+		f.OldPath = ""
+	case strings.HasPrefix(line, "deleted file mode "):
+		f.Status = StatusDeleted
+		f.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+		f.NewPath = ""
+	case strings.HasPrefix(line, "old mode "):
+		f.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		f.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "rename from "):
+		f.Status = StatusRenamed
+		f.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		f.Status = StatusRenamed
+		f.NewPath = unquotePath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		f.Status = StatusCopied
+		f.OldPath = unquotePath(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		f.Status = StatusCopied
+		f.NewPath = unquotePath(strings.TrimPrefix(line, "copy to "))
+	case strings.HasPrefix(line, "similarity index "):
+		f.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+	case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+		f.Binary = true
+	case strings.HasPrefix(line, "--- "):
+		if path := sidePath(strings.TrimPrefix(line, "--- "), "a/"); path != devNull {
+			f.OldPath = path
+		}
+	case strings.HasPrefix(line, "+++ "):
+		if path := sidePath(strings.TrimPrefix(line, "+++ "), "b/"); path != devNull {
+			f.NewPath = path
+		}
+	}
+}
+
+// startHunk begins a hunk from its "@@ -a,b +c,d @@" line
+func (p *parser) startHunk(line string) error {
+	m := hunkHeaderPattern.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("invalid hunk header %q", line)
+	}
+
+	h := &Hunk{
+		OldStart: atoi(m[1]),
+		OldLines: atoiOr(m[2], 1),
+		NewStart: atoi(m[3]),
+		NewLines: atoiOr(m[4], 1),
+		Section:  m[5],
+	}
+	p.file.Hunks = append(p.file.Hunks, h)
+	p.hunk = h
+	p.oldLeft, p.newLeft = h.OldLines, h.NewLines
+	p.oldLine, p.newLine = h.OldStart, h.NewStart
+	return nil
+}
+
+// parseHunkLine consumes a context, added or removed line of the current hunk
+func (p *parser) parseHunkLine(line string) error {
+	var l Line
+	switch {
+	case strings.HasPrefix(line, "+"):
+		l = Line{Kind: LineAdded, Content: line[1:], NewLine: p.newLine}
+		p.newLine++
+		p.newLeft--
+	case strings.HasPrefix(line, "-"):
+		l = Line{Kind: LineRemoved, Content: line[1:], OldLine: p.oldLine}
+		p.oldLine++
+		p.oldLeft--
+	case strings.HasPrefix(line, `\`):
+		l = Line{Kind: LineNoNewline, Content: line[1:]}
+	case strings.HasPrefix(line, " ") || line == "":
+		// Some tools strip the leading space of empty context lines
+		l = Line{Kind: LineContext, Content: strings.TrimPrefix(line, " "), OldLine: p.oldLine, NewLine: p.newLine}
+		p.oldLine++
+		p.newLine++
+		p.oldLeft--
+		p.newLeft--
+	default:
+		return fmt.Errorf("unexpected line in hunk %q", line)
+	}
+
+	p.hunk.Lines = append(p.hunk.Lines, l)
+	return nil
+}
+
+// parseGitHeaderPaths extracts both paths from the "a/x b/y" part of a "diff --git" line.
+// Unquoted paths containing spaces are ambiguous; they are resolved by assuming both sides
+// are equal, and "---"/"+++"/rename lines later override the result when present.
+func parseGitHeaderPaths(s string) (oldPath, newPath string) {
+	if strings.HasPrefix(s, `"`) {
+		first, rest, ok := cutQuoted(s)
+		if ok {
+			return strings.TrimPrefix(first, "a/"), sidePath(strings.TrimSpace(rest), "b/")
+		}
+	}
+	if strings.HasSuffix(s, `"`) {
+		if i := strings.LastIndex(s, ` "`); i >= 0 {
+			return sidePath(s[:i], "a/"), sidePath(s[i+1:], "b/")
+		}
+	}
+
+	// "a/P b/P": both halves have the same length when the paths are equal
+	if n := len(s); n%2 == 1 {
+		half := (n - 1) / 2
+		a, b := s[:half], s[half+1:]
+		if strings.HasPrefix(a, "a/") && strings.HasPrefix(b, "b/") && a[2:] == b[2:] {
+			return a[2:], b[2:]
+		}
+	}
+	if a, b, ok := strings.Cut(s, " b/"); ok {
+		return strings.TrimPrefix(a, "a/"), b
+	}
+	return strings.TrimPrefix(s, "a/"), strings.TrimPrefix(s, "a/")
+}
+
+// sidePath decodes a "---"/"+++" path: unquotes it, drops the trailing tab git adds to
+// paths with spaces, and strips the "a/" or "b/" prefix
+func sidePath(s, prefix string) string {
+	s = unquotePath(strings.TrimSuffix(s, "\t"))
+	if s == devNull {
+		return s
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// unquotePath decodes a C-style quoted path as produced by git for special characters
+func unquotePath(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
+
+// cutQuoted splits a leading quoted string from s and returns it unquoted with the remainder
+func cutQuoted(s string) (quoted, rest string, ok bool) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			unquoted, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", false
+			}
+			return unquoted, s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// atoi converts a regexp-validated number
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// atoiOr converts s, or returns def if s is empty (omitted hunk lengths default to 1)
+func atoiOr(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	return atoi(s)
+}
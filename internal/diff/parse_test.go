@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@ package main
+ func main() {
+--- old comment
++++ new comment
+ 	run()
+ }
+diff --git a/old name.go b/new name.go
+similarity index 90%
+rename from old name.go
+rename to new name.go
+index 1111111..2222222 100644
+--- a/old name.go	
++++ b/new name.go	
+@@ -1 +1 @@
+-a
++b
+\ No newline at end of file
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..4444444
+Binary files /dev/null and b/logo.png differ
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git "a/tab\there.go" "b/tab\there.go"
+new file mode 100644
+index 0000000..5555555
+--- /dev/null
++++ "b/tab\there.go"
+@@ -0,0 +1 @@
++package tab
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	files, err := Parse("commit message preamble\n\n" + sampleDiff)
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go", "new name.go", "gone.go", "logo.png", "run.sh", "tab\there.go"}, Paths(files))
+
+	main := files[0]
+	require.Equal(t, StatusModified, main.Status)
+	require.Equal(t, "package main", main.Hunks[0].Section)
+	added, removed := main.Stats()
+	require.Equal(t, 1, added)
+	require.Equal(t, 1, removed)
+	require.Equal(t, Line{Kind: LineAdded, Content: "++ new comment", NewLine: 2}, main.Hunks[0].Lines[2])
+
+	renamed := files[1]
+	require.Equal(t, StatusRenamed, renamed.Status)
+	require.Equal(t, "old name.go", renamed.OldPath)
+	require.Equal(t, 90, renamed.Similarity)
+	require.Equal(t, LineNoNewline, renamed.Hunks[0].Lines[2].Kind)
+	require.Equal(t, "renamed from old name.go, +1 -1", renamed.Describe())
+
+	require.Equal(t, StatusDeleted, files[2].Status)
+	require.Empty(t, files[2].NewPath)
+	require.Equal(t, "gone.go", files[2].Path())
+
+	require.True(t, files[3].Binary)
+	require.Equal(t, StatusAdded, files[3].Status)
+	require.Equal(t, "added, binary", files[3].Describe())
+
+	require.True(t, files[4].IsModeChange())
+	require.Equal(t, "mode 100644 → 100755", files[4].Describe())
+
+	require.Equal(t, StatusAdded, files[5].Status)
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	files, err := Parse(sampleDiff)
+	require.NoError(t, err)
+	require.Equal(t, sampleDiff, Format(files))
+}
+
+func TestParseInvalidHunk(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse("diff --git a/x b/x\n@@ broken @@\n")
+	require.Error(t, err)
+}
+
+func TestParseGitHeaderPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, old, new string
+	}{
+		{in: "a/x.go b/x.go", old: "x.go", new: "x.go"},
+		{in: "a/with space.go b/with space.go", old: "with space.go", new: "with space.go"},
+		{in: "a/old.go b/new.go", old: "old.go", new: "new.go"},
+		{in: `"a/q\"x.go" "b/q\"x.go"`, old: `q"x.go`, new: `q"x.go`},
+	}
+	for _, tt := range tests {
+		old, new := parseGitHeaderPaths(tt.in)
+		require.Equal(t, tt.old, old, tt.in)
+		require.Equal(t, tt.new, new, tt.in)
+	}
+}
@@ -0,0 +1,63 @@
+// Package egress guards outbound HTTP requests in offline mode.
+package egress
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ErrBlocked is returned for requests to hosts that are not allowed
+var ErrBlocked = errors.New("offline mode: outbound request blocked")
+
+// Guard is an http.RoundTripper that only lets requests to allowed hosts through
+type Guard struct {
+	// Hosts are the allowed host names; "*.example.com" allows the subdomains of example.com
+	Hosts []string
+	// Next carries the allowed requests
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper, failing requests to hosts that are not allowed
+func (g *Guard) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	if !Allowed(g.Hosts, host) {
+		slog.Error("Blocked outbound request", "method", req.Method, "host", host, "allowed_hosts", g.Hosts)
+		return nil, fmt.Errorf("%w: %s is not an allowed host (allowed: %s); add it to options.allowed_hosts", ErrBlocked, host, describe(g.Hosts))
+	}
+	return g.Next.RoundTrip(req)
+}
+
+// Install routes the default HTTP transport, and every client built on it, through a Guard
+func Install(hosts []string) {
+	if _, ok := http.DefaultTransport.(*Guard); ok {
+		return
+	}
+	http.DefaultTransport = &Guard{Hosts: hosts, Next: http.DefaultTransport}
+}
+
+// Allowed reports whether host matches one of the allowed hosts (case-insensitive)
+func Allowed(hosts []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+	return slices.ContainsFunc(hosts, func(allowed string) bool {
+		allowed = strings.ToLower(strings.TrimSuffix(allowed, "."))
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok {
+			return strings.HasSuffix(host, "."+suffix)
+		}
+		return host == allowed
+	})
+}
+
+// describe lists the allowed hosts for error messages
+func describe(hosts []string) string {
+	if len(hosts) == 0 {
+		return "none"
+	}
+	return strings.Join(hosts, ", ")
+}
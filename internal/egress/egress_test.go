@@ -0,0 +1,51 @@
+package egress
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowed(t *testing.T) {
+	hosts := []string{"llm.internal.example", "*.corp.example", "127.0.0.1"}
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"llm.internal.example", true},
+		{"LLM.Internal.Example.", true},
+		{"api.corp.example", true},
+		{"a.b.corp.example", true},
+		{"corp.example", false},
+		{"evilcorp.example", false},
+		{"127.0.0.1", true},
+		{"api.openai.com", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			require.Equal(t, tt.want, Allowed(hosts, tt.host))
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	allowed := &http.Client{Transport: &Guard{Hosts: []string{"127.0.0.1"}, Next: http.DefaultTransport}}
+	resp, err := allowed.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	blocked := &http.Client{Transport: &Guard{Next: http.DefaultTransport}}
+	_, err = blocked.Get(server.URL)
+	require.ErrorIs(t, err, ErrBlocked)
+	require.Contains(t, err.Error(), "127.0.0.1 is not an allowed host (allowed: none)")
+}
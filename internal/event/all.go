@@ -33,6 +33,13 @@ func SessionSwitched() {
 	send("session switched")
 }
 
+func ReviewStarted(props ...any) {
+	send(
+		"review started",
+		props...,
+	)
+}
+
 func FilePickerOpened() {
 	send("filepicker opened")
 }
@@ -34,6 +34,17 @@ func SetInteractive(interactive bool) {
 	baseProps = baseProps.Set("interactive", interactive)
 }
 
+// SetPreset tags the events that follow with the review preset, so usage can be reported per preset
+func SetPreset(name string) {
+	baseProps = baseProps.Set("preset", name)
+}
+
+// Endpoint returns where remote events are sent
+func Endpoint() string { return endpoint }
+
+// MachineID returns the anonymous ID remote events are sent with
+func MachineID() string { return getDistinctId() }
+
 func Init() {
 	c, err := posthog.NewWithConfig(key, posthog.Config{
 		Endpoint: endpoint,
@@ -62,15 +73,22 @@ func Alias(userID string) {
 	slog.Info("Aliased in PostHog", "machine_id", distinctId, "user_id", userID)
 }
 
-// send logs an event to PostHog with the given event name and properties.
+// send logs an event to PostHog and the local event log with the given event name and properties.
 func send(event string, props ...any) {
+	if client == nil && local == nil {
+		return
+	}
+	properties := pairsToProps(props...).Merge(baseProps)
+	if local != nil {
+		local.write(event, properties)
+	}
 	if client == nil {
 		return
 	}
 	err := client.Enqueue(posthog.Capture{
 		DistinctId: distinctId,
 		Event:      event,
-		Properties: pairsToProps(props...).Merge(baseProps),
+		Properties: properties,
 	})
 	if err != nil {
 		slog.Error("Failed to enqueue PostHog event", "event", event, "props", props, "error", err)
@@ -80,7 +98,7 @@ func send(event string, props ...any) {
 
 // Error logs an error event to PostHog with the error type and message.
 func Error(err any, props ...any) {
-	if client == nil {
+	if client == nil && local == nil {
 		return
 	}
 	// The PostHog Go client does not yet support sending exceptions.
@@ -99,6 +117,12 @@ func Error(err any, props ...any) {
 }
 
 func Flush() {
+	if local != nil {
+		if err := local.close(); err != nil {
+			slog.Error("Failed to close local event log", "error", err)
+		}
+		local = nil
+	}
 	if client == nil {
 		return
 	}
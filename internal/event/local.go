@@ -0,0 +1,59 @@
+package event
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/posthog/posthog-go"
+)
+
+// LocalFile is the name of the local event log in the data directory
+const LocalFile = "events.jsonl"
+
+// LocalEvent is one line of the local event log
+type LocalEvent struct {
+	Time       time.Time      `json:"time"`
+	Event      string         `json:"event"`
+	Properties map[string]any `json:"properties"`
+}
+
+// localSink appends events to a JSON lines file
+type localSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var local *localSink
+
+// InitLocal records events as JSON lines appended to path
+func InitLocal(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open local event log: %w", err)
+	}
+	local = &localSink{file: f}
+	return nil
+}
+
+func (s *localSink) write(event string, props posthog.Properties) {
+	line, err := sonic.Marshal(LocalEvent{Time: time.Now().UTC(), Event: event, Properties: props})
+	if err != nil {
+		slog.Error("Failed to encode local event", "event", event, "error", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		slog.Error("Failed to write local event", "event", event, "error", err)
+	}
+}
+
+func (s *localSink) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
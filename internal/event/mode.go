@@ -0,0 +1,26 @@
+package event
+
+import "fmt"
+
+// Mode selects where events are recorded
+type Mode string
+
+// Telemetry modes
+const (
+	// ModeOff records nothing
+	ModeOff Mode = "off"
+	// ModeLocal appends events to a JSON lines file in the data directory; nothing leaves the machine
+	ModeLocal Mode = "local"
+	// ModeRemote sends events to PostHog
+	ModeRemote Mode = "remote"
+)
+
+// ParseMode validates a telemetry mode name
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeOff, ModeLocal, ModeRemote:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid telemetry mode %q: must be one of off, local, remote", s)
+	}
+}
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+)
+
+// baselineHeader is written at the top of new baseline files
+const baselineHeader = `# revcli secrets baseline: known false positives that are not reported.
+# Each line is the sha256 of a matched value, followed by where it was found.
+`
+
+// Baseline is a set of secret fingerprints that are allowed (known false positives)
+type Baseline map[string]bool
+
+// Contains returns true if the fingerprint is allowlisted
+func (b Baseline) Contains(fingerprint string) bool {
+	return b[fingerprint]
+}
+
+// ProjectBaselinePath returns the default baseline path (<repo>/.revcli/secrets-baseline)
+func ProjectBaselinePath(repoRoot string) string {
+	return filepath.Join(repoRoot, config.ProjectDirName, config.SecretsBaselineFileName)
+}
+
+// LoadBaseline reads a baseline file; a missing file yields an empty baseline
+func LoadBaseline(path string) (Baseline, error) {
+	baseline := Baseline{}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return baseline, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secrets baseline %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		baseline[fields[0]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read secrets baseline %s: %w", path, err)
+	}
+	return baseline, nil
+}
+
+// AppendBaseline adds the fingerprints of matches to the baseline file
+func AppendBaseline(path string, matches []SecretMatch) error {
+	existing, err := LoadBaseline(path)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	if len(existing) == 0 {
+		sb.WriteString(baselineHeader)
+	}
+	for _, m := range matches {
+		if existing.Contains(m.Fingerprint) {
+			continue
+		}
+		existing[m.Fingerprint] = true
+		fmt.Fprintf(&sb, "%s  %s:%d %s\n", m.Fingerprint, m.FilePath, m.Line, m.RuleID)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open secrets baseline %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(sb.String()); err != nil {
+		return fmt.Errorf("failed to write secrets baseline %s: %w", path, err)
+	}
+	return nil
+}
@@ -1,19 +1,23 @@
 package filter
 
 import (
-	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
 )
 
-// IgnoredPatterns contains file patterns to ignore during review
+// IgnoredPatterns contains the built-in gitignore-style patterns ignored during review.
+// Projects can extend or override them (e.g. "!go.mod") in .revcli/ignore.
 var IgnoredPatterns = []string{
 	"go.sum",
 	"go.mod",
 	"vendor/",
-	"_generated.go",
-	".pb.go",
-	"_test.go",
-	".mock.go",
+	"*_generated.go",
+	"*.pb.go",
+	"*.mock.go",
 	"mocks/",
 	"testdata/",
 	".git/",
@@ -22,27 +26,14 @@ var IgnoredPatterns = []string{
 	"build/",
 }
 
-// SecretPatterns contains regex patterns that might indicate secrets
-var SecretPatterns = []*regexp.Regexp{
-	regexp.MustCompile(`(?i)(api[_-]?key|apikey)\s*[:=]\s*["']?[a-zA-Z0-9_\-]{20,}["']?`),
-	regexp.MustCompile(`(?i)(secret|password|passwd|pwd)\s*[:=]\s*["'][^"']{8,}["']`),
-	regexp.MustCompile(`(?i)(token|bearer)\s*[:=]\s*["']?[a-zA-Z0-9_\-\.]{20,}["']?`),
-	regexp.MustCompile(`(?i)private[_-]?key\s*[:=]`),
-	regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH )?PRIVATE KEY-----`),
-	regexp.MustCompile(`(?i)(aws[_-]?access[_-]?key[_-]?id|aws[_-]?secret[_-]?access[_-]?key)\s*[:=]\s*["']?[A-Z0-9]{16,}["']?`),
-	regexp.MustCompile(`ghp_[a-zA-Z0-9]{36}`),                                     // GitHub personal access token
-	regexp.MustCompile(`gho_[a-zA-Z0-9]{36}`),                                     // GitHub OAuth token
-	regexp.MustCompile(`sk-[a-zA-Z0-9]{32,}`),                                     // OpenAI API key
-	regexp.MustCompile(`AIza[0-9A-Za-z\-_]{35}`),                                  // Google API key
-	regexp.MustCompile(`(?i)database[_-]?url\s*[:=]\s*["']?[a-zA-Z]+://[^"'\s]+`), // Database URLs with credentials
-}
-
 // FilterResult contains the filtering results
 type FilterResult struct {
 	// FilteredFiles maps file paths to their content after filtering
 	FilteredFiles map[string]string
-	// IgnoredFiles lists files that were ignored
-	IgnoredFiles []string
+	// Diff is the diff of the files that were not ignored
+	Diff []*diff.FileDiff
+	// IgnoredFiles lists files that were ignored and the rule that matched
+	IgnoredFiles []IgnoredFile
 	// SecretsFound contains potential secrets that were detected
 	SecretsFound []SecretMatch
 }
@@ -51,95 +42,74 @@ type FilterResult struct {
 type SecretMatch struct {
 	FilePath string
 	Line     int
-	Match    string
-	Pattern  string
+	// Match is the masked value (safe to display)
+	Match string
+	// Pattern describes the rule that matched
+	Pattern string
+	// RuleID identifies the rule that matched
+	RuleID string
+	// Fingerprint is the sha256 of the value, used for baselines
+	Fingerprint string
+
+	// value is the raw matched value, kept only for redaction
+	value string
 }
 
 // Filter filters out ignored files and scans for secrets
-func Filter(files map[string]string, rawDiff string) *FilterResult {
+func Filter(files map[string]string, diffFiles []*diff.FileDiff, matcher *Matcher, scanner *Scanner) *FilterResult {
 	result := &FilterResult{
 		FilteredFiles: make(map[string]string),
-		IgnoredFiles:  []string{},
+		IgnoredFiles:  []IgnoredFile{},
 		SecretsFound:  []SecretMatch{},
 	}
 
-	for path, content := range files {
+	// Files without contents (e.g. deleted files) only appear in the diff
+	paths := lo.Union(lo.Keys(files), diff.Paths(diffFiles))
+	slices.Sort(paths)
+
+	for _, path := range paths {
 		// Check if file should be ignored
-		if shouldIgnore(path) {
-			result.IgnoredFiles = append(result.IgnoredFiles, path)
+		if rule, ignored := matcher.Match(path); ignored {
+			result.IgnoredFiles = append(result.IgnoredFiles, IgnoredFile{Path: path, Rule: rule})
+			continue
+		}
+
+		content, ok := files[path]
+		if !ok {
 			continue
 		}
 
 		// Scan for secrets
-		secrets := scanForSecrets(path, content)
+		secrets := scanner.Scan(path, content)
 		result.SecretsFound = append(result.SecretsFound, secrets...)
 
 		result.FilteredFiles[path] = content
 	}
 
-	// Also scan the raw diff for secrets
-	diffSecrets := scanForSecrets("diff", rawDiff)
+	// Also scan the diff of reviewed files for secrets
+	result.Diff = FilterDiff(diffFiles, matcher)
+	diffSecrets := scanner.Scan("diff", diffText(result.Diff))
 	result.SecretsFound = append(result.SecretsFound, diffSecrets...)
 
 	return result
 }
 
-// shouldIgnore checks if a file path matches any ignored pattern
-func shouldIgnore(path string) bool {
-	for _, pattern := range IgnoredPatterns {
-		// Check if pattern is a suffix match (for extensions)
-		if strings.HasSuffix(pattern, ".go") || strings.HasSuffix(pattern, ".sum") || strings.HasSuffix(pattern, ".mod") {
-			if strings.HasSuffix(path, pattern) || path == pattern {
-				return true
-			}
+// diffText returns the text of a diff without its line markers; + is a base64 character, so a
+// marker would be read as part of a token, and redacting the token would remove the marker
+func diffText(files []*diff.FileDiff) string {
+	var b strings.Builder
+	for _, f := range files {
+		for _, line := range f.Header {
+			b.WriteString(line + "\n")
 		}
-		// Check if pattern is a directory prefix
-		if strings.HasSuffix(pattern, "/") {
-			if strings.HasPrefix(path, pattern) || strings.Contains(path, "/"+pattern) {
-				return true
+		for _, hunk := range f.Hunks {
+			b.WriteString(hunk.Section + "\n")
+			for _, line := range hunk.Lines {
+				b.WriteString(line.Content + "\n")
 			}
 		}
-		// Exact match
-		if path == pattern {
-			return true
-		}
 	}
-	return false
-}
-
-// scanForSecrets scans content for potential secrets
-func scanForSecrets(filePath, content string) []SecretMatch {
-	var matches []SecretMatch
-
-	lines := strings.Split(content, "\n")
-	for lineNum, line := range lines {
-		for _, pattern := range SecretPatterns {
-			if match := pattern.FindString(line); match != "" {
-				// Mask the actual secret value for safety
-				maskedMatch := maskSecret(match)
-				matches = append(matches, SecretMatch{
-					FilePath: filePath,
-					Line:     lineNum + 1,
-					Match:    maskedMatch,
-					Pattern:  pattern.String(),
-				})
-			}
-		}
-	}
-
-	return matches
-}
-
-// maskSecret masks the sensitive part of a secret
-func maskSecret(secret string) string {
-	if len(secret) <= 10 {
-		return "***REDACTED***"
-	}
-
-	// Show first 5 and last 3 characters
-	prefix := secret[:5]
-	suffix := secret[len(secret)-3:]
-	return prefix + "***" + suffix
+	return b.String()
 }
 
 // HasSecrets returns true if any secrets were found
@@ -147,33 +117,10 @@ func (r *FilterResult) HasSecrets() bool {
 	return len(r.SecretsFound) > 0
 }
 
-// FilterDiff filters the raw diff to remove ignored file changes
-func FilterDiff(rawDiff string) string {
-	var result strings.Builder
-	var currentFile string
-	var skipFile bool
-
-	lines := strings.Split(rawDiff, "\n")
-	for _, line := range lines {
-		// Detect new file in diff
-		if strings.HasPrefix(line, "diff --git") {
-			parts := strings.Split(line, " ")
-			if len(parts) >= 4 {
-				bPath := parts[3]
-				if strings.HasPrefix(bPath, "b/") {
-					currentFile = strings.TrimPrefix(bPath, "b/")
-					skipFile = shouldIgnore(currentFile)
-				}
-			}
-		}
-
-		// Include line if we're not skipping this file
-		if !skipFile {
-			result.WriteString(line)
-			result.WriteString("\n")
-		}
-	}
-
-	return result.String()
+// FilterDiff removes the diffs of ignored files
+func FilterDiff(files []*diff.FileDiff, matcher *Matcher) []*diff.FileDiff {
+	return lo.Reject(files, func(f *diff.FileDiff, _ int) bool {
+		_, ignored := matcher.Match(f.Path())
+		return ignored
+	})
 }
-
@@ -0,0 +1,215 @@
+package filter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+)
+
+// Ignore rule sources that are not files
+const (
+	SourceDefault = "default"
+	SourceExclude = "--exclude"
+	SourceInclude = "--include"
+)
+
+// IgnoreRule is a single gitignore-style pattern and where it was defined
+type IgnoreRule struct {
+	// Pattern is the gitignore pattern (a leading "!" re-includes matching files)
+	Pattern string
+	// Source is "default", a flag name, or "file:line"
+	Source string
+}
+
+// String formats the rule for summaries, e.g. "vendor/ (default)"
+func (r IgnoreRule) String() string {
+	return fmt.Sprintf("%s (%s)", r.Pattern, r.Source)
+}
+
+// IgnoredFile is a file excluded from review and the rule that excluded it
+type IgnoredFile struct {
+	Path string
+	Rule IgnoreRule
+}
+
+// Matcher decides which files are excluded from review using gitignore semantics:
+// the last matching rule wins, and files inside an excluded directory cannot be re-included.
+type Matcher struct {
+	rules []compiledRule
+}
+
+// compiledRule is an IgnoreRule parsed into its gitignore components
+type compiledRule struct {
+	IgnoreRule
+	glob     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewMatcher compiles the rules into a matcher
+func NewMatcher(rules []IgnoreRule) *Matcher {
+	return &Matcher{rules: lo.FilterMap(rules, func(r IgnoreRule, _ int) (compiledRule, bool) {
+		return compileRule(r)
+	})}
+}
+
+// compileRule parses a gitignore pattern; blank lines and comments yield no rule
+func compileRule(r IgnoreRule) (compiledRule, bool) {
+	glob := strings.TrimSpace(r.Pattern)
+	if glob == "" || strings.HasPrefix(glob, "#") {
+		return compiledRule{}, false
+	}
+
+	c := compiledRule{IgnoreRule: r}
+	if rest, ok := strings.CutPrefix(glob, "!"); ok {
+		c.negate = true
+		glob = rest
+	}
+	// "\!" and "\#" match a literal leading "!" or "#"
+	if strings.HasPrefix(glob, `\!`) || strings.HasPrefix(glob, `\#`) {
+		glob = glob[1:]
+	}
+	if rest, ok := strings.CutSuffix(glob, "/"); ok {
+		c.dirOnly = true
+		glob = rest
+	}
+	// A slash anywhere but the end anchors the pattern to the repository root
+	c.anchored = strings.Contains(glob, "/")
+	c.glob = strings.TrimPrefix(glob, "/")
+
+	if c.glob == "" || !doublestar.ValidatePattern(c.glob) {
+		return compiledRule{}, false
+	}
+	return c, true
+}
+
+// matches reports whether the rule matches a repository-relative path
+func (c compiledRule) matches(path string, isDir bool) bool {
+	if c.dirOnly && !isDir {
+		return false
+	}
+	target := path
+	if !c.anchored {
+		target = pathpkg.Base(path)
+	}
+	// doublestar lets a trailing "/**" match zero segments, but in gitignore "dir/**" only matches below dir/
+	if dir, ok := strings.CutSuffix(c.glob, "/**"); ok {
+		if self, _ := doublestar.Match(dir, target); self {
+			return false
+		}
+	}
+	ok, _ := doublestar.Match(c.glob, target)
+	return ok
+}
+
+// Match returns the rule that excludes path, if any
+func (m *Matcher) Match(path string) (IgnoreRule, bool) {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i := 1; i <= len(parts); i++ {
+		candidate := strings.Join(parts[:i], "/")
+		isDir := i < len(parts)
+		rule, ok := m.lastMatch(candidate, isDir)
+		if ok && !rule.negate {
+			return rule.IgnoreRule, true
+		}
+	}
+	return IgnoreRule{}, false
+}
+
+// lastMatch returns the last rule matching path
+func (m *Matcher) lastMatch(path string, isDir bool) (compiledRule, bool) {
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.rules[i].matches(path, isDir) {
+			return m.rules[i], true
+		}
+	}
+	return compiledRule{}, false
+}
+
+// DefaultMatcher returns a matcher with only the built-in rules
+func DefaultMatcher() *Matcher {
+	return NewMatcher(DefaultRules())
+}
+
+// DefaultRules returns the built-in rules from IgnoredPatterns
+func DefaultRules() []IgnoreRule {
+	return lo.Map(IgnoredPatterns, func(p string, _ int) IgnoreRule {
+		return IgnoreRule{Pattern: p, Source: SourceDefault}
+	})
+}
+
+// LoadIgnoreRules assembles rules in precedence order: built-in defaults, the global
+// ignore file, the project's .revcli/ignore, then --exclude and --include patterns
+func LoadIgnoreRules(repoRoot string, include, exclude []string) ([]IgnoreRule, error) {
+	rules := DefaultRules()
+
+	globalPath, err := GlobalIgnorePath()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range []string{globalPath, ProjectIgnorePath(repoRoot)} {
+		fileRules, err := readIgnoreFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	for _, p := range exclude {
+		rules = append(rules, IgnoreRule{Pattern: p, Source: SourceExclude})
+	}
+	for _, p := range include {
+		rules = append(rules, IgnoreRule{Pattern: "!" + strings.TrimPrefix(p, "!"), Source: SourceInclude})
+	}
+	return rules, nil
+}
+
+// GlobalIgnorePath returns the path of the global ignore file (~/.config/revcli/ignore)
+func GlobalIgnorePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, config.ConfigDirName, config.AppDirName, config.IgnoreFileName), nil
+}
+
+// ProjectIgnorePath returns the path of the project ignore file (<repo>/.revcli/ignore)
+func ProjectIgnorePath(repoRoot string) string {
+	return filepath.Join(repoRoot, config.ProjectDirName, config.IgnoreFileName)
+}
+
+// readIgnoreFile reads gitignore-style rules from a file; a missing file yields no rules
+func readIgnoreFile(path string) ([]IgnoreRule, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ignore file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var rules []IgnoreRule
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, IgnoreRule{Pattern: line, Source: fmt.Sprintf("%s:%d", path, lineNum)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore file %s: %w", path, err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,93 @@
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
+)
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	rules := append(DefaultRules(),
+		IgnoreRule{Pattern: "*_test.go", Source: ".revcli/ignore:1"},
+		IgnoreRule{Pattern: "!important_test.go", Source: ".revcli/ignore:2"},
+		IgnoreRule{Pattern: "/docs/*.md", Source: ".revcli/ignore:3"},
+		IgnoreRule{Pattern: "!vendor/keep.go", Source: SourceInclude},
+		IgnoreRule{Pattern: "gen/**", Source: SourceExclude},
+		IgnoreRule{Pattern: "!gen/keep.go", Source: SourceInclude},
+		IgnoreRule{Pattern: "file?.txt", Source: SourceExclude},
+	)
+	m := NewMatcher(rules)
+
+	tests := []struct {
+		path    string
+		ignored bool
+		source  string
+	}{
+		{path: "main.go"},
+		{path: "go.sum", ignored: true, source: SourceDefault},
+		{path: "pkg/api/api.pb.go", ignored: true, source: SourceDefault},
+		{path: "pkg/handler_test.go", ignored: true, source: ".revcli/ignore:1"},
+		{path: "pkg/important_test.go"},
+		{path: "docs/guide.md", ignored: true, source: ".revcli/ignore:3"},
+		{path: "pkg/docs/guide.md"},
+		// Files inside an excluded directory cannot be re-included
+		{path: "vendor/keep.go", ignored: true, source: SourceDefault},
+		{path: "internal/vendor/x.go", ignored: true, source: SourceDefault},
+		{path: "gen/a/b.go", ignored: true, source: SourceExclude},
+		{path: "gen/drop.go", ignored: true, source: SourceExclude},
+		// "gen/**" matches below gen/ but not gen itself, so its files can be re-included
+		{path: "gen/keep.go"},
+		{path: "file1.txt", ignored: true, source: SourceExclude},
+		{path: "file10.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			rule, ignored := m.Match(tt.path)
+			require.Equal(t, tt.ignored, ignored)
+			require.Equal(t, tt.source, rule.Source)
+		})
+	}
+}
+
+func TestLoadIgnoreRules(t *testing.T) {
+	repo := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".revcli"), 0o755))
+	require.NoError(t, os.WriteFile(ProjectIgnorePath(repo), []byte("# comment\n\n*.snap\n"), 0o644))
+
+	rules, err := LoadIgnoreRules(repo, []string{"go.mod"}, []string{"*.tmp"})
+	require.NoError(t, err)
+
+	m := NewMatcher(rules)
+	rule, ignored := m.Match("ui/__snapshots__/a.snap")
+	require.True(t, ignored)
+	require.Equal(t, ProjectIgnorePath(repo)+":3", rule.Source)
+
+	_, ignored = m.Match("go.mod")
+	require.False(t, ignored)
+
+	rule, ignored = m.Match("x.tmp")
+	require.True(t, ignored)
+	require.Equal(t, SourceExclude, rule.Source)
+}
+
+func TestFilterReportsDeletedFiles(t *testing.T) {
+	t.Parallel()
+
+	files, err := diff.Parse("diff --git a/main.go b/main.go\n@@ -1 +1 @@\n-x\n+y\n" +
+		"diff --git a/old_test.go b/old_test.go\ndeleted file mode 100644\n--- a/old_test.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-y\n")
+	require.NoError(t, err)
+	m := NewMatcher([]IgnoreRule{{Pattern: "*_test.go", Source: SourceExclude}})
+
+	result := Filter(map[string]string{"main.go": "package main"}, files, m, DefaultScanner())
+	require.Equal(t, []IgnoredFile{{Path: "old_test.go", Rule: IgnoreRule{Pattern: "*_test.go", Source: SourceExclude}}}, result.IgnoredFiles)
+	require.Equal(t, []string{"main.go"}, diff.Paths(result.Diff))
+}
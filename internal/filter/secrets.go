@@ -0,0 +1,230 @@
+package filter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+)
+
+// Secret handling modes
+const (
+	// SecretsModeBlock aborts the review when secrets are found (unless --force)
+	SecretsModeBlock = "block"
+	// SecretsModeRedact replaces secrets with placeholders and continues
+	SecretsModeRedact = "redact"
+	// SecretsModeOff skips detection (tool results only)
+	SecretsModeOff = "off"
+)
+
+// Entropy detection defaults
+const (
+	EntropyRuleID           = "high-entropy"
+	DefaultEntropyThreshold = 4.5
+	DefaultEntropyMinLength = 20
+)
+
+// AllowSecretAnnotation on a line suppresses secret detection for that line
+const AllowSecretAnnotation = "revcli:allow-secret"
+
+// SecretRule is a named regex that detects a kind of secret
+type SecretRule = config.SecretRule
+
+// EntropyConfig configures Shannon-entropy detection of random-looking strings
+type EntropyConfig = config.EntropyConfig
+
+// SecretsConfig is the secrets section of the revcli config (options.secrets)
+type SecretsConfig = config.SecretsConfig
+
+// BuiltinSecretRules are always checked
+var BuiltinSecretRules = []SecretRule{
+	{ID: "generic-api-key", Regex: `(?i)(api[_-]?key|apikey)\s*[:=]\s*["']?[a-zA-Z0-9_\-]{20,}["']?`, Description: "API key assignment"},
+	{ID: "generic-password", Regex: `(?i)(secret|password|passwd|pwd)\s*[:=]\s*["'][^"']{8,}["']`, Description: "Password or secret assignment"},
+	{ID: "generic-token", Regex: `(?i)(token|bearer)\s*[:=]\s*["']?[a-zA-Z0-9_\-\.]{20,}["']?`, Description: "Token assignment"},
+	{ID: "private-key-assignment", Regex: `(?i)private[_-]?key\s*[:=]`, Description: "Private key assignment"},
+	{ID: "private-key-block", Regex: `-----BEGIN (RSA |EC |DSA |OPENSSH )?PRIVATE KEY-----`, Description: "PEM private key"},
+	{ID: "aws-credentials", Regex: `(?i)(aws[_-]?access[_-]?key[_-]?id|aws[_-]?secret[_-]?access[_-]?key)\s*[:=]\s*["']?[A-Z0-9]{16,}["']?`, Description: "AWS credentials"},
+	{ID: "github-pat", Regex: `ghp_[a-zA-Z0-9]{36}`, Description: "GitHub personal access token"},
+	{ID: "github-oauth", Regex: `gho_[a-zA-Z0-9]{36}`, Description: "GitHub OAuth token"},
+	{ID: "openai-api-key", Regex: `sk-[a-zA-Z0-9]{32,}`, Description: "OpenAI API key"},
+	{ID: "google-api-key", Regex: `AIza[0-9A-Za-z\-_]{35}`, Description: "Google API key"},
+	{ID: "database-url", Regex: `(?i)database[_-]?url\s*[:=]\s*["']?[a-zA-Z]+://[^"'\s]+`, Description: "Database URL with credentials"},
+}
+
+// entropyCandidatePattern matches tokens that could be random secrets
+var entropyCandidatePattern = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// compiledSecretRule is a SecretRule with its compiled regex
+type compiledSecretRule struct {
+	SecretRule
+	re *regexp.Regexp
+}
+
+// Scanner detects secrets with regex rules and entropy analysis
+type Scanner struct {
+	rules            []compiledSecretRule
+	entropyEnabled   bool
+	entropyThreshold float64
+	entropyMinLength int
+	baseline         Baseline
+}
+
+// NewScanner creates a scanner from the built-in rules plus the configured ones.
+// Matches whose fingerprint is in the baseline are not reported.
+func NewScanner(cfg *SecretsConfig, baseline Baseline) (*Scanner, error) {
+	if cfg == nil {
+		cfg = &SecretsConfig{}
+	}
+
+	s := &Scanner{
+		entropyEnabled:   true,
+		entropyThreshold: DefaultEntropyThreshold,
+		entropyMinLength: DefaultEntropyMinLength,
+		baseline:         baseline,
+	}
+	if e := cfg.Entropy; e != nil {
+		if e.Enabled != nil {
+			s.entropyEnabled = *e.Enabled
+		}
+		if e.Threshold > 0 {
+			s.entropyThreshold = e.Threshold
+		}
+		if e.MinLength > 0 {
+			s.entropyMinLength = e.MinLength
+		}
+	}
+
+	for _, rule := range slices.Concat(BuiltinSecretRules, cfg.Rules) {
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret rule %q: %w", rule.ID, err)
+		}
+		s.rules = append(s.rules, compiledSecretRule{SecretRule: rule, re: re})
+	}
+	return s, nil
+}
+
+// DefaultScanner returns a scanner with the built-in rules and no baseline
+func DefaultScanner() *Scanner {
+	s, _ := NewScanner(nil, nil)
+	return s
+}
+
+// Scan returns the secrets found in content, skipping annotated and baselined matches
+func (s *Scanner) Scan(filePath, content string) []SecretMatch {
+	var matches []SecretMatch
+
+	for lineNum, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, AllowSecretAnnotation) {
+			continue
+		}
+
+		for _, rule := range s.rules {
+			for _, value := range rule.re.FindAllString(line, -1) {
+				matches = append(matches, newSecretMatch(filePath, lineNum+1, value, rule.ID, lo.CoalesceOrEmpty(rule.Description, rule.Regex)))
+			}
+		}
+
+		if s.entropyEnabled {
+			for _, value := range s.highEntropyTokens(line) {
+				matches = append(matches, newSecretMatch(filePath, lineNum+1, value, EntropyRuleID, fmt.Sprintf("High-entropy string (>= %.1f bits/char)", s.entropyThreshold)))
+			}
+		}
+	}
+
+	return lo.Filter(matches, func(m SecretMatch, _ int) bool {
+		return !s.baseline.Contains(m.Fingerprint)
+	})
+}
+
+// highEntropyTokens returns tokens in line that look like random secrets
+func (s *Scanner) highEntropyTokens(line string) []string {
+	return lo.Filter(entropyCandidatePattern.FindAllString(line, -1), func(token string, _ int) bool {
+		// Require letters and digits to skip long identifiers and words
+		return len(token) >= s.entropyMinLength &&
+			strings.ContainsAny(token, "0123456789") &&
+			strings.ContainsFunc(token, isLetter) &&
+			ShannonEntropy(token) >= s.entropyThreshold
+	})
+}
+
+// ShannonEntropy returns the Shannon entropy of s in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Redact replaces every matched secret value in content with a placeholder
+func Redact(content string, matches []SecretMatch) string {
+	values := lo.UniqBy(matches, func(m SecretMatch) string { return m.value })
+	// Replace longer values first so overlapping matches are fully redacted
+	slices.SortFunc(values, func(a, b SecretMatch) int { return len(b.value) - len(a.value) })
+
+	oldnew := lo.FlatMap(values, func(m SecretMatch, _ int) []string {
+		return []string{m.value, RedactionPlaceholder(m.RuleID)}
+	})
+	if len(oldnew) == 0 {
+		return content
+	}
+	return strings.NewReplacer(oldnew...).Replace(content)
+}
+
+// RedactionPlaceholder returns the text that replaces a redacted secret
+func RedactionPlaceholder(ruleID string) string {
+	return "[REDACTED:" + ruleID + "]"
+}
+
+// newSecretMatch creates a match with a masked value and fingerprint
+func newSecretMatch(filePath string, line int, value, ruleID, description string) SecretMatch {
+	return SecretMatch{
+		FilePath:    filePath,
+		Line:        line,
+		Match:       maskSecret(value),
+		Pattern:     description,
+		RuleID:      ruleID,
+		Fingerprint: Fingerprint(value),
+		value:       value,
+	}
+}
+
+// Fingerprint identifies a secret value without storing it (sha256 hex)
+func Fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// maskSecret masks the sensitive part of a secret
+func maskSecret(secret string) string {
+	if len(secret) <= 10 {
+		return "***REDACTED***"
+	}
+
+	// Show first 5 and last 3 characters
+	prefix := secret[:5]
+	suffix := secret[len(secret)-3:]
+	return prefix + "***" + suffix
+}
+
+// isLetter reports whether r is an ASCII letter
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,150 @@
+package findings
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// lineTolerance is how many lines apart two findings in a file may point and still be the same
+	lineTolerance = 3
+	// locatedSimilarity is the message similarity two findings at the same lines need to be merged
+	locatedSimilarity = 0.2
+	// unlocatedSimilarity is the message similarity two findings without lines need to be merged
+	unlocatedSimilarity = 0.5
+)
+
+// stopWords are left out when comparing messages, since every review uses them
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "this": true, "that": true, "with": true, "not": true,
+	"are": true, "was": true, "can": true, "when": true, "which": true, "from": true, "into": true,
+	"should": true, "could": true, "would": true, "will": true, "its": true, "but": true, "there": true,
+}
+
+// ModelReport is the review one model gave in a consensus review
+type ModelReport struct {
+	Model  string
+	Report *Report
+}
+
+// group is a finding merged from the reports of several models
+type group struct {
+	finding Finding
+	models  []string
+	// words holds the message words of every merged finding
+	words []map[string]bool
+}
+
+// Merge consolidates the reviews several models gave of the same changes. Findings in the same
+// file, at nearby lines and with similar messages, are merged into one that lists the models that
+// reported it; its confidence is the share of models that did. A merged finding keeps the wording
+// of the first model and the highest severity any model gave it. Findings are ordered by severity,
+// then confidence.
+func Merge(reports []ModelReport) *Report {
+	merged := &Report{Findings: []Finding{}}
+	var groups []*group
+	for _, mr := range reports {
+		merged.Models = append(merged.Models, mr.Model)
+		for _, f := range mr.Report.Findings {
+			if g := bestGroup(groups, f, mr.Model); g != nil {
+				g.add(f, mr.Model)
+				continue
+			}
+			groups = append(groups, &group{finding: f, models: []string{mr.Model}, words: []map[string]bool{messageWords(f.Message)}})
+		}
+		for _, u := range mr.Report.Unparsed {
+			u.Heading = strings.TrimSuffix(mr.Model+": "+u.Heading, ": ")
+			merged.Unparsed = append(merged.Unparsed, u)
+		}
+		// The statuses of previous findings cannot be merged by agreement; the first model's are kept
+		if len(merged.Previous) == 0 {
+			merged.Previous = mr.Report.Previous
+		}
+	}
+
+	for _, g := range groups {
+		f := g.finding
+		f.Models = g.models
+		f.Confidence = math.Round(float64(len(g.models))/float64(len(reports))*100) / 100
+		merged.Findings = append(merged.Findings, f)
+	}
+	slices.SortStableFunc(merged.Findings, func(a, b Finding) int {
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return b.Severity.Rank() - a.Severity.Rank()
+		}
+		return len(b.Models) - len(a.Models)
+	})
+	return merged
+}
+
+// bestGroup returns the group f is most similar to among those model has not contributed to yet
+func bestGroup(groups []*group, f Finding, model string) *group {
+	words := messageWords(f.Message)
+	var best *group
+	bestScore := 0.0
+	for _, g := range groups {
+		if slices.Contains(g.models, model) || g.finding.File != f.File {
+			continue
+		}
+		threshold := unlocatedSimilarity
+		if g.finding.Line > 0 && f.Line > 0 {
+			if !linesOverlap(g.finding, f) {
+				continue
+			}
+			threshold = locatedSimilarity
+		}
+		score := lo.Max(lo.Map(g.words, func(w map[string]bool, _ int) float64 {
+			return similarity(w, words)
+		}))
+		if score >= threshold && score > bestScore {
+			best, bestScore = g, score
+		}
+	}
+	return best
+}
+
+// add merges the finding a model reported into the group
+func (g *group) add(f Finding, model string) {
+	g.models = append(g.models, model)
+	g.words = append(g.words, messageWords(f.Message))
+	if f.Severity.Rank() > g.finding.Severity.Rank() {
+		g.finding.Severity = f.Severity
+	}
+	g.finding.Category = lo.CoalesceOrEmpty(g.finding.Category, f.Category)
+	g.finding.Suggestion = lo.CoalesceOrEmpty(g.finding.Suggestion, f.Suggestion)
+}
+
+// linesOverlap returns true if the line ranges of a and b are at most lineTolerance lines apart
+func linesOverlap(a, b Finding) bool {
+	aEnd, bEnd := max(a.EndLine, a.Line), max(b.EndLine, b.Line)
+	return a.Line <= bEnd+lineTolerance && b.Line <= aEnd+lineTolerance
+}
+
+// messageWords returns the distinct lowercase words of a message, without short and stop words
+func messageWords(message string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len(w) > 2 && !stopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// similarity is the Jaccard index of two word sets
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := lo.CountBy(lo.Keys(a), func(w string) bool {
+		return b[w]
+	})
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,99 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	reports := []ModelReport{
+		{Model: "gpt-4o", Report: &Report{Findings: []Finding{
+			{Severity: SeverityWarning, Message: "auth/login.go:42 ignores the error returned by db.Query", File: "auth/login.go", Line: 42},
+			{Severity: SeverityRefactoring, Message: "Inline the temporary variable in handler.go:10", File: "handler.go", Line: 10},
+		}}},
+		{Model: "claude-sonnet", Report: &Report{
+			Findings: []Finding{
+				{Severity: SeverityCritical, Category: "logic", Message: "The db.Query error is ignored at auth/login.go:43", File: "auth/login.go", Line: 43, Suggestion: "if err != nil { return err }"},
+				{Severity: SeverityWarning, Message: "auth/login.go:90 logs the session cookie", File: "auth/login.go", Line: 90},
+			},
+			Unparsed: []UnparsedSection{{Content: "Overall looks fine."}},
+		}},
+		{Model: "gemini-2.5-pro", Report: &Report{Findings: []Finding{
+			{Severity: SeverityWarning, Message: "Error from db.Query is ignored (auth/login.go:41-44)", File: "auth/login.go", Line: 41, EndLine: 44},
+			{Severity: SeverityWarning, Message: "Error from db.Query is ignored again (auth/login.go:44)", File: "auth/login.go", Line: 44},
+		}}},
+	}
+
+	merged := Merge(reports)
+	assert.Equal(t, []string{"gpt-4o", "claude-sonnet", "gemini-2.5-pro"}, merged.Models)
+	require.Len(t, merged.Findings, 4)
+
+	agreed := merged.Findings[0]
+	assert.Equal(t, SeverityCritical, agreed.Severity, "the highest severity wins")
+	assert.Equal(t, "auth/login.go:42 ignores the error returned by db.Query", agreed.Message, "the first model's wording is kept")
+	assert.Equal(t, "logic", agreed.Category)
+	assert.Equal(t, "if err != nil { return err }", agreed.Suggestion)
+	assert.Equal(t, []string{"gpt-4o", "claude-sonnet", "gemini-2.5-pro"}, agreed.Models)
+	assert.InDelta(t, 1.0, agreed.Confidence, 0.001)
+
+	// A model's second finding at the same place is not merged into its first
+	assert.Equal(t, []string{"gemini-2.5-pro"}, merged.Findings[2].Models)
+	assert.Equal(t, "auth/login.go:90 logs the session cookie", merged.Findings[1].Message)
+	assert.InDelta(t, 0.33, merged.Findings[1].Confidence, 0.001)
+	assert.Equal(t, SeverityRefactoring, merged.Findings[3].Severity)
+
+	require.Len(t, merged.Unparsed, 1)
+	assert.Equal(t, "claude-sonnet", merged.Unparsed[0].Heading)
+}
+
+func TestMergeKeepsDistinctFindings(t *testing.T) {
+	t.Parallel()
+
+	merged := Merge([]ModelReport{
+		{Model: "a", Report: &Report{Findings: []Finding{
+			{Severity: SeverityWarning, Message: "main.go:10 leaks the file handle", File: "main.go", Line: 10},
+			{Severity: SeverityWarning, Message: "Missing tests for the retry logic"},
+		}}},
+		{Model: "b", Report: &Report{Findings: []Finding{
+			{Severity: SeverityWarning, Message: "main.go:30 leaks the file handle", File: "main.go", Line: 30},
+			{Severity: SeverityWarning, Message: "No tests cover the retry logic"},
+			{Severity: SeverityWarning, Message: "util.go:10 leaks the file handle", File: "util.go", Line: 10},
+		}}},
+	})
+
+	// Same message at distant lines or in another file stays apart; unlocated findings merge on wording
+	require.Len(t, merged.Findings, 4)
+	assert.Equal(t, "Missing tests for the retry logic", merged.Findings[0].Message)
+	assert.Equal(t, []string{"a", "b"}, merged.Findings[0].Models)
+	for _, f := range merged.Findings[1:] {
+		assert.Len(t, f.Models, 1, f.Message)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	merged := Merge([]ModelReport{
+		{Model: "a", Report: Parse("### 🔴 Critical\n- **Security**: SQL injection in db/query.go:42\n```\ndb.Query(q, id)\n```\n\n### 🟡 Refactoring\n- Rename x in util.go:3\n")},
+		{Model: "b", Report: Parse("### 🔴 Critical\n- SQL injection through the id parameter at db/query.go:42\n\n### Summary\n- not a finding\n")},
+	})
+
+	markdown := merged.Markdown()
+	assert.Contains(t, markdown, "Consensus of 2 models: a, b")
+	assert.Contains(t, markdown, "_2/2 models (a, b), confidence 100%_")
+
+	// Notes of one model are not read back as findings
+	report := Parse(markdown)
+	require.Len(t, report.Findings, 2)
+	require.Len(t, report.Unparsed, 2)
+	assert.Equal(t, SeverityCritical, report.Findings[0].Severity)
+	assert.Equal(t, "security", report.Findings[0].Category)
+	assert.Equal(t, "db/query.go", report.Findings[0].File)
+	assert.Equal(t, 42, report.Findings[0].Line)
+	assert.Equal(t, "db.Query(q, id)", report.Findings[0].Suggestion)
+	assert.Equal(t, SeverityRefactoring, report.Findings[1].Severity)
+}
@@ -0,0 +1,102 @@
+// Package findings turns free-form review markdown into structured findings
+// that can be exported as JSON or SARIF.
+package findings
+
+import "github.com/samber/lo"
+
+// Severity represents how serious a finding is
+type Severity string
+
+const (
+	SeverityCritical    Severity = "critical"
+	SeverityWarning     Severity = "warning"
+	SeverityRefactoring Severity = "refactoring"
+)
+
+// Rank returns the numeric weight of the severity (higher is more severe)
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityRefactoring:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Finding is a single issue reported by the reviewer
+type Finding struct {
+	// Severity comes from the section the finding was listed under
+	Severity Severity `json:"severity"`
+	// Category is the optional leading label (e.g. "security", "performance")
+	Category string `json:"category,omitempty"`
+	// File is the referenced path (empty if the finding has no location)
+	File string `json:"file,omitempty"`
+	// Line is the referenced line number (0 if unknown)
+	Line int `json:"line,omitempty"`
+	// EndLine is the end of a referenced line range (0 if not a range)
+	EndLine int `json:"end_line,omitempty"`
+	// Message is the finding text without markdown list markers
+	Message string `json:"message"`
+	// Suggestion is a suggested fix (code snippet), if one was provided
+	Suggestion string `json:"suggestion,omitempty"`
+	// Fingerprint identifies the finding across reviews (empty until AddFingerprints)
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Models lists the models that reported the finding (only in a consensus review)
+	Models []string `json:"models,omitempty"`
+	// Confidence is the share of models that reported the finding, 0-1 (only in a consensus review)
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// HasLocation returns true if the finding points at a file
+func (f Finding) HasLocation() bool {
+	return f.File != ""
+}
+
+// Status is what an incremental review decided about a previous finding
+type Status string
+
+const (
+	StatusResolved  Status = "resolved"
+	StatusOpen      Status = "open"
+	StatusRegressed Status = "regressed"
+)
+
+// PreviousFinding is a finding of the last review, re-checked by an incremental review
+type PreviousFinding struct {
+	Status Status `json:"status"`
+	// File is the referenced path (empty if the finding has no location)
+	File string `json:"file,omitempty"`
+	// Line is the referenced line number (0 if unknown)
+	Line int `json:"line,omitempty"`
+	// Message is the finding text without the status label
+	Message string `json:"message"`
+}
+
+// UnparsedSection is review content that could not be mapped to findings
+type UnparsedSection struct {
+	// Heading is the section heading (empty for text before the first heading)
+	Heading string `json:"heading,omitempty"`
+	// Content is the raw markdown content
+	Content string `json:"content"`
+}
+
+// Report is the structured form of a review
+type Report struct {
+	// Models lists the models whose reviews were merged (only in a consensus review)
+	Models   []string  `json:"models,omitempty"`
+	Findings []Finding `json:"findings"`
+	// Previous holds the statuses an incremental review gave the last review's findings
+	Previous []PreviousFinding `json:"previous,omitempty"`
+	Unparsed []UnparsedSection `json:"unparsed,omitempty"`
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	return lo.CountBy(r.Findings, func(f Finding) bool {
+		return f.Severity == severity
+	})
+}
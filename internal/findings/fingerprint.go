@@ -0,0 +1,59 @@
+package findings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// fingerprintLength is how many hex characters of the sha256 a fingerprint keeps
+const fingerprintLength = 16
+
+// Fingerprint identifies a finding across reviews by its file, rule and the code it points at.
+// The code is whitespace-normalized, so moved or reindented lines keep their fingerprint;
+// findings without a resolvable location fall back to the normalized message.
+func Fingerprint(f Finding, source string) string {
+	anchor := snippet(source, f.Line, f.EndLine)
+	if anchor == "" {
+		anchor = strings.ToLower(normalizeSpace(f.Message))
+	}
+	sum := sha256.Sum256([]byte(f.File + "\x00" + ruleID(f) + "\x00" + anchor))
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
+
+// AddFingerprints fingerprints every finding; sources maps file paths to the reviewed contents
+func (r *Report) AddFingerprints(sources map[string]string) {
+	for i := range r.Findings {
+		r.Findings[i].Fingerprint = Fingerprint(r.Findings[i], sources[r.Findings[i].File])
+	}
+}
+
+// Exclude removes the findings whose fingerprint is in fingerprints and returns how many were removed
+func (r *Report) Exclude(fingerprints map[string]bool) int {
+	kept := lo.Reject(r.Findings, func(f Finding, _ int) bool {
+		return fingerprints[f.Fingerprint]
+	})
+	removed := len(r.Findings) - len(kept)
+	r.Findings = kept
+	return removed
+}
+
+// snippet returns the whitespace-normalized lines start..end of source (empty if out of range)
+func snippet(source string, start, end int) string {
+	if source == "" || start <= 0 {
+		return ""
+	}
+	lines := strings.Split(source, "\n")
+	end = min(max(end, start), len(lines))
+	if start > end {
+		return ""
+	}
+	return normalizeSpace(strings.Join(lines[start-1:end], "\n"))
+}
+
+// normalizeSpace collapses every run of whitespace into a single space
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
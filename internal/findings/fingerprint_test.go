@@ -0,0 +1,47 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	finding := Finding{Severity: SeverityWarning, Category: "errors", File: "cmd/main.go", Line: 2, Message: "Error ignored"}
+	source := "package main\n\t_ = run()\n"
+
+	// The same code keeps its fingerprint when it moves, is reindented or is described differently
+	moved := finding
+	moved.Line = 3
+	moved.Message = "The error from run is discarded"
+	require.Equal(t, Fingerprint(finding, source), Fingerprint(moved, "package main\n\n    _ =   run()\n"))
+
+	// Another rule or file is another finding
+	other := finding
+	other.Category = "style"
+	require.NotEqual(t, Fingerprint(finding, source), Fingerprint(other, source))
+	other = finding
+	other.File = "cmd/other.go"
+	require.NotEqual(t, Fingerprint(finding, source), Fingerprint(other, source))
+
+	// Without the code, the message decides
+	require.Equal(t, Fingerprint(finding, ""), Fingerprint(Finding{Severity: SeverityWarning, Category: "errors", File: "cmd/main.go", Message: "error  ignored"}, ""))
+	require.Equal(t, Fingerprint(finding, ""), Fingerprint(Finding{Severity: SeverityWarning, Category: "errors", File: "cmd/main.go", Line: 40, Message: "Error ignored"}, source))
+}
+
+func TestExclude(t *testing.T) {
+	t.Parallel()
+
+	report := Parse(sampleReview)
+	report.AddFingerprints(map[string]string{"cmd/main.go": "package main\n\nfunc main() {\n\t_ = run()\n}\n"})
+	require.Len(t, report.Findings, 3)
+	for _, f := range report.Findings {
+		require.Len(t, f.Fingerprint, fingerprintLength)
+	}
+
+	require.Equal(t, 1, report.Exclude(map[string]bool{report.Findings[2].Fingerprint: true}))
+	require.Len(t, report.Findings, 2)
+	require.Zero(t, report.Count(SeverityWarning))
+}
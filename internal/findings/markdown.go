@@ -0,0 +1,86 @@
+package findings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// severityHeadings are the section headings of the review response format
+var severityHeadings = []struct {
+	severity Severity
+	heading  string
+}{
+	{SeverityCritical, "### 🔴 Critical (Must Fix)"},
+	{SeverityWarning, "### 🟠 Warnings"},
+	{SeverityRefactoring, "### 🟡 Refactoring"},
+}
+
+// previousLabels are the labels previous findings are listed with
+var previousLabels = map[Status]string{
+	StatusResolved:  "Resolved",
+	StatusOpen:      "Still open",
+	StatusRegressed: "Regressed",
+}
+
+// Markdown renders the report in the review response format, so that Parse reads it back.
+// Findings of a consensus review are annotated with the models that reported them.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+	if len(r.Models) > 0 {
+		fmt.Fprintf(&sb, "Consensus of %d models: %s\n\n", len(r.Models), strings.Join(r.Models, ", "))
+	}
+
+	if len(r.Previous) > 0 {
+		sb.WriteString("### 🔁 Previous Findings\n\n")
+		for _, p := range r.Previous {
+			fmt.Fprintf(&sb, "- **%s**: %s\n", previousLabels[p.Status], p.Message)
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, s := range severityHeadings {
+		list := r.bySeverity(s.severity)
+		if len(list) == 0 {
+			continue
+		}
+		sb.WriteString(s.heading + "\n\n")
+		for _, f := range list {
+			sb.WriteString("- ")
+			if f.Category != "" {
+				fmt.Fprintf(&sb, "**%s**: ", f.Category)
+			}
+			sb.WriteString(f.Message + "\n")
+			if len(f.Models) > 0 {
+				fmt.Fprintf(&sb, "  _%d/%d models (%s), confidence %.0f%%_\n", len(f.Models), len(r.Models), strings.Join(f.Models, ", "), f.Confidence*100)
+			}
+			if f.Suggestion != "" {
+				fmt.Fprintf(&sb, "```\n%s\n```\n", f.Suggestion)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if len(r.Findings) == 0 {
+		sb.WriteString("No issues found.\n\n")
+	}
+
+	if len(r.Unparsed) > 0 {
+		// A heading of its own keeps lists in these notes from being read as findings
+		sb.WriteString("### Other Notes\n\n")
+	}
+	for _, u := range r.Unparsed {
+		if u.Heading != "" {
+			fmt.Fprintf(&sb, "#### %s\n\n", u.Heading)
+		}
+		sb.WriteString(u.Content + "\n\n")
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// bySeverity returns the findings with the given severity
+func (r *Report) bySeverity(severity Severity) []Finding {
+	return lo.Filter(r.Findings, func(f Finding, _ int) bool {
+		return f.Severity == severity
+	})
+}
@@ -0,0 +1,57 @@
+package findings
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytedance/sonic"
+)
+
+// Format is an output format for review results
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+	FormatSARIF    Format = "sarif"
+)
+
+// Formats lists all supported output formats
+var Formats = []Format{FormatMarkdown, FormatJSON, FormatSARIF}
+
+// ParseFormat validates an output format name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format %q (supported: markdown, json, sarif)", name)
+}
+
+// IsStructured returns true if the format requires parsing the review into findings
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatSARIF
+}
+
+// Write encodes the report in the given structured format
+func (r *Report) Write(w io.Writer, format Format, toolVersion string) error {
+	var v any
+	switch format {
+	case FormatJSON:
+		v = r
+	case FormatSARIF:
+		v = r.toSARIF(toolVersion)
+	default:
+		return fmt.Errorf("format %q is not a structured format", format)
+	}
+
+	data, err := sonic.ConfigStd.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s report: %w", format, err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s report: %w", format, err)
+	}
+	return nil
+}
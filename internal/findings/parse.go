@@ -0,0 +1,280 @@
+package findings
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// suggestionsHeading is used for code suggestions that could not be attached to a finding
+const suggestionsHeading = "Code Suggestions"
+
+// previousStatuses maps the labels of previous findings to their status
+var previousStatuses = map[string]Status{
+	"resolved":   StatusResolved,
+	"fixed":      StatusResolved,
+	"open":       StatusOpen,
+	"still open": StatusOpen,
+	"regressed":  StatusRegressed,
+}
+
+// sectionKind classifies a top-level heading of the review response format
+type sectionKind int
+
+const (
+	sectionUnknown sectionKind = iota
+	sectionSeverity
+	sectionSuggestions
+	sectionPrevious
+)
+
+var (
+	// headingPattern matches section headings (levels 1-3; deeper headings are content)
+	headingPattern = regexp.MustCompile(`^\s{0,3}#{1,3}\s+(.*?)\s*#*\s*$`)
+	// bulletPattern matches a top-level list item ("- ", "* ", "+ ", "1. ", "1) ")
+	bulletPattern = regexp.MustCompile(`^\s?(?:[-*+]|\d+[.)])\s+(.*)$`)
+	// locationPattern matches clickable references like path/to/file.go:42 or file.go:10-20
+	locationPattern = regexp.MustCompile(`([\w./\-]+\.[A-Za-z0-9]+):(\d+)(?:-(\d+))?`)
+	// categoryPattern matches a short leading label like "**Security**:" or "Logic error:"
+	categoryPattern = regexp.MustCompile(`^\**([A-Za-z][A-Za-z /\-]{1,28}?)\**\s*:\**\s+`)
+)
+
+// section is a heading together with the lines that belong to it
+type section struct {
+	heading  string
+	kind     sectionKind
+	severity Severity
+	lines    []string
+}
+
+// block is a list item (or the prose before the first list item) in a section
+type block struct {
+	text   []string
+	code   []string
+	inList bool
+}
+
+// Parse converts review markdown into a structured report.
+// It is tolerant of partial output: anything that cannot be mapped to a
+// finding is reported in Report.Unparsed instead of being dropped.
+func Parse(markdown string) *Report {
+	report := &Report{Findings: []Finding{}}
+
+	var suggestions []block
+	for _, sec := range splitSections(markdown) {
+		switch sec.kind {
+		case sectionSeverity:
+			parseSeveritySection(report, sec)
+		case sectionSuggestions:
+			suggestions = append(suggestions, splitBlocks(sec.lines)...)
+		case sectionPrevious:
+			parsePreviousSection(report, sec)
+		default:
+			addUnparsed(report, sec.heading, strings.Join(sec.lines, "\n"))
+		}
+	}
+
+	attachSuggestions(report, suggestions)
+	return report
+}
+
+// splitSections splits markdown into sections at level 1-3 headings, ignoring headings inside code fences
+func splitSections(markdown string) []section {
+	current := section{kind: sectionUnknown}
+	var sections []section
+	inFence := false
+
+	for _, line := range strings.Split(markdown, "\n") {
+		if isFence(line) {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := headingPattern.FindStringSubmatch(line); m != nil {
+				sections = append(sections, current)
+				current = newSection(m[1])
+				continue
+			}
+		}
+		current.lines = append(current.lines, line)
+	}
+
+	return append(sections, current)
+}
+
+// newSection classifies a heading into a section kind and severity
+func newSection(heading string) section {
+	sec := section{heading: heading, kind: sectionSeverity}
+	lower := strings.ToLower(heading)
+	switch {
+	// Checked first: previous findings may be labeled with their severity
+	case strings.Contains(heading, "🔁") || strings.Contains(lower, "previous findings"):
+		sec.kind = sectionPrevious
+	case strings.Contains(heading, "🔴") || strings.Contains(lower, "critical"):
+		sec.severity = SeverityCritical
+	case strings.Contains(heading, "🟠") || strings.Contains(lower, "warning"):
+		sec.severity = SeverityWarning
+	case strings.Contains(heading, "🟡") || strings.Contains(lower, "refactor"):
+		sec.severity = SeverityRefactoring
+	case strings.Contains(heading, "💡") || strings.Contains(lower, "suggestion"):
+		sec.kind = sectionSuggestions
+	default:
+		sec.kind = sectionUnknown
+	}
+	return sec
+}
+
+// parseSeveritySection turns each list item of a severity section into a finding
+func parseSeveritySection(report *Report, sec section) {
+	for _, b := range splitBlocks(sec.lines) {
+		if !b.inList {
+			addUnparsed(report, sec.heading, b.markdown())
+			continue
+		}
+		message := strings.TrimSpace(strings.Join(b.text, "\n"))
+		if message == "" {
+			continue
+		}
+		f := Finding{
+			Severity:   sec.severity,
+			Suggestion: strings.TrimSpace(strings.Join(b.code, "\n")),
+		}
+		f.Category, message = extractCategory(message)
+		f.File, f.Line, f.EndLine = extractLocation(message)
+		f.Message = message
+		report.Findings = append(report.Findings, f)
+	}
+}
+
+// parsePreviousSection reads the "Status: finding" items of an incremental review's previous findings
+func parsePreviousSection(report *Report, sec section) {
+	for _, b := range splitBlocks(sec.lines) {
+		label, message := extractCategory(strings.TrimSpace(strings.Join(b.text, "\n")))
+		status, ok := previousStatuses[label]
+		if !b.inList || !ok {
+			addUnparsed(report, sec.heading, b.markdown())
+			continue
+		}
+		f := PreviousFinding{Status: status, Message: message}
+		f.File, f.Line, _ = extractLocation(message)
+		report.Previous = append(report.Previous, f)
+	}
+}
+
+// splitBlocks groups section lines into list items; code fences stay with the item they follow
+func splitBlocks(lines []string) []block {
+	var blocks []block
+	current := block{}
+	inFence := false
+
+	for _, line := range lines {
+		if isFence(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			current.code = append(current.code, line)
+			continue
+		}
+		if m := bulletPattern.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, current)
+			current = block{text: []string{m[1]}, inList: true}
+			continue
+		}
+		current.text = append(current.text, strings.TrimSpace(line))
+	}
+	blocks = append(blocks, current)
+
+	// Drop empty blocks (e.g. the implicit block before the first list item)
+	return lo.Filter(blocks, func(b block, _ int) bool {
+		return strings.TrimSpace(strings.Join(b.text, "")) != "" || len(b.code) > 0
+	})
+}
+
+// markdown renders the block back to markdown (prose followed by its code)
+func (b block) markdown() string {
+	text := strings.TrimSpace(strings.Join(b.text, "\n"))
+	if len(b.code) == 0 {
+		return text
+	}
+	return text + "\n```\n" + strings.Join(b.code, "\n") + "\n```"
+}
+
+// attachSuggestions attaches code suggestions to the findings they reference
+func attachSuggestions(report *Report, suggestions []block) {
+	for _, s := range suggestions {
+		code := strings.TrimSpace(strings.Join(s.code, "\n"))
+		file, line, _ := extractLocation(strings.Join(s.text, "\n"))
+		idx := matchFinding(report.Findings, file, line)
+		if code == "" || idx < 0 {
+			addUnparsed(report, suggestionsHeading, s.markdown())
+			continue
+		}
+		if report.Findings[idx].Suggestion != "" {
+			report.Findings[idx].Suggestion += "\n\n"
+		}
+		report.Findings[idx].Suggestion += code
+	}
+}
+
+// matchFinding finds the finding a suggestion refers to: exact line first, then the
+// first finding in the same file without a suggestion. Returns -1 if none match.
+func matchFinding(findings []Finding, file string, line int) int {
+	if file == "" {
+		return -1
+	}
+	for i, f := range findings {
+		if f.File == file && line > 0 && (f.Line == line || (f.EndLine > 0 && line >= f.Line && line <= f.EndLine)) {
+			return i
+		}
+	}
+	for i, f := range findings {
+		if f.File == file && f.Suggestion == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// extractLocation returns the first file:line reference in text
+func extractLocation(text string) (string, int, int) {
+	for _, m := range locationPattern.FindAllStringSubmatch(text, -1) {
+		// Skip URLs such as https://example.com:443
+		if strings.HasPrefix(m[1], "//") {
+			continue
+		}
+		line, _ := strconv.Atoi(m[2])
+		endLine := 0
+		if m[3] != "" {
+			endLine, _ = strconv.Atoi(m[3])
+		}
+		return strings.TrimPrefix(m[1], "./"), line, endLine
+	}
+	return "", 0, 0
+}
+
+// extractCategory splits a leading "Category:" label from the message
+func extractCategory(message string) (string, string) {
+	m := categoryPattern.FindStringSubmatchIndex(message)
+	if m == nil {
+		return "", message
+	}
+	category := strings.ToLower(strings.TrimSpace(message[m[2]:m[3]]))
+	return category, strings.TrimSpace(message[m[1]:])
+}
+
+// addUnparsed records non-empty content that could not be mapped to a finding
+func addUnparsed(report *Report, heading, content string) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return
+	}
+	report.Unparsed = append(report.Unparsed, UnparsedSection{Heading: heading, Content: content})
+}
+
+// isFence returns true if the line opens or closes a fenced code block
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
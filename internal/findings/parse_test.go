@@ -0,0 +1,106 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const sampleReview = `Overall the change looks good.
+
+## 🔴 Critical Issues
+- **Security**: SQL built from user input in ` + "`internal/db/query.go:42`" + `
+- Nil map write in internal/cache/store.go:10-14
+
+## 🟠 Warnings
+1. Error ignored at cmd/main.go:7
+
+## 💡 Code Suggestions
+For internal/db/query.go:42:
+` + "```go" + `
+db.Query("SELECT * FROM t WHERE id = ?", id)
+` + "```" + `
+
+## ❓ Questions
+Why is the retry limit 3?
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	report := Parse(sampleReview)
+	require.Len(t, report.Findings, 3)
+
+	first := report.Findings[0]
+	require.Equal(t, SeverityCritical, first.Severity)
+	require.Equal(t, "security", first.Category)
+	require.Equal(t, "internal/db/query.go", first.File)
+	require.Equal(t, 42, first.Line)
+	require.Contains(t, first.Suggestion, "db.Query")
+
+	second := report.Findings[1]
+	require.Empty(t, second.Category)
+	require.Equal(t, "internal/cache/store.go", second.File)
+	require.Equal(t, 10, second.Line)
+	require.Equal(t, 14, second.EndLine)
+
+	require.Equal(t, SeverityWarning, report.Findings[2].Severity)
+	require.Equal(t, 1, report.Count(SeverityWarning))
+
+	// Preamble and questions are kept as unparsed sections
+	require.Len(t, report.Unparsed, 2)
+	require.Empty(t, report.Unparsed[0].Heading)
+	require.Contains(t, report.Unparsed[1].Content, "retry limit")
+}
+
+func TestParsePrevious(t *testing.T) {
+	t.Parallel()
+
+	report := Parse(`## 🔁 Previous Findings
+- **Resolved**: SQL built from user input in internal/db/query.go:42
+- **Still open**: Error ignored at cmd/main.go:7
+- **Regressed**: Nil map write in internal/cache/store.go:10
+- Could not tell for the rest
+
+## 🟠 Warnings
+- Error ignored at cmd/main.go:7
+`)
+	require.Len(t, report.Previous, 3)
+	require.Equal(t, StatusResolved, report.Previous[0].Status)
+	require.Equal(t, "internal/db/query.go", report.Previous[0].File)
+	require.Equal(t, 42, report.Previous[0].Line)
+	require.Equal(t, StatusOpen, report.Previous[1].Status)
+	require.Equal(t, StatusRegressed, report.Previous[2].Status)
+	require.Len(t, report.Unparsed, 1)
+
+	// Open findings are repeated in their severity section, so they still count
+	require.Len(t, report.Findings, 1)
+	require.Equal(t, "cmd/main.go", report.Findings[0].File)
+}
+
+func TestParseTolerant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("truncated response", func(t *testing.T) {
+		t.Parallel()
+		report := Parse("## 🔴 Critical Issues\n- Race in worker.go:3\n```go\nmu.Lock(")
+		require.Len(t, report.Findings, 1)
+		require.Equal(t, "worker.go", report.Findings[0].File)
+		require.Equal(t, "mu.Lock(", report.Findings[0].Suggestion)
+	})
+
+	t.Run("unmatched suggestion", func(t *testing.T) {
+		t.Parallel()
+		report := Parse("## 💡 Code Suggestions\n```go\nfoo()\n```")
+		require.Empty(t, report.Findings)
+		require.Len(t, report.Unparsed, 1)
+		require.Equal(t, suggestionsHeading, report.Unparsed[0].Heading)
+	})
+
+	t.Run("url is not a location", func(t *testing.T) {
+		t.Parallel()
+		file, line, _ := extractLocation("see https://example.com:443 for details")
+		require.Empty(t, file)
+		require.Zero(t, line)
+	})
+}
@@ -0,0 +1,166 @@
+package findings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// SARIF constants (https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html)
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "revcli"
+	sarifToolURI  = "https://github.com/trankhanh040147/revcli"
+	// sarifFingerprintKey names the finding fingerprint in partialFingerprints
+	sarifFingerprintKey = "revcliFingerprint/v1"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string           `json:"ruleId"`
+	Level      string           `json:"level"`
+	Message    sarifMessage     `json:"message"`
+	Locations  []sarifLocation  `json:"locations,omitempty"`
+	Properties *sarifProperties `json:"properties,omitempty"`
+	// PartialFingerprints lets code scanning track the finding across runs
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// sarifProperties carries the suggested code and, in a consensus review, the models that agree.
+// The suggestion is not a SARIF fix: fixes require artifactChanges with exact replacement ranges,
+// which the model does not produce.
+type sarifProperties struct {
+	Suggestion string   `json:"suggestion,omitempty"`
+	Models     []string `json:"models,omitempty"`
+	Confidence float64  `json:"confidence,omitempty"`
+}
+
+// toSARIF converts the report into a SARIF 2.1.0 log
+func (r *Report) toSARIF(toolVersion string) sarifLog {
+	results := lo.Map(r.Findings, func(f Finding, _ int) sarifResult {
+		return toSARIFResult(f)
+	})
+
+	ruleIDs := lo.Uniq(lo.Map(results, func(res sarifResult, _ int) string {
+		return res.RuleID
+	}))
+	rules := lo.Map(ruleIDs, func(id string, _ int) sarifRule {
+		return sarifRule{ID: id, ShortDescription: sarifMessage{Text: ruleDescription(id)}}
+	})
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sarifToolName,
+				Version:        toolVersion,
+				InformationURI: sarifToolURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// toSARIFResult converts a single finding into a SARIF result
+func toSARIFResult(f Finding) sarifResult {
+	res := sarifResult{
+		RuleID:  ruleID(f),
+		Level:   sarifLevel(f.Severity),
+		Message: sarifMessage{Text: f.Message},
+	}
+	if f.HasLocation() {
+		loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: f.File},
+		}}
+		if f.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line, EndLine: f.EndLine}
+		}
+		res.Locations = []sarifLocation{loc}
+	}
+	if f.Suggestion != "" || len(f.Models) > 0 {
+		res.Properties = &sarifProperties{Suggestion: f.Suggestion, Models: f.Models, Confidence: f.Confidence}
+	}
+	if f.Fingerprint != "" {
+		res.PartialFingerprints = map[string]string{sarifFingerprintKey: f.Fingerprint}
+	}
+	return res
+}
+
+// ruleID derives a stable rule id from the finding category (or severity if uncategorized)
+func ruleID(f Finding) string {
+	name := f.Category
+	if name == "" {
+		name = string(f.Severity)
+	}
+	return sarifToolName + "/" + strings.ReplaceAll(strings.ToLower(name), " ", "-")
+}
+
+// ruleDescription returns a human readable description for a rule id
+func ruleDescription(id string) string {
+	name := strings.ReplaceAll(strings.TrimPrefix(id, sarifToolName+"/"), "-", " ")
+	return fmt.Sprintf("Code review finding: %s", name)
+}
+
+// sarifLevel maps a severity onto a SARIF result level
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityCritical:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
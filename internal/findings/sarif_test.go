@@ -0,0 +1,53 @@
+package findings
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	report := &Report{Findings: []Finding{
+		{Severity: SeverityCritical, Category: "Security", Message: "SQL injection", File: "db/query.go", Line: 42, Suggestion: "db.Query(q, id)", Fingerprint: "0123456789abcdef"},
+		{Severity: SeverityRefactoring, Message: "Rename x"},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf, FormatSARIF, "1.0.0"))
+
+	var log map[string]any
+	require.NoError(t, sonic.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, sarifVersion, log["version"])
+	require.NotEmpty(t, log["$schema"])
+
+	runs := log["runs"].([]any)
+	require.Len(t, runs, 1)
+	run := runs[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	require.Equal(t, sarifToolName, driver["name"])
+
+	results := run["results"].([]any)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		result := r.(map[string]any)
+		require.NotEmpty(t, result["ruleId"])
+		require.NotEmpty(t, result["message"].(map[string]any)["text"])
+		// Every fix needs at least one artifactChange, so none are emitted
+		require.NotContains(t, result, "fixes")
+	}
+
+	first := results[0].(map[string]any)
+	location := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(t, "db/query.go", location["artifactLocation"].(map[string]any)["uri"])
+	require.EqualValues(t, 42, location["region"].(map[string]any)["startLine"])
+	require.Equal(t, "db.Query(q, id)", first["properties"].(map[string]any)["suggestion"])
+	require.Equal(t, "0123456789abcdef", first["partialFingerprints"].(map[string]any)[sarifFingerprintKey])
+
+	second := results[1].(map[string]any)
+	require.NotContains(t, second, "locations")
+	require.NotContains(t, second, "properties")
+	require.NotContains(t, second, "partialFingerprints")
+}
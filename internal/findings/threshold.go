@@ -0,0 +1,51 @@
+package findings
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// Threshold is the minimum severity that fails a review (used for CI gating)
+type Threshold string
+
+const (
+	ThresholdNone     Threshold = ""
+	ThresholdCritical Threshold = "critical"
+	ThresholdWarning  Threshold = "warning"
+	ThresholdAny      Threshold = "any"
+)
+
+// ParseThreshold validates a --fail-on value (empty disables gating)
+func ParseThreshold(name string) (Threshold, error) {
+	switch t := Threshold(name); t {
+	case ThresholdNone, ThresholdCritical, ThresholdWarning, ThresholdAny:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unsupported fail-on threshold %q (supported: critical, warning, any)", name)
+	}
+}
+
+// minRank returns the lowest severity rank that hits the threshold
+func (t Threshold) minRank() int {
+	switch t {
+	case ThresholdCritical:
+		return SeverityCritical.Rank()
+	case ThresholdWarning:
+		return SeverityWarning.Rank()
+	case ThresholdAny:
+		return SeverityRefactoring.Rank()
+	default:
+		return 0
+	}
+}
+
+// Hits returns the findings at or above the threshold
+func (r *Report) Hits(t Threshold) []Finding {
+	if t == ThresholdNone {
+		return nil
+	}
+	return lo.Filter(r.Findings, func(f Finding, _ int) bool {
+		return f.Severity.Rank() >= t.minRank()
+	})
+}
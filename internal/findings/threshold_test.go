@@ -0,0 +1,24 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportHits(t *testing.T) {
+	t.Parallel()
+
+	report := &Report{Findings: []Finding{
+		{Severity: SeverityWarning, Message: "w"},
+		{Severity: SeverityRefactoring, Message: "r"},
+	}}
+
+	require.Empty(t, report.Hits(ThresholdNone))
+	require.Empty(t, report.Hits(ThresholdCritical))
+	require.Len(t, report.Hits(ThresholdWarning), 1)
+	require.Len(t, report.Hits(ThresholdAny), 2)
+
+	_, err := ParseThreshold("major")
+	require.Error(t, err)
+}
@@ -1,13 +1,14 @@
 package git
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
 )
 
 // DiffResult contains the extracted diff and file contents
@@ -16,55 +17,86 @@ type DiffResult struct {
 	RawDiff string
 	// ModifiedFiles maps file paths to their full content
 	ModifiedFiles map[string]string
+	// Files is the parsed diff, one entry per changed file
+	Files []*diff.FileDiff
 	// FilePaths is a list of all modified file paths
 	FilePaths []string
+	// UntrackedFiles lists new files included that are not yet known to git
+	UntrackedFiles []string
 }
 
-// GetDiff extracts the git diff and reads modified file contents
-// If baseBranch is provided, compares against that branch/commit
-// If staged is true, compares staged changes against HEAD
-// Otherwise, compares working directory against HEAD
-func GetDiff(staged bool, baseBranch string) (*DiffResult, error) {
+// GetDiff extracts the git diff selected by source and reads modified file contents.
+// File contents come from the reviewed revision (or the index for staged changes),
+// and from the working tree only when reviewing uncommitted changes.
+func GetDiff(source DiffSource) (*DiffResult, error) {
 	// Check if we're in a git repository
 	if err := checkGitRepo(); err != nil {
 		return nil, err
 	}
 
-	// Validate base branch if provided
-	if baseBranch != "" {
-		if err := validateRef(baseBranch); err != nil {
-			return nil, fmt.Errorf("invalid base reference '%s': %w", baseBranch, err)
-		}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	args, contentRev, err := source.diffArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	rootDir, err := getGitRoot()
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the raw diff
-	rawDiff, err := getRawDiff(staged, baseBranch)
+	rawDiff, err := getRawDiff(args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git diff: %w", err)
 	}
 
+	// git diff does not show untracked files, so add them as new-file diffs
+	var untracked []string
+	if source.IsWorkingTree() && source.Untracked {
+		if untracked, err = listUntracked(rootDir); err != nil {
+			return nil, err
+		}
+		newFiles, err := untrackedDiff(rootDir, untracked)
+		if err != nil {
+			return nil, err
+		}
+		rawDiff += newFiles
+	}
+
 	if rawDiff == "" {
-		return nil, fmt.Errorf("no changes detected. Make sure you have uncommitted changes")
+		if source.IsWorkingTree() {
+			return nil, fmt.Errorf("no changes detected. Make sure you have uncommitted changes")
+		}
+		return nil, fmt.Errorf("no changes detected in the selected revisions")
 	}
 
-	// Parse file paths from the diff
-	filePaths := parseFilePaths(rawDiff)
+	files, err := diff.Parse(rawDiff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse git diff: %w", err)
+	}
 
-	// Read file contents for modified files
+	// Read file contents for modified files; deleted and binary files only appear in the diff
 	modifiedFiles := make(map[string]string)
-	for _, path := range filePaths {
-		content, err := readFileContent(path)
+	for _, f := range files {
+		if f.Status == diff.StatusDeleted || f.Binary {
+			continue
+		}
+		content, err := readFileContent(rootDir, contentRev, f.Path())
 		if err != nil {
-			// File might have been deleted, skip it
 			continue
 		}
-		modifiedFiles[path] = content
+		modifiedFiles[f.Path()] = content
 	}
 
 	return &DiffResult{
-		RawDiff:       rawDiff,
-		ModifiedFiles: modifiedFiles,
-		FilePaths:     filePaths,
+		RawDiff:        rawDiff,
+		Files:          files,
+		ModifiedFiles:  modifiedFiles,
+		FilePaths:      diff.Paths(files),
+		UntrackedFiles: untracked,
 	}, nil
 }
 
@@ -77,31 +109,8 @@ func checkGitRepo() error {
 	return nil
 }
 
-// validateRef checks if a git reference (branch/commit) exists
-func validateRef(ref string) error {
-	cmd := exec.Command("git", "rev-parse", "--verify", ref)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("reference not found")
-	}
-	return nil
-}
-
-// getRawDiff runs git diff and returns the output
-// Supports three modes:
-// 1. baseBranch provided: git diff <base>...HEAD (MR-style, changes since branching)
-// 2. staged: git diff --staged (staged changes vs HEAD)
-// 3. default: git diff (working directory vs HEAD)
-func getRawDiff(staged bool, baseBranch string) (string, error) {
-	args := []string{"diff"}
-
-	if baseBranch != "" {
-		// MR-style diff: show changes between merge-base and HEAD
-		// This shows what would be merged, not just the tip difference
-		args = append(args, baseBranch+"...HEAD")
-	} else if staged {
-		args = append(args, "--staged")
-	}
-
+// getRawDiff runs git diff with the source's arguments and returns the output
+func getRawDiff(args []string) (string, error) {
 	// Add unified diff format for better context
 	args = append(args, "-U3")
 
@@ -117,41 +126,14 @@ func getRawDiff(staged bool, baseBranch string) (string, error) {
 	return stdout.String(), nil
 }
 
-// parseFilePaths extracts file paths from the git diff output
-func parseFilePaths(diff string) []string {
-	var paths []string
-	seen := make(map[string]bool)
-
-	scanner := bufio.NewScanner(strings.NewReader(diff))
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Look for lines like "diff --git a/path/to/file b/path/to/file"
-		if strings.HasPrefix(line, "diff --git") {
-			parts := strings.Split(line, " ")
-			if len(parts) >= 4 {
-				// Extract the "b/path" part and remove the "b/" prefix
-				bPath := parts[3]
-				if strings.HasPrefix(bPath, "b/") {
-					path := strings.TrimPrefix(bPath, "b/")
-					if !seen[path] {
-						paths = append(paths, path)
-						seen[path] = true
-					}
-				}
-			}
+// readFileContent reads the full content of a file at rev, or from the working tree if rev is empty
+func readFileContent(rootDir, rev, path string) (string, error) {
+	if rev != "" {
+		content, err := showFile(rootDir, rev, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s at %s: %w", path, rev, err)
 		}
-	}
-
-	return paths
-}
-
-// readFileContent reads the full content of a file
-func readFileContent(path string) (string, error) {
-	// Get the git root directory
-	rootDir, err := getGitRoot()
-	if err != nil {
-		return "", err
+		return content, nil
 	}
 
 	fullPath := filepath.Join(rootDir, path)
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveRef returns the full commit SHA of a reference
+func ResolveRef(ref string) (string, error) {
+	return runGit("rev-parse", "--verify", ref+"^{commit}")
+}
+
+// MergeBase returns the best common ancestor of two references
+func MergeBase(a, b string) (string, error) {
+	return runGit("merge-base", a, b)
+}
+
+// IsAncestor reports whether ancestor is reachable from commit
+func IsAncestor(ancestor, commit string) (bool, error) {
+	err := exec.Command("git", "merge-base", "--is-ancestor", ancestor, commit).Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return false, nil
+	default:
+		return false, fmt.Errorf("git merge-base failed: %w", err)
+	}
+}
+
+// CurrentBranch returns the checked out branch, or "" when HEAD is detached
+func CurrentBranch() (string, error) {
+	branch, err := runGit("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		return "", err
+	}
+	return branch, nil
+}
+
+// IndexTree writes the index as a tree object and returns its SHA, which identifies the staged content
+func IndexTree() (string, error) {
+	return runGit("write-tree")
+}
+
+// HooksDir returns the absolute path of the repository's hooks directory, honoring core.hooksPath
+func HooksDir() (string, error) {
+	dir, err := runGit("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
+
+// runGit runs a git command and returns its trimmed stdout
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,221 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// emptyTreeSHA is git's well-known empty tree, used as the parent of root commits
+const emptyTreeSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// indexRev reads file contents from the index (staged versions)
+const indexRev = ":"
+
+// DiffSource selects which changes are reviewed. At most one of Staged, Base, Commit,
+// Range, Last and Stash may be set; if none is, the working tree is reviewed against HEAD.
+type DiffSource struct {
+	// Staged reviews staged changes against HEAD
+	Staged bool
+	// Base reviews <Base>...<Head> (changes since Head branched from Base)
+	Base string
+	// Head is the tip compared with Base (default: HEAD); it need not be checked out
+	Head string
+	// Commit reviews the changes introduced by a single commit
+	Commit string
+	// Range reviews an explicit range, "A..B" (tip difference) or "A...B" (since merge base)
+	Range string
+	// Last reviews the last N commits on HEAD
+	Last int
+	// Stash reviews a stash entry (e.g. "stash@{0}")
+	Stash string
+	// Untracked includes untracked, non-ignored files in working tree reviews
+	Untracked bool
+}
+
+// Validate checks that at most one source is selected
+func (s DiffSource) Validate() error {
+	var selected []string
+	if s.Staged {
+		selected = append(selected, "--staged")
+	}
+	if s.Base != "" {
+		selected = append(selected, "--base")
+	}
+	if s.Commit != "" {
+		selected = append(selected, "--commit")
+	}
+	if s.Range != "" {
+		selected = append(selected, "--range")
+	}
+	if s.Last != 0 {
+		selected = append(selected, "--last")
+	}
+	if s.Stash != "" {
+		selected = append(selected, "--stash")
+	}
+
+	if len(selected) > 1 {
+		return fmt.Errorf("cannot use %s together. Choose one", strings.Join(selected, " and "))
+	}
+	if s.Head != "" && s.Base == "" {
+		return fmt.Errorf("--head requires --base")
+	}
+	if s.Last < 0 {
+		return fmt.Errorf("--last must be a positive number of commits")
+	}
+	return nil
+}
+
+// IsWorkingTree returns true if the source reads uncommitted changes from the working tree
+func (s DiffSource) IsWorkingTree() bool {
+	return !s.Staged && s.Base == "" && s.Commit == "" && s.Range == "" && s.Last == 0 && s.Stash == ""
+}
+
+// IsCommitted returns true if the source compares committed revisions (--base, --commit,
+// --range or --last), so its lines can be anchored to a pushed commit
+func (s DiffSource) IsCommitted() bool {
+	return s.Base != "" || s.Commit != "" || s.Range != "" || s.Last > 0
+}
+
+// Revisions resolves the base and head commit SHAs the diff is taken between.
+// For working tree and staged reviews both are HEAD.
+func (s DiffSource) Revisions() (base, head string, err error) {
+	switch {
+	case s.Base != "":
+		headRef := s.Head
+		if headRef == "" {
+			headRef = "HEAD"
+		}
+		return mergeBaseRevisions(s.Base, headRef)
+	case s.Commit != "":
+		if head, err = resolveCommit(s.Commit); err != nil {
+			return "", "", err
+		}
+		return parentOf(head), head, nil
+	case s.Range != "":
+		from, to, symmetric := parseRange(s.Range)
+		if symmetric {
+			return mergeBaseRevisions(from, to)
+		}
+		if base, err = resolveCommit(from); err != nil {
+			return "", "", err
+		}
+		if head, err = resolveCommit(to); err != nil {
+			return "", "", err
+		}
+		return base, head, nil
+	case s.Last > 0:
+		if head, err = resolveCommit("HEAD"); err != nil {
+			return "", "", err
+		}
+		if base, err = resolveCommit(fmt.Sprintf("HEAD~%d", s.Last)); err != nil {
+			return "", "", fmt.Errorf("HEAD has fewer than %d commits: %w", s.Last, err)
+		}
+		return base, head, nil
+	case s.Stash != "":
+		if head, err = resolveCommit(s.Stash); err != nil {
+			return "", "", err
+		}
+		if base, err = resolveCommit(head + "^1"); err != nil {
+			return "", "", err
+		}
+		return base, head, nil
+	default:
+		if head, err = resolveCommit("HEAD"); err != nil {
+			return "", "", err
+		}
+		return head, head, nil
+	}
+}
+
+// diffArgs returns the git diff arguments and the revision file contents are read from
+// ("" for the working tree, indexRev for the index)
+func (s DiffSource) diffArgs() (args []string, contentRev string, err error) {
+	switch {
+	case s.IsWorkingTree():
+		return []string{"diff"}, "", nil
+	case s.Staged:
+		return []string{"diff", "--staged"}, indexRev, nil
+	default:
+		base, head, err := s.Revisions()
+		if err != nil {
+			return nil, "", err
+		}
+		return []string{"diff", base, head}, head, nil
+	}
+}
+
+// mergeBaseRevisions resolves the merge base of from and to, and to itself
+func mergeBaseRevisions(from, to string) (base, head string, err error) {
+	if head, err = resolveCommit(to); err != nil {
+		return "", "", err
+	}
+	if _, err = resolveCommit(from); err != nil {
+		return "", "", err
+	}
+	if base, err = MergeBase(from, head); err != nil {
+		return "", "", fmt.Errorf("no merge base between '%s' and '%s': %w", from, to, err)
+	}
+	return base, head, nil
+}
+
+// resolveCommit resolves ref to a commit SHA with a user-facing error
+func resolveCommit(ref string) (string, error) {
+	sha, err := ResolveRef(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid reference '%s': reference not found", ref)
+	}
+	return sha, nil
+}
+
+// parentOf returns the first parent of a commit, or the empty tree for root commits
+func parentOf(sha string) string {
+	parent, err := ResolveRef(sha + "^1")
+	if err != nil {
+		return emptyTreeSHA
+	}
+	return parent
+}
+
+// parseRange splits "A..B" or "A...B"; a missing side defaults to HEAD
+func parseRange(r string) (from, to string, symmetric bool) {
+	sep := ".."
+	if strings.Contains(r, "...") {
+		sep = "..."
+		symmetric = true
+	}
+	from, to, found := strings.Cut(r, sep)
+	if !found {
+		// A single revision means "from there to HEAD", like git log A..
+		to = ""
+	}
+	if from == "" {
+		from = "HEAD"
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	return from, to, symmetric
+}
+
+// showFile returns the content of path at rev (indexRev for the staged version)
+func showFile(rootDir, rev, path string) (string, error) {
+	spec := rev + ":" + path
+	if rev == indexRev {
+		spec = indexRev + path
+	}
+
+	cmd := exec.Command("git", "show", spec)
+	cmd.Dir = rootDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, from, to string
+		symmetric    bool
+	}{
+		{in: "a..b", from: "a", to: "b"},
+		{in: "a...b", from: "a", to: "b", symmetric: true},
+		{in: "a..", from: "a", to: "HEAD"},
+		{in: "...b", from: "HEAD", to: "b", symmetric: true},
+		{in: "a", from: "a", to: "HEAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			from, to, symmetric := parseRange(tt.in)
+			require.Equal(t, tt.from, from)
+			require.Equal(t, tt.to, to)
+			require.Equal(t, tt.symmetric, symmetric)
+		})
+	}
+}
+
+func TestDiffSourceValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DiffSource{}.Validate())
+	require.NoError(t, DiffSource{Base: "main", Head: "feature"}.Validate())
+	require.Error(t, DiffSource{Staged: true, Base: "main"}.Validate())
+	require.Error(t, DiffSource{Commit: "abc", Last: 2}.Validate())
+	require.Error(t, DiffSource{Head: "feature"}.Validate())
+	require.Error(t, DiffSource{Last: -1}.Validate())
+}
+
+func TestDiffSourceIsCommitted(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, DiffSource{Base: "main"}.IsCommitted())
+	require.True(t, DiffSource{Commit: "abc"}.IsCommitted())
+	require.True(t, DiffSource{Range: "a..b"}.IsCommitted())
+	require.True(t, DiffSource{Last: 2}.IsCommitted())
+	require.False(t, DiffSource{}.IsCommitted())
+	require.False(t, DiffSource{Staged: true}.IsCommitted())
+	require.False(t, DiffSource{Stash: "stash@{0}"}.IsCommitted())
+}
+
+func TestGetDiffReadsRevisionContents(t *testing.T) {
+	repo := t.TempDir()
+	t.Chdir(repo)
+
+	gitRun(t, "init", "-q", "-b", "main")
+	writeFile(t, repo, "a.go", "package a // v1\n")
+	gitRun(t, "add", ".")
+	gitRun(t, "commit", "-q", "-m", "first")
+	writeFile(t, repo, "a.go", "package a // v2\n")
+	gitRun(t, "commit", "-q", "-am", "second")
+	// A dirty working tree must not leak into revision reviews
+	writeFile(t, repo, "a.go", "package a // dirty\n")
+
+	result, err := GetDiff(DiffSource{Commit: "HEAD~1"})
+	require.NoError(t, err)
+	require.Equal(t, "package a // v1\n", result.ModifiedFiles["a.go"])
+
+	result, err = GetDiff(DiffSource{Last: 1})
+	require.NoError(t, err)
+	require.Equal(t, "package a // v2\n", result.ModifiedFiles["a.go"])
+	require.Contains(t, result.RawDiff, "+package a // v2")
+
+	writeFile(t, repo, "new file.go", "package a // new\n")
+	writeFile(t, repo, ".gitignore", "*.log\n")
+	writeFile(t, repo, "debug.log", "ignored\n")
+
+	result, err = GetDiff(DiffSource{})
+	require.NoError(t, err)
+	require.Equal(t, "package a // dirty\n", result.ModifiedFiles["a.go"])
+	require.NotContains(t, result.RawDiff, "new file.go")
+
+	result, err = GetDiff(DiffSource{Untracked: true})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"new file.go", ".gitignore"}, result.UntrackedFiles)
+	require.Contains(t, result.RawDiff, "+package a // new")
+	// Paths with spaces are parsed from the typed diff, not split on spaces
+	require.Equal(t, "package a // new\n", result.ModifiedFiles["new file.go"])
+	require.NotContains(t, result.RawDiff, "debug.log")
+	require.NoError(t, os.Remove(filepath.Join(repo, "new file.go")))
+	require.NoError(t, os.Remove(filepath.Join(repo, ".gitignore")))
+	require.NoError(t, os.Remove(filepath.Join(repo, "debug.log")))
+
+	gitRun(t, "stash", "-q")
+	result, err = GetDiff(DiffSource{Stash: "stash@{0}"})
+	require.NoError(t, err)
+	require.Equal(t, "package a // dirty\n", result.ModifiedFiles["a.go"])
+
+	_, err = GetDiff(DiffSource{Last: 5})
+	require.Error(t, err)
+}
+
+func gitRun(t *testing.T, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// listUntracked returns untracked files that are not ignored by .gitignore, relative to rootDir
+func listUntracked(rootDir string) ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "--others", "--exclude-standard", "-z")
+	cmd.Dir = rootDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return lo.Compact(strings.Split(stdout.String(), "\x00")), nil
+}
+
+// untrackedDiff synthesizes new-file diffs for untracked files
+func untrackedDiff(rootDir string, paths []string) (string, error) {
+	var sb strings.Builder
+	for _, path := range paths {
+		diff, err := newFileDiff(rootDir, path)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), nil
+}
+
+// newFileDiff returns the diff adding path as a new file (git diff --no-index /dev/null <path>)
+func newFileDiff(rootDir, path string) (string, error) {
+	cmd := exec.Command("git", "diff", "--no-index", "-U3", "--", "/dev/null", path)
+	cmd.Dir = rootDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// --no-index exits with 1 when the files differ, which is always the case here
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return "", fmt.Errorf("failed to diff untracked file %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,59 @@
+package hook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheDir is the cache's directory in the data directory
+const CacheDir = "hook-cache"
+
+// cacheTTL is how long a passed review is remembered
+const cacheTTL = 7 * 24 * time.Hour
+
+// Cache remembers the reviews a hook passed, so unchanged changes are not reviewed twice.
+// Blocked reviews are not remembered: running the hook again shows the findings again.
+type Cache struct {
+	dir string
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Key identifies a review by its hook, settings and revisions
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Passed reports whether the review of key passed within the cache's lifetime
+func (c *Cache) Passed(key string) bool {
+	info, err := os.Stat(filepath.Join(c.dir, key))
+	return err == nil && time.Since(info.ModTime()) < cacheTTL
+}
+
+// MarkPassed remembers that the review of key passed, and forgets expired reviews
+func (c *Cache) MarkPassed(key string) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create hook cache: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, key), nil, 0o600); err != nil {
+		return fmt.Errorf("failed to write hook cache: %w", err)
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) >= cacheTTL {
+			_ = os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package hook manages the git hooks that review changes before they are committed or pushed.
+package hook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+const (
+	PreCommit = "pre-commit"
+	PrePush   = "pre-push"
+
+	// SkipEnv set to a true value lets a commit or push through without a review
+	SkipEnv = "REVCLI_SKIP_HOOKS"
+
+	// marker identifies the hooks revcli manages
+	marker = "# revcli managed hook"
+	// chainedSuffix names the user's hook an installed hook replaced; it runs before the review
+	chainedSuffix = ".revcli-chained"
+)
+
+// Names lists the hooks revcli installs
+var Names = []string{PreCommit, PrePush}
+
+// State is what a hook path holds
+type State string
+
+const (
+	StateMissing   State = "not installed"
+	StateInstalled State = "installed"
+	// StateForeign is a hook revcli does not manage; installing chains it
+	StateForeign State = "other hook"
+)
+
+// Status describes a hook
+type Status struct {
+	Name  string
+	Path  string
+	State State
+	// Chained is the path of the user's hook run before the review; empty if there is none
+	Chained string
+}
+
+// script is a managed hook: it runs the hook it replaced, then the review
+var script = template.Must(template.New("hook").Parse(`#!/bin/sh
+{{.Marker}}: {{.Name}}
+# "revcli hook uninstall" removes it and restores the hook it replaced.
+# Skip the review with {{.SkipEnv}}=1 or git's --no-verify.
+
+chained="$(dirname "$0")/{{.Name}}{{.ChainedSuffix}}"
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+revcli={{.Executable}}
+[ -x "$revcli" ] || revcli=revcli
+exec "$revcli" hook run {{.Name}}
+`))
+
+// Inspect returns the status of a hook in dir
+func Inspect(dir, name string) (Status, error) {
+	status := Status{Name: name, Path: filepath.Join(dir, name), State: StateMissing}
+	data, err := os.ReadFile(status.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return status, nil
+	case err != nil:
+		return status, fmt.Errorf("failed to read %s hook: %w", name, err)
+	case bytes.Contains(data, []byte(marker)):
+		status.State = StateInstalled
+	default:
+		status.State = StateForeign
+	}
+	if _, err := os.Stat(status.Path + chainedSuffix); err == nil {
+		status.Chained = status.Path + chainedSuffix
+	}
+	return status, nil
+}
+
+// Install writes a managed hook to dir that runs executable. A hook revcli does not manage
+// is kept and runs first; a managed one is rewritten.
+func Install(dir, name, executable string) (Status, error) {
+	status, err := Inspect(dir, name)
+	if err != nil {
+		return status, err
+	}
+	if status.State == StateForeign {
+		if status.Chained != "" {
+			return status, fmt.Errorf("cannot chain the %s hook: %s already exists", name, status.Chained)
+		}
+		if err := os.Rename(status.Path, status.Path+chainedSuffix); err != nil {
+			return status, fmt.Errorf("failed to keep the existing %s hook: %w", name, err)
+		}
+		status.Chained = status.Path + chainedSuffix
+	}
+
+	var buf bytes.Buffer
+	if err := script.Execute(&buf, map[string]string{
+		"Marker":        marker,
+		"Name":          name,
+		"SkipEnv":       SkipEnv,
+		"ChainedSuffix": chainedSuffix,
+		"Executable":    shellQuote(filepath.ToSlash(executable)),
+	}); err != nil {
+		return status, fmt.Errorf("failed to render %s hook: %w", name, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return status, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(status.Path, buf.Bytes(), 0o755); err != nil {
+		return status, fmt.Errorf("failed to write %s hook: %w", name, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(status.Path, 0o755); err != nil {
+		return status, fmt.Errorf("failed to make %s hook executable: %w", name, err)
+	}
+	status.State = StateInstalled
+	return status, nil
+}
+
+// Uninstall removes a managed hook and puts back the hook it replaced. Hooks revcli does not
+// manage are left alone.
+func Uninstall(dir, name string) (Status, error) {
+	status, err := Inspect(dir, name)
+	if err != nil || status.State != StateInstalled {
+		return status, err
+	}
+	if err := os.Remove(status.Path); err != nil {
+		return status, fmt.Errorf("failed to remove %s hook: %w", name, err)
+	}
+	status.State = StateMissing
+	if status.Chained != "" {
+		if err := os.Rename(status.Chained, status.Path); err != nil {
+			return status, fmt.Errorf("failed to restore the previous %s hook: %w", name, err)
+		}
+		status.State = StateForeign
+		status.Chained = ""
+	}
+	return status, nil
+}
+
+// ValidName checks that revcli manages a hook of that name
+func ValidName(name string) error {
+	if slices.Contains(Names, name) {
+		return nil
+	}
+	return fmt.Errorf("unsupported hook %q (supported: %s)", name, strings.Join(Names, ", "))
+}
+
+// shellQuote quotes s for sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
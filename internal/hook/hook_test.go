@@ -0,0 +1,123 @@
+package hook
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+func TestInstallChainsExistingHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are sh scripts")
+	}
+	t.Parallel()
+
+	dir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+	userHook := "#!/bin/sh\necho user \"$@\" >> " + shellQuote(log) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, PrePush), []byte(userHook), 0o755))
+	// Stands in for the revcli binary
+	fakeRevcli := filepath.Join(t.TempDir(), "rev'cli")
+	require.NoError(t, os.WriteFile(fakeRevcli, []byte("#!/bin/sh\necho revcli \"$@\" >> "+shellQuote(log)+"\n"), 0o755))
+
+	status, err := Install(dir, PrePush, fakeRevcli)
+	require.NoError(t, err)
+	assert.Equal(t, StateInstalled, status.State)
+	assert.Equal(t, filepath.Join(dir, PrePush+chainedSuffix), status.Chained)
+
+	// Installing again rewrites the managed hook and keeps the chain
+	status, err = Install(dir, PrePush, fakeRevcli)
+	require.NoError(t, err)
+	assert.NotEmpty(t, status.Chained)
+
+	require.NoError(t, exec.Command(filepath.Join(dir, PrePush), "origin", "url").Run())
+	out, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, "user origin url\nrevcli hook run pre-push\n", string(out))
+
+	status, err = Uninstall(dir, PrePush)
+	require.NoError(t, err)
+	assert.Equal(t, StateForeign, status.State)
+	restored, err := os.ReadFile(filepath.Join(dir, PrePush))
+	require.NoError(t, err)
+	assert.Equal(t, userHook, string(restored))
+	assert.NoFileExists(t, filepath.Join(dir, PrePush+chainedSuffix))
+}
+
+func TestChainedHookFailureBlocks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are sh scripts")
+	}
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, PreCommit), []byte("#!/bin/sh\nexit 7\n"), 0o755))
+	_, err := Install(dir, PreCommit, "/nonexistent/revcli")
+	require.NoError(t, err)
+
+	err = exec.Command(filepath.Join(dir, PreCommit)).Run()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 7, exitErr.ExitCode())
+}
+
+func TestUninstallLeavesOtherHooks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, PreCommit)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+
+	status, err := Uninstall(dir, PreCommit)
+	require.NoError(t, err)
+	assert.Equal(t, StateForeign, status.State)
+	assert.FileExists(t, path)
+
+	status, err = Uninstall(dir, PrePush)
+	require.NoError(t, err)
+	assert.Equal(t, StateMissing, status.State)
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	settings, err := Resolve(PreCommit, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Settings{Preset: "quick", Model: smallModel, FailOn: findings.ThresholdCritical, Timeout: 2 * time.Minute}, settings)
+
+	settings, err = Resolve(PrePush, &config.HooksConfig{PrePush: &config.HookConfig{FailOn: "warning", Timeout: "30s"}})
+	require.NoError(t, err)
+	assert.Equal(t, Settings{FailOn: findings.ThresholdWarning, Timeout: 30 * time.Second}, settings)
+	assert.Equal(t, []string{"--no-interactive", "--fail-on", "warning"}, settings.ReviewArgs())
+
+	_, err = Resolve(PreCommit, &config.HooksConfig{PreCommit: &config.HookConfig{FailOn: "sometimes"}})
+	require.Error(t, err)
+	_, err = Resolve(PreCommit, &config.HooksConfig{PreCommit: &config.HookConfig{Timeout: "soon"}})
+	require.Error(t, err)
+	_, err = Resolve("post-merge", nil)
+	require.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache(filepath.Join(t.TempDir(), CacheDir))
+	key := Key(PreCommit, "tree", "head")
+	assert.False(t, cache.Passed(key))
+	require.NoError(t, cache.MarkPassed(key))
+	assert.True(t, cache.Passed(key))
+	assert.False(t, cache.Passed(Key(PreCommit, "other tree", "head")))
+
+	expired := time.Now().Add(-cacheTTL)
+	require.NoError(t, os.Chtimes(filepath.Join(cache.dir, key), expired, expired))
+	assert.False(t, cache.Passed(key))
+}
@@ -0,0 +1,67 @@
+package hook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+// smallModel selects the configured small model for a review
+const smallModel = "small"
+
+// Settings are how a hook reviews: options.hooks over the hook's defaults
+type Settings struct {
+	Preset  string
+	Model   string
+	FailOn  findings.Threshold
+	Timeout time.Duration
+}
+
+// defaults keeps commits fast and gives pushes a full review
+var defaults = map[string]config.HookConfig{
+	PreCommit: {Preset: "quick", Model: smallModel, FailOn: string(findings.ThresholdCritical), Timeout: "2m"},
+	PrePush:   {FailOn: string(findings.ThresholdCritical), Timeout: "10m"},
+}
+
+// Resolve returns the settings of a hook from the hooks config, which may be nil
+func Resolve(name string, hooks *config.HooksConfig) (Settings, error) {
+	if err := ValidName(name); err != nil {
+		return Settings{}, err
+	}
+	configured := &config.HookConfig{}
+	if hooks != nil {
+		configured = lo.CoalesceOrEmpty(lo.Ternary(name == PreCommit, hooks.PreCommit, hooks.PrePush), configured)
+	}
+	def := defaults[name]
+
+	failOn, err := findings.ParseThreshold(lo.CoalesceOrEmpty(configured.FailOn, def.FailOn))
+	if err != nil {
+		return Settings{}, fmt.Errorf("options.hooks %s: %w", name, err)
+	}
+	timeout, err := time.ParseDuration(lo.CoalesceOrEmpty(configured.Timeout, def.Timeout))
+	if err != nil || timeout <= 0 {
+		return Settings{}, fmt.Errorf("options.hooks %s: invalid timeout %q", name, configured.Timeout)
+	}
+	return Settings{
+		Preset:  lo.CoalesceOrEmpty(configured.Preset, def.Preset),
+		Model:   lo.CoalesceOrEmpty(configured.Model, def.Model),
+		FailOn:  failOn,
+		Timeout: timeout,
+	}, nil
+}
+
+// ReviewArgs returns the review flags of the settings
+func (s Settings) ReviewArgs() []string {
+	args := []string{"--no-interactive", "--fail-on", string(s.FailOn)}
+	if s.Preset != "" {
+		args = append(args, "--preset", s.Preset)
+	}
+	if s.Model != "" {
+		args = append(args, "--model", s.Model)
+	}
+	return args
+}
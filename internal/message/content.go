@@ -112,10 +112,22 @@ type ToolResult struct {
 	MIMEType   string `json:"mime_type"`
 	Metadata   string `json:"metadata"`
 	IsError    bool   `json:"is_error"`
+	// Redactions are the secrets withheld from the model
+	Redactions []Redaction `json:"redactions,omitempty"`
 }
 
 func (ToolResult) isPart() {}
 
+// Redaction is a secret withheld from a tool result before it was sent to the model
+type Redaction struct {
+	RuleID string `json:"rule_id"`
+	// Match is the masked secret
+	Match string `json:"match"`
+	Line  int    `json:"line"`
+	// Blocked is set when the whole result was withheld rather than the secret replaced
+	Blocked bool `json:"blocked,omitempty"`
+}
+
 type Finish struct {
 	Reason  FinishReason `json:"reason"`
 	Time    int64        `json:"time"`
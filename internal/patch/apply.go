@@ -0,0 +1,174 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/trankhanh040147/revcli/internal/agent/tools"
+	"github.com/trankhanh040147/revcli/internal/fsext"
+	"github.com/trankhanh040147/revcli/internal/history"
+)
+
+// AppliedFile is a file written by a batch
+type AppliedFile struct {
+	Path string
+	// BeforeID is the history version holding the content before the batch
+	BeforeID string
+	// Created is true when the batch created the file
+	Created bool
+}
+
+// Batch is a set of patches applied together, so they can be reverted together
+type Batch struct {
+	SessionID string
+	Files     []AppliedFile
+}
+
+// fileChange is what a batch writes to one file, composed from the accepted patches to it
+type fileChange struct {
+	path    string
+	before  string
+	after   string
+	created bool
+	crlf    bool
+}
+
+// Apply writes the accepted patches, recording the content before and after each file in history.
+// Nothing is written if any target changed on disk since it was previewed, and a batch that fails
+// midway is reverted; the batch is only returned with an error if that revert failed too.
+func Apply(ctx context.Context, files history.Service, sessionID string, patches []Patch) (*Batch, error) {
+	changes, err := compose(patches)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{SessionID: sessionID}
+	for _, c := range changes {
+		if err := batch.write(ctx, files, c); err != nil {
+			if revertErr := batch.Revert(ctx, files); revertErr != nil {
+				// The batch is returned so the revert can be retried
+				return batch, errors.Join(err, fmt.Errorf("failed to revert the batch: %w", revertErr))
+			}
+			return nil, err
+		}
+	}
+	return batch, nil
+}
+
+// compose turns the accepted patches into one change per file. Patches to the same file are
+// combined in order, replaying the edits of each on the result of the ones before it.
+func compose(patches []Patch) ([]*fileChange, error) {
+	var changes []*fileChange
+	byPath := make(map[string]*fileChange)
+	for _, p := range patches {
+		if p.Status != StatusAccepted {
+			continue
+		}
+		c, ok := byPath[p.Path]
+		if !ok {
+			if err := checkUnchanged(p); err != nil {
+				return nil, err
+			}
+			c = &fileChange{path: p.Path, before: p.Before, after: p.After, created: p.Created, crlf: p.crlf}
+			byPath[p.Path] = c
+			changes = append(changes, c)
+			continue
+		}
+
+		switch {
+		case p.Before != c.before:
+			return nil, fmt.Errorf("%s changed since the patch was generated", p.FilePath)
+		case p.Edited || p.Created:
+			// The whole file is replaced, which would drop the other patches
+			return nil, fmt.Errorf("%s: a patch that replaces the whole file cannot be combined with other patches to it", p.FilePath)
+		}
+		after, failed, err := tools.PreviewMultiEdit(c.after, p.Edits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.FilePath, err)
+		}
+		if len(failed) > len(p.Failed) {
+			return nil, fmt.Errorf("%s: patch %q conflicts with another accepted patch to the file", p.FilePath, p.Description)
+		}
+		c.after = after
+	}
+	return changes, nil
+}
+
+// write records the content before the change, writes it and records the content after
+func (b *Batch) write(ctx context.Context, files history.Service, c *fileChange) error {
+	before, err := files.CreateVersion(ctx, b.SessionID, c.path, c.before)
+	if err != nil {
+		return fmt.Errorf("error creating file history: %w", err)
+	}
+	// Recorded before writing, so a partial write is reverted too
+	b.Files = append(b.Files, AppliedFile{Path: c.path, BeforeID: before.ID, Created: c.created})
+	if err := write(c.path, c.after, c.crlf); err != nil {
+		return err
+	}
+	if _, err := files.CreateVersion(ctx, b.SessionID, c.path, c.after); err != nil {
+		return fmt.Errorf("error creating file history version: %w", err)
+	}
+	return nil
+}
+
+// Revert restores every file of the batch to its content before the batch
+func (b *Batch) Revert(ctx context.Context, files history.Service) error {
+	for _, f := range b.Files {
+		if f.Created {
+			if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", f.Path, err)
+			}
+			continue
+		}
+		before, err := files.Get(ctx, f.BeforeID)
+		if err != nil {
+			return fmt.Errorf("failed to load history of %s: %w", f.Path, err)
+		}
+		current, err := os.ReadFile(f.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		_, crlf := fsext.ToUnixLineEndings(string(current))
+		if err := write(f.Path, before.Content, crlf); err != nil {
+			return err
+		}
+		if _, err := files.CreateVersion(ctx, b.SessionID, f.Path, before.Content); err != nil {
+			return fmt.Errorf("error creating file history version: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkUnchanged fails if the patch target no longer matches the previewed content
+func checkUnchanged(p Patch) error {
+	content, err := os.ReadFile(p.Path)
+	switch {
+	case p.Created && err == nil:
+		return fmt.Errorf("file already exists: %s", p.FilePath)
+	case p.Created && os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read %s: %w", p.FilePath, err)
+	}
+	if current, _ := fsext.ToUnixLineEndings(string(content)); current != p.Before {
+		return fmt.Errorf("%s changed since the patch was generated", p.FilePath)
+	}
+	return nil
+}
+
+// write writes content, restoring Windows line endings if the file used them
+func write(path, content string, crlf bool) error {
+	if crlf {
+		content, _ = fsext.ToWindowsLineEndings(content)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create parent directories: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
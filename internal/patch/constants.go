@@ -0,0 +1,23 @@
+package patch
+
+// Prompt asks the model to turn its code suggestions into multiedit operations
+const Prompt = `Turn each code suggestion from your review ("💡 Code Suggestions" and any fixes you proposed for issues) into exact edits. Do not edit any file yourself.
+
+Reply with only a JSON array, one object per suggestion. Several suggestions for the same file are separate objects, each with only its own edits:
+
+[
+  {
+    "file_path": "path/relative/to/repo.go",
+    "description": "One line describing the fix",
+    "edits": [
+      {"old_string": "exact text currently in the file", "new_string": "replacement text", "replace_all": false}
+    ]
+  }
+]
+
+Rules:
+- old_string must match the current file exactly, including whitespace, and be unique in the file unless replace_all is true. Include enough surrounding lines to make it unique.
+- Edits of a suggestion are applied in order, each to the result of the previous one.
+- Write every suggestion against the current file, not against the result of another suggestion.
+- To create a new file, make the first edit's old_string empty and put the whole file in new_string.
+- Reply with [] if there is nothing to fix.`
@@ -0,0 +1,122 @@
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/agent/tools"
+	"github.com/trankhanh040147/revcli/internal/filepathext"
+	"github.com/trankhanh040147/revcli/internal/fsext"
+)
+
+// Status is the reviewer's decision on a patch
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusAccepted
+	StatusRejected
+)
+
+// String returns the status label shown in the patch list
+func (s Status) String() string {
+	switch s {
+	case StatusAccepted:
+		return "accepted"
+	case StatusRejected:
+		return "rejected"
+	default:
+		return "pending"
+	}
+}
+
+// Suggestion is a code suggestion from the review, expressed as multiedit operations on one file
+type Suggestion struct {
+	FilePath    string                     `json:"file_path"`
+	Description string                     `json:"description"`
+	Edits       []tools.MultiEditOperation `json:"edits"`
+}
+
+// Patch is a suggestion previewed against the file on disk
+type Patch struct {
+	Suggestion
+	// Path is the absolute file path
+	Path string
+	// Before and After are the file content (with Unix line endings) before and after the patch
+	Before string
+	After  string
+	// Created is true when the patch creates the file
+	Created bool
+	// Failed lists the edits that did not apply; the rest are still in After
+	Failed []tools.FailedEdit
+	Status Status
+	// Edited is true when After was changed by hand
+	Edited bool
+
+	crlf bool
+}
+
+// ErrNoChanges is returned by Preview when a suggestion leaves the file unchanged
+var ErrNoChanges = errors.New("suggestion makes no changes")
+
+// fencePattern matches a fenced code block, capturing its body
+var fencePattern = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*\\n(.*?)\\n\\s*```")
+
+// ParseSuggestions extracts the suggestion list from a model response, with or without a code fence
+func ParseSuggestions(response string) ([]Suggestion, error) {
+	text := strings.TrimSpace(response)
+	if m := fencePattern.FindStringSubmatch(text); m != nil {
+		text = strings.TrimSpace(m[1])
+	}
+	// Tolerate prose around the JSON array
+	if start, end := strings.Index(text, "["), strings.LastIndex(text, "]"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+
+	var suggestions []Suggestion
+	if err := sonic.UnmarshalString(text, &suggestions); err != nil {
+		return nil, fmt.Errorf("failed to parse suggestions: %w", err)
+	}
+	return lo.Filter(suggestions, func(s Suggestion, _ int) bool {
+		return s.FilePath != "" && len(s.Edits) > 0
+	}), nil
+}
+
+// Preview applies a suggestion to the file in workingDir without writing it (a multiedit dry run)
+func Preview(workingDir string, s Suggestion) (*Patch, error) {
+	p := &Patch{Suggestion: s, Path: filepathext.SmartJoin(workingDir, s.FilePath)}
+	p.Created = len(s.Edits) > 0 && s.Edits[0].OldString == ""
+
+	if !p.Created {
+		content, err := os.ReadFile(p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", s.FilePath, err)
+		}
+		p.Before, p.crlf = fsext.ToUnixLineEndings(string(content))
+	} else if _, err := os.Stat(p.Path); err == nil {
+		return nil, fmt.Errorf("file already exists: %s", s.FilePath)
+	}
+
+	after, failed, err := tools.PreviewMultiEdit(p.Before, s.Edits)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.FilePath, err)
+	}
+	if after == p.Before {
+		return nil, fmt.Errorf("%s: %w", s.FilePath, ErrNoChanges)
+	}
+	p.After, p.Failed = after, failed
+	return p, nil
+}
+
+// SetAfter replaces the patched content with a hand-edited version and accepts the patch
+func (p *Patch) SetAfter(content string) {
+	p.After = content
+	p.Edited = true
+	p.Status = StatusAccepted
+}
@@ -0,0 +1,187 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/agent/tools"
+	"github.com/trankhanh040147/revcli/internal/history"
+)
+
+// memoryHistory keeps file versions in memory
+type memoryHistory struct {
+	history.Service
+	files map[string]history.File
+	// failPath makes recording a version of that path fail
+	failPath string
+}
+
+func (h *memoryHistory) CreateVersion(_ context.Context, sessionID, path, content string) (history.File, error) {
+	if path == h.failPath {
+		return history.File{}, errors.New("history unavailable")
+	}
+	f := history.File{ID: uuid.NewString(), SessionID: sessionID, Path: path, Content: content}
+	h.files[f.ID] = f
+	return f, nil
+}
+
+func (h *memoryHistory) Get(_ context.Context, id string) (history.File, error) {
+	return h.files[id], nil
+}
+
+func TestParseSuggestions(t *testing.T) {
+	t.Parallel()
+
+	response := "Here are the patches:\n\n```json\n" + `[
+  {"file_path": "a.go", "description": "Check the error", "edits": [{"old_string": "f()", "new_string": "if err := f(); err != nil {\n\treturn err\n}"}]},
+  {"file_path": "b.go", "description": "No edits", "edits": []}
+]` + "\n```\n"
+
+	suggestions, err := ParseSuggestions(response)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, "a.go", suggestions[0].FilePath)
+	require.Equal(t, "f()", suggestions[0].Edits[0].OldString)
+
+	suggestions, err = ParseSuggestions("[]")
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+
+	_, err = ParseSuggestions("I could not find anything to fix.")
+	require.Error(t, err)
+}
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\r\n\r\nvar x = 1\r\n"), 0o644))
+
+	p, err := Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{
+		{OldString: "x = 1", NewString: "x = 2"},
+		{OldString: "missing", NewString: "y"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "package a\n\nvar x = 1\n", p.Before)
+	require.Equal(t, "package a\n\nvar x = 2\n", p.After)
+	require.Len(t, p.Failed, 1)
+	require.Equal(t, StatusPending, p.Status)
+
+	_, err = Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{{OldString: "missing", NewString: "y"}}})
+	require.ErrorIs(t, err, ErrNoChanges)
+
+	_, err = Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{{NewString: "package a\n"}}})
+	require.ErrorContains(t, err, "already exists")
+}
+
+func TestApplyAndRevert(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.go")
+	require.NoError(t, os.WriteFile(existing, []byte("package a\r\n\r\nvar x = 1\r\n"), 0o644))
+
+	edit, err := Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{{OldString: "x = 1", NewString: "x = 2"}}})
+	require.NoError(t, err)
+	edit.Status = StatusAccepted
+	create, err := Preview(dir, Suggestion{FilePath: "sub/b.go", Edits: []tools.MultiEditOperation{{NewString: "package sub\n"}}})
+	require.NoError(t, err)
+	create.SetAfter("package sub\n\n// edited\n")
+	rejected, err := Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{{OldString: "package a", NewString: "package b"}}})
+	require.NoError(t, err)
+	rejected.Status = StatusRejected
+
+	files := &memoryHistory{files: map[string]history.File{}}
+	batch, err := Apply(t.Context(), files, "session", []Patch{*edit, *create, *rejected})
+	require.NoError(t, err)
+	require.Len(t, batch.Files, 2)
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	require.Equal(t, "package a\r\n\r\nvar x = 2\r\n", string(content), "line endings are kept")
+	content, err = os.ReadFile(filepath.Join(dir, "sub", "b.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package sub\n\n// edited\n", string(content))
+
+	// The same patch cannot be applied on top of itself
+	_, err = Apply(t.Context(), files, "session", []Patch{*edit})
+	require.ErrorContains(t, err, "changed since")
+
+	require.NoError(t, batch.Revert(t.Context(), files))
+	content, err = os.ReadFile(existing)
+	require.NoError(t, err)
+	require.Equal(t, "package a\r\n\r\nvar x = 1\r\n", string(content))
+	require.NoFileExists(t, filepath.Join(dir, "sub", "b.go"))
+}
+
+func TestApplyCombinesPatchesToOneFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, os.WriteFile(path, []byte("package a\n\nvar x = 1\nvar y = 1\n"), 0o644))
+
+	preview := func(edits ...tools.MultiEditOperation) Patch {
+		p, err := Preview(dir, Suggestion{FilePath: "a.go", Edits: edits})
+		require.NoError(t, err)
+		p.Status = StatusAccepted
+		return *p
+	}
+	first := preview(tools.MultiEditOperation{OldString: "x = 1", NewString: "x = 2"})
+	second := preview(tools.MultiEditOperation{OldString: "y = 1", NewString: "y = 2"})
+	conflicting := preview(tools.MultiEditOperation{OldString: "x = 1", NewString: "x = 3"})
+	edited := preview(tools.MultiEditOperation{OldString: "y = 1", NewString: "y = 3"})
+	edited.SetAfter("package a\n")
+
+	files := &memoryHistory{files: map[string]history.File{}}
+	_, err := Apply(t.Context(), files, "session", []Patch{first, conflicting})
+	require.ErrorContains(t, err, "conflicts")
+	_, err = Apply(t.Context(), files, "session", []Patch{first, edited})
+	require.ErrorContains(t, err, "cannot be combined")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "package a\n\nvar x = 1\nvar y = 1\n", string(content), "nothing is written when patches conflict")
+
+	batch, err := Apply(t.Context(), files, "session", []Patch{first, second})
+	require.NoError(t, err)
+	require.Len(t, batch.Files, 1)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "package a\n\nvar x = 2\nvar y = 2\n", string(content))
+
+	require.NoError(t, batch.Revert(t.Context(), files))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "package a\n\nvar x = 1\nvar y = 1\n", string(content))
+}
+
+func TestApplyRevertsFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.go")
+	require.NoError(t, os.WriteFile(existing, []byte("package a\n\nvar x = 1\n"), 0o644))
+
+	edit, err := Preview(dir, Suggestion{FilePath: "a.go", Edits: []tools.MultiEditOperation{{OldString: "x = 1", NewString: "x = 2"}}})
+	require.NoError(t, err)
+	edit.Status = StatusAccepted
+	create, err := Preview(dir, Suggestion{FilePath: "b.go", Edits: []tools.MultiEditOperation{{NewString: "package a\n"}}})
+	require.NoError(t, err)
+	create.Status = StatusAccepted
+
+	files := &memoryHistory{files: map[string]history.File{}, failPath: create.Path}
+	batch, err := Apply(t.Context(), files, "session", []Patch{*edit, *create})
+	require.ErrorContains(t, err, "history unavailable")
+	require.Nil(t, batch)
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	require.Equal(t, "package a\n\nvar x = 1\n", string(content), "files written before the failure are restored")
+	require.NoFileExists(t, create.Path)
+}
@@ -0,0 +1,99 @@
+package permission
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/db"
+)
+
+// Source is what decided a permission request
+type Source string
+
+const (
+	// SourcePolicy is the project's permission policy
+	SourcePolicy Source = "policy"
+	// SourceAllowedTools is the permissions.allowed_tools config
+	SourceAllowedTools Source = "allowed_tools"
+	// SourceYolo is --yolo, which skips every request
+	SourceYolo Source = "yolo"
+	// SourceAutoApprove is a session approved as a whole, e.g. a non-interactive run
+	SourceAutoApprove Source = "auto_approve"
+	// SourceSession is a grant the user made earlier in the session
+	SourceSession Source = "session"
+	// SourceUser is the user's answer to the prompt
+	SourceUser Source = "user"
+)
+
+// AuditEntry is one logged permission decision
+type AuditEntry struct {
+	SessionID  string
+	ToolCallID string
+	ToolName   string
+	Action     string
+	// Subject is the command, file or path the request was about
+	Subject string
+	Granted bool
+	Source  Source
+	// Rule is the policy rule that matched, if any
+	Rule      string
+	CreatedAt int64
+}
+
+// AuditLog stores every permission decision
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry) error
+	// List returns the decisions of a session, most recent first; with an empty sessionID,
+	// the latest limit decisions of all sessions
+	List(ctx context.Context, sessionID string, limit int64) ([]AuditEntry, error)
+}
+
+type auditLog struct {
+	q db.Querier
+}
+
+func NewAuditLog(q db.Querier) AuditLog {
+	return &auditLog{q: q}
+}
+
+func (a *auditLog) Record(ctx context.Context, entry AuditEntry) error {
+	return a.q.CreatePermissionAudit(ctx, db.CreatePermissionAuditParams{
+		ID:         uuid.New().String(),
+		SessionID:  entry.SessionID,
+		ToolCallID: entry.ToolCallID,
+		ToolName:   entry.ToolName,
+		Action:     entry.Action,
+		Subject:    entry.Subject,
+		Decision:   string(lo.Ternary(entry.Granted, EffectAllow, EffectDeny)),
+		Source:     string(entry.Source),
+		Rule:       entry.Rule,
+	})
+}
+
+func (a *auditLog) List(ctx context.Context, sessionID string, limit int64) ([]AuditEntry, error) {
+	var rows []db.PermissionAudit
+	var err error
+	if sessionID == "" {
+		rows, err = a.q.ListPermissionAudit(ctx, limit)
+	} else {
+		rows, err = a.q.ListPermissionAuditBySession(ctx, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(rows, func(row db.PermissionAudit, _ int) AuditEntry {
+		return AuditEntry{
+			SessionID:  row.SessionID,
+			ToolCallID: row.ToolCallID,
+			ToolName:   row.ToolName,
+			Action:     row.Action,
+			Subject:    row.Subject,
+			Granted:    row.Decision == string(EffectAllow),
+			Source:     Source(row.Source),
+			Rule:       row.Rule,
+			CreatedAt:  row.CreatedAt,
+		}
+	}), nil
+}
@@ -1,13 +1,17 @@
 package permission
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 
+	"github.com/bytedance/sonic"
 	"github.com/google/uuid"
 	"github.com/trankhanh040147/revcli/internal/csync"
 	"github.com/trankhanh040147/revcli/internal/pubsub"
@@ -48,6 +52,11 @@ type Service interface {
 	Grant(permission PermissionRequest)
 	Deny(permission PermissionRequest)
 	Request(opts CreatePermissionRequest) bool
+	// Check decides an action a tool takes without asking, like reading a file in the working
+	// directory: only the policy can deny it, or send it to Request with an explicit ask rule
+	Check(opts CreatePermissionRequest) bool
+	// PolicyDenial returns the policy rule that denied a tool call, and forgets it
+	PolicyDenial(toolCallID string) (rule string, denied bool)
 	AutoApproveSession(sessionID string)
 	SetSkipRequests(skip bool)
 	SkipRequests() bool
@@ -66,6 +75,9 @@ type permissionService struct {
 	autoApproveSessionsMu sync.RWMutex
 	skip                  bool
 	allowedTools          []string
+	policy                *Policy
+	audit                 AuditLog
+	denials               *csync.Map[string, string]
 
 	// used to make sure we only process one request at a time
 	requestMu     sync.Mutex
@@ -123,9 +135,94 @@ func (s *permissionService) Deny(permission PermissionRequest) {
 }
 
 func (s *permissionService) Request(opts CreatePermissionRequest) bool {
-	if s.skip {
+	command, file := s.requestSubject(opts)
+	v := s.policy.decide(opts, command, file)
+	if v.effect != EffectAsk {
+		granted := v.effect == EffectAllow
+		if !granted {
+			s.denials.Set(opts.ToolCallID, v.rule)
+		}
+		s.record(opts, command, file, granted, SourcePolicy, v.rule)
+		return granted
+	}
+
+	granted, source := s.request(opts)
+	s.record(opts, command, file, granted, source, v.rule)
+	return granted
+}
+
+func (s *permissionService) Check(opts CreatePermissionRequest) bool {
+	command, file := s.requestSubject(opts)
+	v := s.policy.decide(opts, command, file)
+	if v.rule == "" || v.rule == defaultRule {
+		// The default is for requests; the tool would not ask, and no rule decided, so nothing is recorded
 		return true
 	}
+	if v.effect == EffectAsk {
+		return s.Request(opts)
+	}
+	granted := v.effect == EffectAllow
+	if !granted {
+		s.denials.Set(opts.ToolCallID, v.rule)
+	}
+	s.record(opts, command, file, granted, SourcePolicy, v.rule)
+	return granted
+}
+
+func (s *permissionService) PolicyDenial(toolCallID string) (string, bool) {
+	return s.denials.Take(toolCallID)
+}
+
+// requestSubject returns the command or file a request is about, from its params' JSON
+// fields; files in the working directory are relative to it
+func (s *permissionService) requestSubject(opts CreatePermissionRequest) (command, file string) {
+	var params struct {
+		Command  string `json:"command"`
+		FilePath string `json:"file_path"`
+		Path     string `json:"path"`
+	}
+	if data, err := sonic.Marshal(opts.Params); err == nil {
+		// Params without these fields, like the raw input of MCP tools, leave them empty
+		_ = sonic.Unmarshal(data, &params)
+	}
+	file = cmp.Or(params.FilePath, params.Path)
+	if file == "" {
+		return params.Command, ""
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(s.workingDir, file)
+	}
+	if rel, err := filepath.Rel(s.workingDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return params.Command, filepath.ToSlash(file)
+}
+
+// record adds a decision to the audit log
+func (s *permissionService) record(opts CreatePermissionRequest, command, file string, granted bool, source Source, rule string) {
+	if s.audit == nil {
+		return
+	}
+	err := s.audit.Record(context.Background(), AuditEntry{
+		SessionID:  opts.SessionID,
+		ToolCallID: opts.ToolCallID,
+		ToolName:   opts.ToolName,
+		Action:     opts.Action,
+		Subject:    cmp.Or(command, file, opts.Path),
+		Granted:    granted,
+		Source:     source,
+		Rule:       rule,
+	})
+	if err != nil {
+		slog.Error("Failed to record permission decision", "tool", opts.ToolName, "error", err)
+	}
+}
+
+// request runs the usual flow for requests the policy leaves to ask, and returns what decided it
+func (s *permissionService) request(opts CreatePermissionRequest) (bool, Source) {
+	if s.skip {
+		return true, SourceYolo
+	}
 
 	// tell the UI that a permission was requested
 	s.notificationBroker.Publish(pubsub.CreatedEvent, PermissionNotification{
@@ -137,7 +234,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	// Check if the tool/action combination is in the allowlist
 	commandKey := opts.ToolName + ":" + opts.Action
 	if slices.Contains(s.allowedTools, commandKey) || slices.Contains(s.allowedTools, opts.ToolName) {
-		return true
+		return true, SourceAllowedTools
 	}
 
 	s.autoApproveSessionsMu.RLock()
@@ -145,7 +242,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	s.autoApproveSessionsMu.RUnlock()
 
 	if autoApprove {
-		return true
+		return true, SourceAutoApprove
 	}
 
 	fileInfo, err := os.Stat(opts.Path)
@@ -176,7 +273,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	for _, p := range s.sessionPermissions {
 		if p.ToolName == permission.ToolName && p.Action == permission.Action && p.SessionID == permission.SessionID && p.Path == permission.Path {
 			s.sessionPermissionsMu.RUnlock()
-			return true
+			return true, SourceSession
 		}
 	}
 	s.sessionPermissionsMu.RUnlock()
@@ -185,7 +282,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	for _, p := range s.sessionPermissions {
 		if p.ToolName == permission.ToolName && p.Action == permission.Action && p.SessionID == permission.SessionID && p.Path == permission.Path {
 			s.sessionPermissionsMu.RUnlock()
-			return true
+			return true, SourceSession
 		}
 	}
 	s.sessionPermissionsMu.RUnlock()
@@ -199,7 +296,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	// Publish the request
 	s.Publish(pubsub.CreatedEvent, permission)
 
-	return <-respCh
+	return <-respCh, SourceUser
 }
 
 func (s *permissionService) AutoApproveSession(sessionID string) {
@@ -220,8 +317,25 @@ func (s *permissionService) SkipRequests() bool {
 	return s.skip
 }
 
-func NewPermissionService(workingDir string, skip bool, allowedTools []string) Service {
-	return &permissionService{
+// Option configures the permission service
+type Option func(*permissionService)
+
+// WithPolicy applies a project's permission policy before the usual flow
+func WithPolicy(policy *Policy) Option {
+	return func(s *permissionService) {
+		s.policy = policy
+	}
+}
+
+// WithAuditLog records every decision in log
+func WithAuditLog(log AuditLog) Option {
+	return func(s *permissionService) {
+		s.audit = log
+	}
+}
+
+func NewPermissionService(workingDir string, skip bool, allowedTools []string, opts ...Option) Service {
+	s := &permissionService{
 		Broker:              pubsub.NewBroker[PermissionRequest](),
 		notificationBroker:  pubsub.NewBroker[PermissionNotification](),
 		workingDir:          workingDir,
@@ -230,5 +344,10 @@ func NewPermissionService(workingDir string, skip bool, allowedTools []string) S
 		skip:                skip,
 		allowedTools:        allowedTools,
 		pendingRequests:     csync.NewMap[string, chan bool](),
+		denials:             csync.NewMap[string, string](),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
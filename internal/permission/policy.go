@@ -0,0 +1,268 @@
+package permission
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/trankhanh040147/revcli/internal/agent/tools/constants"
+	"github.com/trankhanh040147/revcli/internal/config"
+)
+
+// Effect is what a policy does with a permission request
+type Effect string
+
+const (
+	// EffectAsk leaves the request to the usual flow: allowed_tools, --yolo, auto-approval or the user
+	EffectAsk   Effect = "ask"
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// strength orders effects when several rules match: deny wins over ask, ask over allow
+func (e Effect) strength() int {
+	switch e {
+	case EffectDeny:
+		return 2
+	case EffectAsk:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Policy is a project's permission policy, checked into the repository as
+// .revcli/permissions.json. Rules about the command, file or MCP tool of a request
+// come first, then the tool name, then the default.
+type Policy struct {
+	// Default applies to requests no rule matches (default: ask)
+	Default Effect `json:"default,omitempty"`
+	// Tools maps tool names (bash, view, write, fetch, ...) to effects
+	Tools map[string]Effect `json:"tools,omitempty"`
+	// Bash maps command patterns to effects; * matches any text. Every command of a
+	// pipeline or list must be allowed, and one denied command denies the whole line.
+	Bash map[string]Effect `json:"bash,omitempty"`
+	// Paths maps request actions (read, write, list, download) to path globs and their
+	// effects; globs are relative to the working directory, or absolute outside of it
+	Paths map[string]map[string]Effect `json:"paths,omitempty"`
+	// MCP maps "server" or "server/tool" patterns to effects; * matches any text
+	MCP map[string]Effect `json:"mcp,omitempty"`
+
+	// patterns holds the compiled bash patterns and MCP tool name patterns
+	patterns map[string]*regexp.Regexp
+}
+
+// defaultRule names the policy default in verdicts and the audit log
+const defaultRule = "default"
+
+// verdict is a policy's effect on a request and the rule that decided it
+type verdict struct {
+	effect Effect
+	rule   string
+}
+
+// PolicyPath returns the path of a repository's permission policy
+func PolicyPath(repoRoot string) string {
+	return filepath.Join(repoRoot, config.ProjectDirName, config.PermissionPolicyFileName)
+}
+
+// LoadPolicy reads the permission policy of a repository; nil when it has none
+func LoadPolicy(repoRoot string) (*Policy, error) {
+	path := PolicyPath(repoRoot)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permission policy: %w", err)
+	}
+	var policy Policy
+	if err := sonic.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid permission policy %s: %w", path, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid permission policy %s: %w", path, err)
+	}
+	policy.compile()
+	return &policy, nil
+}
+
+// compile compiles the bash and MCP patterns once, rather than on every request
+func (p *Policy) compile() {
+	p.patterns = make(map[string]*regexp.Regexp, len(p.Bash)+len(p.MCP))
+	for pattern := range p.Bash {
+		p.patterns[pattern] = compilePattern(pattern)
+	}
+	for pattern := range p.MCP {
+		tool := mcpToolPattern(pattern)
+		p.patterns[tool] = compilePattern(tool)
+	}
+}
+
+// validate checks every effect, and every glob and pattern
+func (p *Policy) validate() error {
+	effects := map[string]Effect{"default": lo.CoalesceOrEmpty(p.Default, EffectAsk)}
+	for name, effect := range p.Tools {
+		effects["tools."+name] = effect
+	}
+	for pattern, effect := range p.Bash {
+		effects[fmt.Sprintf("bash %q", pattern)] = effect
+	}
+	for action, globs := range p.Paths {
+		for glob, effect := range globs {
+			if !doublestar.ValidatePattern(glob) {
+				return fmt.Errorf("paths.%s: invalid glob %q", action, glob)
+			}
+			effects[fmt.Sprintf("paths.%s %q", action, glob)] = effect
+		}
+	}
+	for pattern, effect := range p.MCP {
+		effects[fmt.Sprintf("mcp %q", pattern)] = effect
+	}
+	for rule, effect := range effects {
+		if !slices.Contains([]Effect{EffectAsk, EffectAllow, EffectDeny}, effect) {
+			return fmt.Errorf("%s: invalid effect %q (supported: ask, allow, deny)", rule, effect)
+		}
+	}
+	return nil
+}
+
+// decide returns the policy's verdict on a request about command or file (either may be empty)
+func (p *Policy) decide(opts CreatePermissionRequest, command, file string) verdict {
+	if p == nil {
+		return verdict{effect: EffectAsk}
+	}
+
+	var specific []verdict
+	if opts.ToolName == constants.BashToolName && command != "" {
+		if v, ok := p.commandVerdict(command); ok {
+			specific = append(specific, v)
+		}
+	}
+	if file != "" {
+		specific = append(specific, matchRules(p.Paths[opts.Action], "paths."+opts.Action, func(glob string) bool {
+			ok, _ := doublestar.Match(glob, file)
+			return ok
+		})...)
+	}
+	if strings.HasPrefix(opts.ToolName, "mcp_") {
+		specific = append(specific, matchRules(p.MCP, "mcp", func(pattern string) bool {
+			return p.patterns[mcpToolPattern(pattern)].MatchString(opts.ToolName)
+		})...)
+	}
+	if v, ok := strongest(specific); ok {
+		return v
+	}
+
+	if effect, ok := p.Tools[opts.ToolName]; ok {
+		return verdict{effect: effect, rule: "tools." + opts.ToolName}
+	}
+	return verdict{effect: lo.CoalesceOrEmpty(p.Default, EffectAsk), rule: defaultRule}
+}
+
+// commandVerdict matches each command of a shell line; false when the bash rules do not cover it
+func (p *Policy) commandVerdict(line string) (verdict, bool) {
+	var result verdict
+	matched, covered := false, true
+	for _, command := range simpleCommands(line) {
+		v, ok := strongest(matchRules(p.Bash, "bash", func(pattern string) bool {
+			return p.patterns[pattern].MatchString(command)
+		}))
+		if !ok {
+			covered = false
+			continue
+		}
+		if v.effect == EffectDeny {
+			return v, true
+		}
+		if !matched || v.effect.strength() > result.effect.strength() {
+			result, matched = v, true
+		}
+	}
+	// Allowing some commands of a line does not allow the others
+	if !matched || (!covered && result.effect == EffectAllow) {
+		return verdict{}, false
+	}
+	return result, true
+}
+
+// matchRules returns the verdicts of the rules whose pattern matches, in pattern order
+func matchRules(rules map[string]Effect, section string, match func(pattern string) bool) []verdict {
+	patterns := lo.Keys(rules)
+	slices.Sort(patterns)
+	var verdicts []verdict
+	for _, pattern := range patterns {
+		if match(pattern) {
+			verdicts = append(verdicts, verdict{effect: rules[pattern], rule: fmt.Sprintf("%s %q", section, pattern)})
+		}
+	}
+	return verdicts
+}
+
+// strongest returns the verdict with the strongest effect, the first one among equals
+func strongest(verdicts []verdict) (verdict, bool) {
+	if len(verdicts) == 0 {
+		return verdict{}, false
+	}
+	return lo.MaxBy(verdicts, func(a, b verdict) bool {
+		return a.effect.strength() > b.effect.strength()
+	}), true
+}
+
+// compilePattern compiles a pattern where * matches any text
+func compilePattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("(?s)^" + strings.Join(parts, ".*") + "$")
+}
+
+// mcpToolPattern turns "server/tool" into the tool name pattern "mcp_server_tool", and
+// "server" into "mcp_server_*"
+func mcpToolPattern(pattern string) string {
+	server, tool, ok := strings.Cut(pattern, "/")
+	if !ok {
+		tool = "*"
+	}
+	return fmt.Sprintf("mcp_%s_%s", server, tool)
+}
+
+// simpleCommands splits a shell line into its commands, nested ones included, each
+// normalized to its words; the line itself when it does not parse
+func simpleCommands(line string) []string {
+	file, err := syntax.NewParser().Parse(strings.NewReader(line), "")
+	if err != nil {
+		return []string{strings.TrimSpace(line)}
+	}
+	printer := syntax.NewPrinter()
+	var commands []string
+	syntax.Walk(file, func(node syntax.Node) bool {
+		call, ok := node.(*syntax.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		words := make([]string, 0, len(call.Args))
+		for _, word := range call.Args {
+			var buf bytes.Buffer
+			if err := printer.Print(&buf, word); err == nil {
+				words = append(words, buf.String())
+			}
+		}
+		commands = append(commands, strings.Join(words, " "))
+		return true
+	})
+	if len(commands) == 0 {
+		return []string{strings.TrimSpace(line)}
+	}
+	return commands
+}
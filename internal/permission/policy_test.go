@@ -0,0 +1,218 @@
+package permission
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuditLog keeps the recorded decisions in memory
+type fakeAuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+func (f *fakeAuditLog) Record(ctx context.Context, entry AuditEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func (f *fakeAuditLog) List(ctx context.Context, sessionID string, limit int64) ([]AuditEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.entries, nil
+}
+
+func testPolicy() *Policy {
+	policy := &Policy{
+		Default: EffectAsk,
+		Tools:   map[string]Effect{"fetch": EffectDeny, "view": EffectAllow},
+		Bash: map[string]Effect{
+			"go test ./...": EffectAllow,
+			"go vet *":      EffectAllow,
+			"git push*":     EffectDeny,
+			"rm *":          EffectAsk,
+		},
+		Paths: map[string]map[string]Effect{
+			"write": {"internal/**": EffectAllow, "internal/secrets/**": EffectDeny},
+			"read":  {".env": EffectDeny},
+		},
+		MCP: map[string]Effect{"github": EffectAllow, "github/create_issue": EffectDeny},
+	}
+	policy.compile()
+	return policy
+}
+
+func TestPolicy_Decide(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tool    string
+		action  string
+		command string
+		file    string
+		effect  Effect
+		rule    string
+	}{
+		{name: "allowed command", tool: "bash", command: "go test ./...", effect: EffectAllow, rule: `bash "go test ./..."`},
+		{name: "denied command", tool: "bash", command: "git push origin main", effect: EffectDeny, rule: `bash "git push*"`},
+		{name: "pattern is anchored", tool: "bash", command: "go test ./... && echo ok", effect: EffectAsk, rule: defaultRule},
+		{name: "all commands allowed", tool: "bash", command: "go vet ./... && go test ./...", effect: EffectAllow, rule: `bash "go vet *"`},
+		{name: "one denied command denies the line", tool: "bash", command: "go test ./... && git push", effect: EffectDeny, rule: `bash "git push*"`},
+		{name: "nested command", tool: "bash", command: "echo $(git push)", effect: EffectDeny, rule: `bash "git push*"`},
+		{name: "ask wins over allow", tool: "bash", command: "go test ./... && rm -rf build", effect: EffectAsk, rule: `bash "rm *"`},
+		{name: "uncovered command", tool: "bash", command: "make", effect: EffectAsk, rule: defaultRule},
+		{name: "tool rule", tool: "fetch", action: "fetch", effect: EffectDeny, rule: "tools.fetch"},
+		{name: "allowed path", tool: "write", action: "write", file: "internal/app/app.go", effect: EffectAllow, rule: `paths.write "internal/**"`},
+		{name: "deny wins over allow", tool: "write", action: "write", file: "internal/secrets/key.go", effect: EffectDeny, rule: `paths.write "internal/secrets/**"`},
+		{name: "path rules are per action", tool: "view", action: "read", file: "internal/app/app.go", effect: EffectAllow, rule: "tools.view"},
+		{name: "path rule before tool rule", tool: "view", action: "read", file: ".env", effect: EffectDeny, rule: `paths.read ".env"`},
+		{name: "path outside rules", tool: "write", action: "write", file: "README.md", effect: EffectAsk, rule: defaultRule},
+		{name: "mcp server", tool: "mcp_github_list_issues", action: "execute", effect: EffectAllow, rule: `mcp "github"`},
+		{name: "mcp tool", tool: "mcp_github_create_issue", action: "execute", effect: EffectDeny, rule: `mcp "github/create_issue"`},
+		{name: "other mcp server", tool: "mcp_jira_search", action: "execute", effect: EffectAsk, rule: defaultRule},
+	}
+	policy := testPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := policy.decide(CreatePermissionRequest{ToolName: tt.tool, Action: tt.action}, tt.command, tt.file)
+			assert.Equal(t, tt.effect, v.effect)
+			assert.Equal(t, tt.rule, v.rule)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		policy, err := LoadPolicy(t.TempDir())
+		require.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		t.Parallel()
+		root := writePolicy(t, `{"default": "deny", "bash": {"go test *": "allow"}}`)
+		policy, err := LoadPolicy(root)
+		require.NoError(t, err)
+		assert.Equal(t, EffectDeny, policy.Default)
+		assert.Equal(t, EffectAllow, policy.Bash["go test *"])
+	})
+
+	invalid := map[string]string{
+		"unknown effect": `{"tools": {"bash": "maybe"}}`,
+		"invalid glob":   `{"paths": {"write": {"internal/[": "allow"}}}`,
+		"invalid json":   `{"default": `,
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadPolicy(writePolicy(t, content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	path := PolicyPath(root)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return root
+}
+
+func TestPermissionService_Policy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("policy decides before yolo and records the rule", func(t *testing.T) {
+		t.Parallel()
+		audit := &fakeAuditLog{}
+		service := NewPermissionService("/repo", true, nil, WithPolicy(testPolicy()), WithAuditLog(audit))
+
+		granted := service.Request(CreatePermissionRequest{
+			SessionID:  "session",
+			ToolCallID: "call-1",
+			ToolName:   "bash",
+			Action:     "execute",
+			Params:     map[string]string{"command": "git push --force"},
+		})
+		assert.False(t, granted)
+
+		rule, denied := service.PolicyDenial("call-1")
+		assert.True(t, denied)
+		assert.Equal(t, `bash "git push*"`, rule)
+		_, denied = service.PolicyDenial("call-1")
+		assert.False(t, denied, "a denial is reported once")
+
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, AuditEntry{
+			SessionID:  "session",
+			ToolCallID: "call-1",
+			ToolName:   "bash",
+			Action:     "execute",
+			Subject:    "git push --force",
+			Granted:    false,
+			Source:     SourcePolicy,
+			Rule:       `bash "git push*"`,
+		}, audit.entries[0])
+	})
+
+	t.Run("ask falls through to auto approval", func(t *testing.T) {
+		t.Parallel()
+		audit := &fakeAuditLog{}
+		service := NewPermissionService("/repo", false, nil, WithPolicy(testPolicy()), WithAuditLog(audit))
+		service.AutoApproveSession("ci")
+
+		granted := service.Request(CreatePermissionRequest{
+			SessionID: "ci",
+			ToolName:  "write",
+			Action:    "write",
+			Params:    map[string]string{"file_path": "/repo/README.md"},
+		})
+		assert.True(t, granted)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, SourceAutoApprove, audit.entries[0].Source)
+		assert.Equal(t, "README.md", audit.entries[0].Subject)
+		assert.Equal(t, defaultRule, audit.entries[0].Rule)
+	})
+
+	t.Run("check only lets explicit rules deny", func(t *testing.T) {
+		t.Parallel()
+		audit := &fakeAuditLog{}
+		service := NewPermissionService("/repo", false, nil,
+			WithPolicy(&Policy{Default: EffectDeny, Paths: map[string]map[string]Effect{"read": {".env": EffectDeny}}}),
+			WithAuditLog(audit))
+
+		assert.True(t, service.Check(CreatePermissionRequest{
+			ToolCallID: "call-1",
+			ToolName:   "view",
+			Action:     "read",
+			Params:     map[string]string{"file_path": "/repo/main.go"},
+		}))
+		assert.False(t, service.Check(CreatePermissionRequest{
+			ToolCallID: "call-2",
+			ToolName:   "view",
+			Action:     "read",
+			Params:     map[string]string{"file_path": ".env"},
+		}))
+
+		// Only the decision a rule made is audited
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, SourcePolicy, audit.entries[0].Source)
+		assert.Equal(t, ".env", audit.entries[0].Subject)
+		_, denied := service.PolicyDenial("call-2")
+		assert.True(t, denied)
+	})
+}
@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/samber/lo"
 	"github.com/trankhanh040147/revcli/internal/config"
 	"gopkg.in/yaml.v3"
 )
 
-// getConfigPath returns the path to the config file
-func getConfigPath() (string, error) {
+// LegacyConfigPath returns the path to the YAML config revcli used before revcli.json
+func LegacyConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -18,57 +21,41 @@ func getConfigPath() (string, error) {
 	return filepath.Join(homeDir, config.ConfigDirName, config.AppDirName, "config.yaml"), nil
 }
 
-// LoadConfig loads the configuration from ~/.config/revcli/config.yaml
+// LoadConfig loads the legacy configuration from ~/.config/revcli/config.yaml, with defaults applied
 func LoadConfig() (*Config, error) {
-	configPath, err := getConfigPath()
+	config, err := readLegacyConfig()
 	if err != nil {
 		return nil, err
 	}
-
-	// If config file doesn't exist, return default config
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	if config == nil {
 		return defaultConfig(), nil
 	}
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
 	// Apply defaults for missing Gemini config
-	applyGeminiDefaults(&config)
+	applyGeminiDefaults(config)
 
-	return &config, nil
+	return config, nil
 }
 
-// SaveConfig saves the configuration to ~/.config/revcli/config.yaml
-func SaveConfig(config *Config) error {
-	configPath, err := getConfigPath()
+// readLegacyConfig reads ~/.config/revcli/config.yaml as written; nil when there is none
+func readLegacyConfig() (*Config, error) {
+	configPath, err := LegacyConfigPath()
 	if err != nil {
-		return err
-	}
-
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+		return nil, err
 	}
 
-	data, err := yaml.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
-
-	return nil
+	return &config, nil
 }
 
 // applyGeminiDefaults applies default values to Gemini configuration
@@ -134,34 +121,94 @@ func defaultSafetySettings() *SafetySettings {
 	}
 }
 
-// GetDefaultPreset returns the default preset name from config, or empty string if not set
+// GetDefaultPreset returns the default preset of the revcli config (options.default_preset), or empty string if not set
 func GetDefaultPreset() (string, error) {
-	config, err := LoadConfig()
+	cwd, err := os.Getwd()
 	if err != nil {
 		return "", err
 	}
-	return config.DefaultPreset, nil
-}
-
-// SetDefaultPreset sets the default preset in the config file
-func SetDefaultPreset(presetName string) error {
-	config, err := LoadConfig()
+	opts, err := config.LoadOptions(cwd, "")
 	if err != nil {
-		return err
+		return "", err
 	}
+	return opts.DefaultPreset, nil
+}
 
-	config.DefaultPreset = presetName
-	return SaveConfig(config)
+// SetDefaultPreset sets the default preset in the global revcli config
+func SetDefaultPreset(presetName string) error {
+	return config.SetGlobalOption("default_preset", presetName)
 }
 
-// ClearDefaultPreset removes the default preset from the config file
+// ClearDefaultPreset removes the default preset from the global revcli config
 func ClearDefaultPreset() error {
-	config, err := LoadConfig()
-	if err != nil {
-		return err
+	return config.UnsetGlobalOption("default_preset")
+}
+
+// MigrationField is a setting of the legacy YAML config and the revcli.json field it moves to
+type MigrationField struct {
+	Key   string
+	Value any
+}
+
+// harmCategories are the Gemini harm categories the legacy safety threshold applied to
+var harmCategories = []string{
+	"HARM_CATEGORY_HARASSMENT",
+	"HARM_CATEGORY_HATE_SPEECH",
+	"HARM_CATEGORY_SEXUALLY_EXPLICIT",
+	"HARM_CATEGORY_DANGEROUS_CONTENT",
+	"HARM_CATEGORY_CIVIC_INTEGRITY",
+}
+
+// harmBlockThresholds maps the legacy safety thresholds to Gemini's
+var harmBlockThresholds = map[string]string{
+	"HIGH":             "BLOCK_ONLY_HIGH",
+	"MEDIUM_AND_ABOVE": "BLOCK_MEDIUM_AND_ABOVE",
+	"LOW_AND_ABOVE":    "BLOCK_LOW_AND_ABOVE",
+	"NONE":             "BLOCK_NONE",
+	"OFF":              "OFF",
+}
+
+// LegacyMigration returns the settings of the legacy YAML config as revcli.json fields. Only settings
+// the file sets are returned, not the legacy defaults; found is false when there is no legacy config.
+func LegacyMigration() (fields []MigrationField, found bool, err error) {
+	legacy, err := readLegacyConfig()
+	if err != nil || legacy == nil {
+		return nil, false, err
 	}
 
-	config.DefaultPreset = ""
-	return SaveConfig(config)
+	if legacy.DefaultPreset != "" {
+		fields = append(fields, MigrationField{"options.default_preset", legacy.DefaultPreset})
+	}
+	if legacy.Gemini != nil && legacy.Gemini.ModelParams != nil {
+		params := legacy.Gemini.ModelParams
+		if params.Temperature != 0 {
+			fields = append(fields, MigrationField{"options.generation.temperature", widen(params.Temperature)})
+		}
+		if params.TopP != 0 {
+			fields = append(fields, MigrationField{"options.generation.top_p", widen(params.TopP)})
+		}
+		if params.TopK != 0 {
+			fields = append(fields, MigrationField{"options.generation.top_k", params.TopK})
+		}
+	}
+	if legacy.Gemini != nil && legacy.Gemini.SafetySettings != nil && legacy.Gemini.SafetySettings.Threshold != "" {
+		threshold, ok := harmBlockThresholds[strings.ToUpper(legacy.Gemini.SafetySettings.Threshold)]
+		if !ok {
+			return nil, true, fmt.Errorf("unknown safety threshold %q (supported: HIGH, MEDIUM_AND_ABOVE, LOW_AND_ABOVE, NONE, OFF)", legacy.Gemini.SafetySettings.Threshold)
+		}
+		settings := lo.Map(harmCategories, func(category string, _ int) map[string]string {
+			return map[string]string{"category": category, "threshold": threshold}
+		})
+		fields = append(fields, MigrationField{"providers.gemini.provider_options.safety_settings", settings})
+	}
+	if legacy.Secrets != nil {
+		fields = append(fields, MigrationField{"options.secrets", legacy.Secrets})
+	}
+	return fields, true, nil
 }
 
+// widen converts a float32 to the float64 with the same shortest decimal form (0.3, not 0.30000001192092896)
+func widen(f float32) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'f', -1, 32), 64)
+	return v
+}
@@ -1,6 +1,10 @@
 package preset
 
-import "gopkg.in/yaml.v3"
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/trankhanh040147/revcli/internal/filter"
+)
 
 // Preset defines a review preset configuration
 type Preset struct {
@@ -55,8 +59,9 @@ func (p *Preset) MarshalYAML() (interface{}, error) {
 
 // Config defines the revcli configuration
 type Config struct {
-	DefaultPreset string        `yaml:"default_preset,omitempty"`
-	Gemini        *GeminiConfig `yaml:"gemini,omitempty"`
+	DefaultPreset string                `yaml:"default_preset,omitempty"`
+	Gemini        *GeminiConfig         `yaml:"gemini,omitempty"`
+	Secrets       *filter.SecretsConfig `yaml:"secrets,omitempty"`
 }
 
 // GeminiConfig defines Gemini API client configuration
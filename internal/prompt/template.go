@@ -3,6 +3,9 @@ package prompt
 import (
 	"fmt"
 	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/diff"
+	"github.com/trankhanh040147/revcli/internal/findings"
 )
 
 // SystemPrompt defines the Senior Go Engineer persona
@@ -81,29 +84,40 @@ Any clarifying questions about the intent of the code.
 Be concise but thorough. Focus on the most impactful feedback. If the code looks good, acknowledge it and highlight any particularly well-written sections.`
 
 // BuildReviewPrompt constructs the full prompt for code review
-func BuildReviewPrompt(rawDiff string, fileContents map[string]string) string {
-	return BuildReviewPromptWithPruning(rawDiff, fileContents, nil)
+func BuildReviewPrompt(files []*diff.FileDiff, fileContents map[string]string) string {
+	return BuildReviewPromptWithPruning(files, fileContents, nil)
 }
 
 // BuildReviewPromptWithPruning constructs the full prompt for code review with pruning support
-func BuildReviewPromptWithPruning(rawDiff string, fileContents map[string]string, prunedFiles map[string]string) string {
+func BuildReviewPromptWithPruning(files []*diff.FileDiff, fileContents map[string]string, prunedFiles map[string]string) string {
 	var builder strings.Builder
 
 	builder.WriteString("## Code Review Request\n\n")
 	builder.WriteString("Please review the following code changes.\n\n")
 
+	// List the changed files so deletions, renames and binary files are visible without content
+	builder.WriteString("### Changed Files\n\n")
+	for _, f := range files {
+		builder.WriteString(fmt.Sprintf("- `%s` (%s)\n", f.Path(), f.Describe()))
+	}
+	builder.WriteString("\n")
+
 	// Add the diff
 	builder.WriteString("### Git Diff (Changes)\n\n")
 	builder.WriteString("```diff\n")
-	builder.WriteString(rawDiff)
+	builder.WriteString(diff.Format(files))
 	builder.WriteString("\n```\n\n")
 
-	// Add file contents for context
+	// Add file contents for context, in diff order
 	if len(fileContents) > 0 {
 		builder.WriteString("### Full File Context\n\n")
 		builder.WriteString("Below are the complete contents of the modified files for additional context:\n\n")
 
-		for path, content := range fileContents {
+		for _, path := range diff.Paths(files) {
+			content, ok := fileContents[path]
+			if !ok {
+				continue
+			}
 			// Check if file is pruned
 			if prunedFiles != nil {
 				if summary, pruned := prunedFiles[path]; pruned {
@@ -114,21 +128,7 @@ func BuildReviewPromptWithPruning(rawDiff string, fileContents map[string]string
 				}
 			}
 
-			// Determine language for syntax highlighting
-			lang := getLanguageFromPath(path)
-
-			builder.WriteString(fmt.Sprintf("#### File: `%s`\n\n", path))
-			builder.WriteString(fmt.Sprintf("```%s\n", lang))
-
-			// Truncate very large files
-			if len(content) > 50000 {
-				builder.WriteString(content[:50000])
-				builder.WriteString("\n\n... (file truncated due to size) ...\n")
-			} else {
-				builder.WriteString(content)
-			}
-
-			builder.WriteString("\n```\n\n")
+			writeFileContext(&builder, path, content)
 		}
 	}
 
@@ -138,9 +138,60 @@ func BuildReviewPromptWithPruning(rawDiff string, fileContents map[string]string
 	return builder.String()
 }
 
+// previousFindingsInstructions tells the model how to re-check the last review's findings
+const previousFindingsInstructions = `For each previous finding, decide whether the changes above resolved it, left it open, or regressed it (made it worse or brought it back). A finding in code the changes do not touch is still open.
+
+Start your review with a "### 🔁 Previous Findings" section listing every previous finding once, as "- **Resolved**: ...", "- **Still open**: ..." or "- **Regressed**: ...", keeping its file reference. Then review the changes above as usual, and repeat each still open or regressed finding in its severity section, so those sections list everything that still needs attention. Do not repeat resolved findings.
+`
+
+// BuildPreviousFindingsSection asks an incremental review to re-check the findings of the review of previousHead
+func BuildPreviousFindingsSection(previousHead string, previous []findings.Finding) string {
+	var builder strings.Builder
+	builder.WriteString("\n### Previous Findings\n\n")
+	builder.WriteString(fmt.Sprintf("This is an incremental review: the diff only covers the commits since the last review of `%s`. That review reported:\n\n", previousHead))
+	if len(previous) == 0 {
+		builder.WriteString("- (no findings)\n")
+	}
+	for i, f := range previous {
+		location := ""
+		if f.HasLocation() {
+			location = fmt.Sprintf("`%s:%d` ", f.File, f.Line)
+		}
+		builder.WriteString(fmt.Sprintf("%d. [%s] %s%s\n", i+1, f.Severity, location, f.Message))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(previousFindingsInstructions)
+	return builder.String()
+}
+
+// followUpPrefix introduces the question of a follow-up prompt
+const followUpPrefix = "Follow-up question about the code review:\n\n"
+
 // BuildFollowUpPrompt constructs a prompt for follow-up questions
 func BuildFollowUpPrompt(question string) string {
-	return fmt.Sprintf("Follow-up question about the code review:\n\n%s", question)
+	return followUpPrefix + question
+}
+
+// FollowUpQuestion returns the question of a follow-up prompt, with or without restated context
+func FollowUpQuestion(prompt string) (string, bool) {
+	_, question, ok := strings.Cut(prompt, followUpPrefix)
+	return question, ok
+}
+
+// BuildFollowUpPromptWithContext restates the review context (with pruned files summarized),
+// the previous review and the conversation before a follow-up question
+func BuildFollowUpPromptWithContext(reviewPrompt, review, transcript, question string) string {
+	var builder strings.Builder
+	builder.WriteString(reviewPrompt)
+	builder.WriteString("\n### Previous Review\n\n")
+	builder.WriteString(review)
+	builder.WriteString("\n\n")
+	if transcript != "" {
+		builder.WriteString("### Conversation So Far\n\n")
+		builder.WriteString(transcript)
+	}
+	builder.WriteString(BuildFollowUpPrompt(question))
+	return builder.String()
 }
 
 // getLanguageFromPath returns the language identifier for syntax highlighting
@@ -175,12 +226,43 @@ func getLanguageFromPath(path string) string {
 	}
 }
 
+// writeFileContext writes a file's full content section, truncating very large files
+func writeFileContext(builder *strings.Builder, path, content string) {
+	// Determine language for syntax highlighting
+	lang := getLanguageFromPath(path)
+
+	builder.WriteString(fmt.Sprintf("#### File: `%s`\n\n", path))
+	builder.WriteString(fmt.Sprintf("```%s\n", lang))
+
+	// Truncate very large files
+	if len(content) > 50000 {
+		builder.WriteString(content[:50000])
+		builder.WriteString("\n\n... (file truncated due to size) ...\n")
+	} else {
+		builder.WriteString(content)
+	}
+
+	builder.WriteString("\n```\n\n")
+}
+
+// EstimateFileContextTokens estimates the tokens a file's full content section adds to the review prompt
+func EstimateFileContextTokens(path, content string) int {
+	var builder strings.Builder
+	writeFileContext(&builder, path, content)
+	return EstimateTokens(builder.String())
+}
+
 // EstimateTokens provides a rough estimate of tokens in the prompt
 // This is a simple heuristic: ~4 characters per token for English text
 func EstimateTokens(text string) int {
 	return len(text) / 4
 }
 
+// EstimateFileTokens estimates the tokens a file adds to the prompt: its diff plus its content
+func EstimateFileTokens(f *diff.FileDiff, content string) int {
+	return EstimateTokens(f.String()) + EstimateTokens(content)
+}
+
 // MaxTokenWarning returns a warning if the prompt is too large
 func MaxTokenWarning(prompt string, maxTokens int) string {
 	estimated := EstimateTokens(prompt)
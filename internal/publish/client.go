@@ -0,0 +1,80 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// client is a minimal JSON REST client shared by the forge publishers
+type client struct {
+	baseURL    string
+	headers    map[string]string
+	httpClient *http.Client
+	dryRun     io.Writer
+}
+
+// newClient creates a client for the given API root
+func newClient(opts Options, headers map[string]string) *client {
+	return &client{
+		baseURL:    strings.TrimSuffix(opts.BaseURL, "/"),
+		headers:    headers,
+		httpClient: opts.HTTPClient,
+		dryRun:     opts.DryRun,
+	}
+}
+
+// do sends a JSON request and decodes the response into out (if non-nil).
+// In dry-run mode the payload is printed instead and no request is made.
+func (c *client) do(ctx context.Context, method, path string, payload, out any) error {
+	url := c.baseURL + path
+
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = sonic.ConfigStd.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode request for %s: %w", path, err)
+		}
+	}
+
+	if c.dryRun != nil {
+		fmt.Fprintf(c.dryRun, "%s %s\n%s\n\n", method, url, body)
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response from %s: %w", path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		if err := sonic.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode response from %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package publish
+
+// Default API endpoints
+const (
+	GitHubAPIURL = "https://api.github.com"
+	GitLabAPIURL = "https://gitlab.com/api/v4"
+
+	gitHubAPIVersion = "2022-11-28"
+)
@@ -0,0 +1,64 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+)
+
+// gitHub publishes a pull request review with inline comments
+type gitHub struct {
+	client *client
+	repo   string
+	number int
+}
+
+type gitHubReviewRequest struct {
+	CommitID string                `json:"commit_id,omitempty"`
+	Body     string                `json:"body"`
+	Event    string                `json:"event"`
+	Comments []gitHubReviewComment `json:"comments"`
+}
+
+type gitHubReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+// newGitHub creates a GitHub publisher
+func newGitHub(opts Options) *gitHub {
+	if opts.BaseURL == "" {
+		opts.BaseURL = GitHubAPIURL
+	}
+	return &gitHub{
+		client: newClient(opts, map[string]string{
+			"Authorization":        "Bearer " + opts.Token,
+			"Accept":               "application/vnd.github+json",
+			"X-GitHub-Api-Version": gitHubAPIVersion,
+		}),
+		repo:   opts.Repo,
+		number: opts.Number,
+	}
+}
+
+// Publish creates a single "COMMENT" review containing all inline comments
+func (g *gitHub) Publish(ctx context.Context, review *Review) error {
+	payload := gitHubReviewRequest{
+		CommitID: review.HeadSHA,
+		Body:     review.Body,
+		Event:    "COMMENT",
+		Comments: lo.Map(review.Comments, func(c Comment, _ int) gitHubReviewComment {
+			return gitHubReviewComment{Path: c.Path, Line: c.Line, Side: "RIGHT", Body: c.Body}
+		}),
+	}
+
+	path := fmt.Sprintf("/repos/%s/pulls/%d/reviews", g.repo, g.number)
+	if err := g.client.do(ctx, http.MethodPost, path, payload, nil); err != nil {
+		return fmt.Errorf("failed to publish GitHub review: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+// gitLab publishes merge request discussions anchored to diff lines
+type gitLab struct {
+	client  *client
+	project string
+	iid     int
+}
+
+type gitLabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+type gitLabMergeRequest struct {
+	DiffRefs gitLabDiffRefs `json:"diff_refs"`
+}
+
+type gitLabPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	HeadSHA      string `json:"head_sha"`
+	StartSHA     string `json:"start_sha"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+	// OldLine is required for unchanged context lines
+	OldLine int `json:"old_line,omitempty"`
+}
+
+type gitLabDiscussionRequest struct {
+	Body     string          `json:"body"`
+	Position *gitLabPosition `json:"position,omitempty"`
+}
+
+type gitLabNoteRequest struct {
+	Body string `json:"body"`
+}
+
+// newGitLab creates a GitLab publisher
+func newGitLab(opts Options) *gitLab {
+	if opts.BaseURL == "" {
+		opts.BaseURL = GitLabAPIURL
+	}
+	return &gitLab{
+		client:  newClient(opts, map[string]string{"PRIVATE-TOKEN": opts.Token}),
+		project: url.PathEscape(opts.Repo),
+		iid:     opts.Number,
+	}
+}
+
+// Publish posts one discussion per comment and a summary note. Comments GitLab
+// rejects are listed in the summary instead, so one bad position loses nothing.
+func (g *gitLab) Publish(ctx context.Context, review *Review) error {
+	mrPath := fmt.Sprintf("/projects/%s/merge_requests/%d", g.project, g.iid)
+
+	refs, err := g.diffRefs(ctx, mrPath, review)
+	if err != nil {
+		return err
+	}
+
+	var failed []findings.Finding
+	for _, c := range review.Comments {
+		oldPath := c.Anchor.OldPath
+		if oldPath == "" {
+			oldPath = c.Path
+		}
+		payload := gitLabDiscussionRequest{
+			Body: c.Body,
+			Position: &gitLabPosition{
+				PositionType: "text",
+				BaseSHA:      refs.BaseSHA,
+				HeadSHA:      refs.HeadSHA,
+				StartSHA:     refs.StartSHA,
+				OldPath:      oldPath,
+				NewPath:      c.Path,
+				NewLine:      c.Line,
+				OldLine:      c.Anchor.OldLine,
+			},
+		}
+		if err := g.client.do(ctx, http.MethodPost, mrPath+"/discussions", payload, nil); err != nil {
+			slog.Warn("GitLab rejected inline comment, adding it to the summary", "path", c.Path, "line", c.Line, "error", err)
+			failed = append(failed, c.Finding)
+		}
+	}
+
+	body := review.Body
+	if len(failed) > 0 {
+		var sb strings.Builder
+		sb.WriteString(body)
+		sb.WriteString("\n\nFindings that could not be posted inline:\n")
+		writeFindingList(&sb, failed)
+		body = sb.String()
+	}
+	if err := g.client.do(ctx, http.MethodPost, mrPath+"/notes", gitLabNoteRequest{Body: body}, nil); err != nil {
+		return fmt.Errorf("failed to publish GitLab summary: %w", err)
+	}
+	return nil
+}
+
+// diffRefs returns the merge request's diff refs, which GitLab requires for
+// positioned comments. Dry runs use the locally computed refs instead.
+func (g *gitLab) diffRefs(ctx context.Context, mrPath string, review *Review) (gitLabDiffRefs, error) {
+	if g.client.dryRun != nil {
+		return gitLabDiffRefs{BaseSHA: review.BaseSHA, HeadSHA: review.HeadSHA, StartSHA: review.BaseSHA}, nil
+	}
+
+	var mr gitLabMergeRequest
+	if err := g.client.do(ctx, http.MethodGet, mrPath, nil, &mr); err != nil {
+		return gitLabDiffRefs{}, fmt.Errorf("failed to fetch GitLab merge request: %w", err)
+	}
+	if mr.DiffRefs.HeadSHA != review.HeadSHA && review.HeadSHA != "" {
+		return gitLabDiffRefs{}, fmt.Errorf("merge request head %s does not match local HEAD %s; push your branch first", mr.DiffRefs.HeadSHA, review.HeadSHA)
+	}
+	return mr.DiffRefs, nil
+}
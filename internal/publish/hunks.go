@@ -0,0 +1,80 @@
+package publish
+
+import (
+	"github.com/trankhanh040147/revcli/internal/diff"
+)
+
+// diffLine is a new-side line shown in a hunk
+type diffLine struct {
+	kind diff.LineKind
+	// oldLine is the old-side line number of a context line (0 for added lines)
+	oldLine int
+}
+
+// fileIndex holds the commentable lines of one file
+type fileIndex struct {
+	oldPath string
+	lines   map[int]diffLine
+}
+
+// DiffIndex records which new-side lines of each file are part of a diff.
+// Forges only accept inline comments on those lines.
+type DiffIndex map[string]*fileIndex
+
+// Anchor locates a comment in the diff the way forges expect it
+type Anchor struct {
+	// OldPath is the path before the change (differs from the new path for renames)
+	OldPath string
+	// OldLine is set for unchanged context lines, which GitLab anchors on both sides
+	OldLine int
+}
+
+// NewDiffIndex builds an index from unified diff output
+func NewDiffIndex(rawDiff string) DiffIndex {
+	index := DiffIndex{}
+
+	// An unparsable diff yields an empty index, so every finding goes to the summary
+	files, _ := diff.Parse(rawDiff)
+	for _, f := range files {
+		// Deleted files have no new side to comment on
+		if f.NewPath == "" {
+			continue
+		}
+		fi := &fileIndex{oldPath: f.OldPath, lines: map[int]diffLine{}}
+		if fi.oldPath == "" {
+			fi.oldPath = f.NewPath
+		}
+		for _, h := range f.Hunks {
+			for _, l := range h.Lines {
+				switch l.Kind {
+				case diff.LineAdded:
+					fi.lines[l.NewLine] = diffLine{kind: l.Kind}
+				case diff.LineContext:
+					fi.lines[l.NewLine] = diffLine{kind: l.Kind, oldLine: l.OldLine}
+				}
+			}
+		}
+		index[f.NewPath] = fi
+	}
+
+	return index
+}
+
+// Contains returns true if the new-side line of path is inside a hunk
+func (d DiffIndex) Contains(path string, line int) bool {
+	_, ok := d.Anchor(path, line)
+	return ok
+}
+
+// Anchor returns the diff position of the new-side line of path, if it is inside a hunk
+func (d DiffIndex) Anchor(path string, line int) (Anchor, bool) {
+	fi, ok := d[path]
+	if !ok || line <= 0 {
+		return Anchor{}, false
+	}
+	l, ok := fi.lines[line]
+	if !ok {
+		return Anchor{}, false
+	}
+	return Anchor{OldPath: fi.oldPath, OldLine: l.oldLine}, true
+}
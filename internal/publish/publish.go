@@ -0,0 +1,177 @@
+// Package publish posts review findings as line-anchored comments on a
+// GitHub pull request or GitLab merge request.
+package publish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+// Target is the forge that review comments are published to
+type Target string
+
+const (
+	TargetGitHub Target = "github"
+	TargetGitLab Target = "gitlab"
+)
+
+// ParseTarget validates a --publish value
+func ParseTarget(name string) (Target, error) {
+	switch t := Target(name); t {
+	case TargetGitHub, TargetGitLab:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unsupported publish target %q (supported: github, gitlab)", name)
+	}
+}
+
+// Comment is a review comment anchored to a line on the new side of the diff
+type Comment struct {
+	Path string
+	Line int
+	Body string
+	// Anchor holds the old-side position, needed by GitLab for renames and context lines
+	Anchor Anchor
+	// Finding is the source finding, listed in the summary if the comment cannot be posted
+	Finding findings.Finding
+}
+
+// Review is the set of comments plus a summary posted for one review run
+type Review struct {
+	// BaseSHA is the merge base of the reviewed range
+	BaseSHA string
+	// HeadSHA is the commit the comments are anchored to
+	HeadSHA string
+	// Body is the summary, including findings that could not be anchored
+	Body string
+	// Comments are the line-anchored findings
+	Comments []Comment
+}
+
+// Publisher posts a review to a forge
+type Publisher interface {
+	Publish(ctx context.Context, review *Review) error
+}
+
+// Options configures a publisher
+type Options struct {
+	Target Target
+	// BaseURL is the REST API root (e.g. https://api.github.com, https://gitlab.com/api/v4)
+	BaseURL string
+	Token   string
+	// Repo is "owner/name" on GitHub, or the project ID or path on GitLab
+	Repo string
+	// Number is the pull request number (GitHub) or merge request IID (GitLab)
+	Number int
+	// DryRun, if set, receives the request payloads instead of sending them
+	DryRun     io.Writer
+	HTTPClient *http.Client
+}
+
+// New creates a publisher for the configured target
+func New(opts Options) (Publisher, error) {
+	if opts.Repo == "" {
+		return nil, fmt.Errorf("publish target %s requires a repository (--repo)", opts.Target)
+	}
+	if opts.Number <= 0 {
+		return nil, fmt.Errorf("publish target %s requires a pull/merge request number (--pr)", opts.Target)
+	}
+	if opts.Token == "" && opts.DryRun == nil {
+		return nil, fmt.Errorf("publish target %s requires an API token", opts.Target)
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	switch opts.Target {
+	case TargetGitHub:
+		return newGitHub(opts), nil
+	case TargetGitLab:
+		return newGitLab(opts), nil
+	default:
+		return nil, fmt.Errorf("unsupported publish target %q", opts.Target)
+	}
+}
+
+// BuildReview anchors findings to the lines of rawDiff; findings that point
+// outside the diff are listed in the review summary instead
+func BuildReview(report *findings.Report, rawDiff, baseSHA, headSHA string) *Review {
+	index := NewDiffIndex(rawDiff)
+
+	anchored, unanchored := lo.FilterReject(report.Findings, func(f findings.Finding, _ int) bool {
+		return index.Contains(f.File, f.Line)
+	})
+
+	return &Review{
+		BaseSHA: baseSHA,
+		HeadSHA: headSHA,
+		Body:    summaryBody(report, unanchored),
+		Comments: lo.Map(anchored, func(f findings.Finding, _ int) Comment {
+			anchor, _ := index.Anchor(f.File, f.Line)
+			return Comment{Path: f.File, Line: f.Line, Body: commentBody(f), Anchor: anchor, Finding: f}
+		}),
+	}
+}
+
+// commentBody renders a finding as markdown for an inline comment
+func commentBody(f findings.Finding) string {
+	var sb strings.Builder
+	sb.WriteString(severityLabel(f.Severity))
+	if f.Category != "" {
+		fmt.Fprintf(&sb, " (%s)", f.Category)
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(f.Message)
+	if len(f.Models) > 0 {
+		fmt.Fprintf(&sb, "\n\n_Reported by %s (confidence %.0f%%)_", strings.Join(f.Models, ", "), f.Confidence*100)
+	}
+	if f.Suggestion != "" {
+		sb.WriteString("\n\n```\n")
+		sb.WriteString(f.Suggestion)
+		sb.WriteString("\n```")
+	}
+	return sb.String()
+}
+
+// summaryBody renders the review summary with findings that have no diff anchor
+func summaryBody(report *findings.Report, unanchored []findings.Finding) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**revcli review**: %d critical, %d warning, %d refactoring",
+		report.Count(findings.SeverityCritical),
+		report.Count(findings.SeverityWarning),
+		report.Count(findings.SeverityRefactoring))
+
+	if len(unanchored) > 0 {
+		sb.WriteString("\n\nFindings outside the diff:\n")
+		writeFindingList(&sb, unanchored)
+	}
+	return sb.String()
+}
+
+// writeFindingList renders findings as a markdown list
+func writeFindingList(sb *strings.Builder, list []findings.Finding) {
+	for _, f := range list {
+		fmt.Fprintf(sb, "\n- %s: %s", severityLabel(f.Severity), f.Message)
+	}
+}
+
+// severityLabel returns the label used in comments (matching the reviewer's section headings)
+func severityLabel(s findings.Severity) string {
+	switch s {
+	case findings.SeverityCritical:
+		return "🔴 **Critical**"
+	case findings.SeverityWarning:
+		return "🟠 **Warning**"
+	case findings.SeverityRefactoring:
+		return "🟡 **Refactoring**"
+	default:
+		return "**Finding**"
+	}
+}
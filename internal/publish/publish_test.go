@@ -0,0 +1,201 @@
+package publish
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,3 +10,4 @@ func main() {
+ 	a := 1
++	b := 2
+ 	c := 3
+ 	d := 4
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package old
+-
+`
+
+func sampleReport() *findings.Report {
+	return &findings.Report{Findings: []findings.Finding{
+		{Severity: findings.SeverityCritical, File: "main.go", Line: 11, Message: "b is unused"},
+		{Severity: findings.SeverityWarning, File: "main.go", Line: 40, Message: "outside the hunk"},
+		{Severity: findings.SeverityWarning, File: "old.go", Line: 1, Message: "deleted file"},
+	}}
+}
+
+// recorder is a local stand-in for a forge API that records requests
+type recorder struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   []string
+}
+
+func (r *recorder) handler(responses map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, req.Method+" "+req.URL.EscapedPath())
+		r.bodies = append(r.bodies, string(body))
+		r.mu.Unlock()
+		_, _ = w.Write([]byte(responses[req.Method+" "+req.URL.EscapedPath()]))
+	}
+}
+
+func TestDiffIndex(t *testing.T) {
+	t.Parallel()
+
+	index := NewDiffIndex(sampleDiff)
+	require.True(t, index.Contains("main.go", 10))
+	require.True(t, index.Contains("main.go", 13))
+	require.False(t, index.Contains("main.go", 14))
+	require.False(t, index.Contains("old.go", 1))
+}
+
+func TestBuildReview(t *testing.T) {
+	t.Parallel()
+
+	review := BuildReview(sampleReport(), sampleDiff, "base", "head")
+	require.Len(t, review.Comments, 1)
+	require.Equal(t, "main.go", review.Comments[0].Path)
+	require.Equal(t, 11, review.Comments[0].Line)
+	require.Contains(t, review.Body, "outside the hunk")
+	require.Contains(t, review.Body, "deleted file")
+	require.NotContains(t, review.Comments[0].Body, "Reported by")
+
+	consensus := sampleReport()
+	consensus.Findings[0].Models = []string{"gpt-5", "claude-sonnet-4"}
+	consensus.Findings[0].Confidence = 0.67
+	review = BuildReview(consensus, sampleDiff, "base", "head")
+	require.Contains(t, review.Comments[0].Body, "_Reported by gpt-5, claude-sonnet-4 (confidence 67%)_")
+}
+
+func TestGitHubPublish(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	srv := httptest.NewServer(rec.handler(nil))
+	defer srv.Close()
+
+	pub, err := New(Options{Target: TargetGitHub, BaseURL: srv.URL, Token: "t", Repo: "acme/app", Number: 7})
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(t.Context(), BuildReview(sampleReport(), sampleDiff, "base", "head")))
+
+	require.Equal(t, []string{"POST /repos/acme/app/pulls/7/reviews"}, rec.requests)
+	require.Contains(t, rec.bodies[0], `"commit_id": "head"`)
+	require.Contains(t, rec.bodies[0], `"line": 11`)
+}
+
+func TestGitLabPublish(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	mrPath := "/projects/group%2Fapp/merge_requests/3"
+	srv := httptest.NewServer(rec.handler(map[string]string{
+		"GET " + mrPath: `{"diff_refs":{"base_sha":"base","head_sha":"head","start_sha":"start"}}`,
+	}))
+	defer srv.Close()
+
+	pub, err := New(Options{Target: TargetGitLab, BaseURL: srv.URL, Token: "t", Repo: "group/app", Number: 3})
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(t.Context(), BuildReview(sampleReport(), sampleDiff, "base", "head")))
+
+	require.Equal(t, []string{
+		"GET " + mrPath,
+		"POST " + mrPath + "/discussions",
+		"POST " + mrPath + "/notes",
+	}, rec.requests)
+	require.Contains(t, rec.bodies[1], `"start_sha": "start"`)
+	require.Contains(t, rec.bodies[1], `"new_line": 11`)
+}
+
+const renameDiff = `diff --git a/old_name.go b/new_name.go
+similarity index 90%
+rename from old_name.go
+rename to new_name.go
+--- a/old_name.go
++++ b/new_name.go
+@@ -5,3 +5,3 @@
+ 	x := 1
+-	y := 2
++	y := 3
+ 	z := 4
+`
+
+func TestDiffIndexAnchor(t *testing.T) {
+	t.Parallel()
+
+	index := NewDiffIndex(renameDiff)
+	added, ok := index.Anchor("new_name.go", 6)
+	require.True(t, ok)
+	require.Equal(t, Anchor{OldPath: "old_name.go"}, added)
+
+	context, ok := index.Anchor("new_name.go", 7)
+	require.True(t, ok)
+	require.Equal(t, Anchor{OldPath: "old_name.go", OldLine: 7}, context)
+
+	_, ok = index.Anchor("old_name.go", 6)
+	require.False(t, ok)
+}
+
+func TestGitLabPublishPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		switch {
+		case req.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"diff_refs":{"base_sha":"base","head_sha":"head","start_sha":"start"}}`))
+		case bytes.Contains(body, []byte(`"new_line": 6`)):
+			http.Error(w, `{"message":"line_code can't be blank"}`, http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	report := &findings.Report{Findings: []findings.Finding{
+		{Severity: findings.SeverityWarning, File: "new_name.go", Line: 6, Message: "rejected by the forge"},
+		{Severity: findings.SeverityWarning, File: "new_name.go", Line: 7, Message: "on a context line"},
+	}}
+	pub, err := New(Options{Target: TargetGitLab, BaseURL: srv.URL, Token: "t", Repo: "group/app", Number: 3})
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(t.Context(), BuildReview(report, renameDiff, "base", "head")))
+
+	require.Len(t, bodies, 4)
+	require.Contains(t, bodies[1], `"old_path": "old_name.go"`)
+	require.Contains(t, bodies[2], `"old_line": 7`)
+	require.NotContains(t, bodies[1], `"old_line"`)
+	// The rejected comment moves to the summary note
+	require.Contains(t, bodies[3], "could not be posted inline")
+	require.Contains(t, bodies[3], "rejected by the forge")
+	require.NotContains(t, bodies[3], "on a context line")
+}
+
+func TestPublishDryRun(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	pub, err := New(Options{Target: TargetGitHub, Repo: "acme/app", Number: 7, DryRun: &out})
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(t.Context(), BuildReview(sampleReport(), sampleDiff, "base", "head")))
+	require.Contains(t, out.String(), "POST "+GitHubAPIURL+"/repos/acme/app/pulls/7/reviews")
+}
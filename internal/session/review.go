@@ -0,0 +1,96 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/db"
+)
+
+// Review records what a review session reviewed
+type Review struct {
+	SessionID string
+	// Repo is the repository root the review ran in
+	Repo string
+	// Branch is the checked out branch ("" when HEAD was detached)
+	Branch string
+	// BaseRef is the base as given on the command line, or BaseCommit when none was
+	BaseRef    string
+	BaseCommit string
+	HeadCommit string
+	// DiffHash is the SHA-256 of the reviewed diff
+	DiffHash      string
+	Preset        string
+	PresetReplace bool
+	// Intent is the JSON-encoded review intent ("" when none was collected)
+	Intent    string
+	CreatedAt int64
+}
+
+// IsCommitted reports whether the review covered committed changes, so its diff can be rebuilt
+func (r Review) IsCommitted() bool {
+	return r.BaseCommit != r.HeadCommit
+}
+
+func (s *service) CreateReview(ctx context.Context, review Review) (Review, error) {
+	dbReview, err := s.q.CreateSessionReview(ctx, db.CreateSessionReviewParams{
+		SessionID:     review.SessionID,
+		Repo:          review.Repo,
+		Branch:        review.Branch,
+		BaseRef:       review.BaseRef,
+		BaseCommit:    review.BaseCommit,
+		HeadCommit:    review.HeadCommit,
+		DiffHash:      review.DiffHash,
+		Preset:        review.Preset,
+		PresetReplace: lo.Ternary[int64](review.PresetReplace, 1, 0),
+		Intent:        sql.NullString{String: review.Intent, Valid: review.Intent != ""},
+	})
+	if err != nil {
+		return Review{}, err
+	}
+	return fromDBReview(dbReview), nil
+}
+
+func (s *service) GetReview(ctx context.Context, sessionID string) (Review, error) {
+	dbReview, err := s.q.GetSessionReview(ctx, sessionID)
+	if err != nil {
+		return Review{}, err
+	}
+	return fromDBReview(dbReview), nil
+}
+
+// ListReviews lists the reviews of a repository, newest first
+func (s *service) ListReviews(ctx context.Context, repo string) ([]Review, error) {
+	dbReviews, err := s.q.ListSessionReviews(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(dbReviews, func(item db.SessionReview, _ int) Review { return fromDBReview(item) }), nil
+}
+
+// LatestReview returns the newest review of committed changes on a branch
+func (s *service) LatestReview(ctx context.Context, repo, branch string) (Review, error) {
+	dbReview, err := s.q.GetLatestSessionReview(ctx, db.GetLatestSessionReviewParams{Repo: repo, Branch: branch})
+	if err != nil {
+		return Review{}, err
+	}
+	return fromDBReview(dbReview), nil
+}
+
+func fromDBReview(item db.SessionReview) Review {
+	return Review{
+		SessionID:     item.SessionID,
+		Repo:          item.Repo,
+		Branch:        item.Branch,
+		BaseRef:       item.BaseRef,
+		BaseCommit:    item.BaseCommit,
+		HeadCommit:    item.HeadCommit,
+		DiffHash:      item.DiffHash,
+		Preset:        item.Preset,
+		PresetReplace: item.PresetReplace != 0,
+		Intent:        item.Intent.String,
+		CreatedAt:     item.CreatedAt,
+	}
+}
@@ -38,6 +38,7 @@ type Session struct {
 	SummaryMessageID string
 	Cost             float64
 	Todos            []Todo
+	WebAccessUsed    bool
 	CreatedAt        int64
 	UpdatedAt        int64
 }
@@ -51,8 +52,15 @@ type Service interface {
 	List(ctx context.Context) ([]Session, error)
 	Save(ctx context.Context, session Session) (Session, error)
 	UpdateTitleAndUsage(ctx context.Context, sessionID, title string, promptTokens, completionTokens int64, cost float64) error
+	MarkWebAccessUsed(ctx context.Context, sessionID string) (Session, error)
 	Delete(ctx context.Context, id string) error
 
+	// Review metadata
+	CreateReview(ctx context.Context, review Review) (Review, error)
+	GetReview(ctx context.Context, sessionID string) (Review, error)
+	ListReviews(ctx context.Context, repo string) ([]Review, error)
+	LatestReview(ctx context.Context, repo, branch string) (Review, error)
+
 	// Agent tool session management
 	CreateAgentToolSessionID(messageID, toolCallID string) string
 	ParseAgentToolSessionID(sessionID string) (messageID string, toolCallID string, ok bool)
@@ -169,6 +177,18 @@ func (s *service) UpdateTitleAndUsage(ctx context.Context, sessionID, title stri
 	})
 }
 
+// MarkWebAccessUsed records that the agent reached the web in the session. The flag is only
+// ever set, so a concurrent Save of an older copy cannot clear it.
+func (s *service) MarkWebAccessUsed(ctx context.Context, sessionID string) (Session, error) {
+	dbSession, err := s.q.MarkSessionWebAccessUsed(ctx, sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+	session := s.fromDBItem(dbSession)
+	s.Publish(pubsub.UpdatedEvent, session)
+	return session, nil
+}
+
 func (s *service) List(ctx context.Context) ([]Session, error) {
 	dbSessions, err := s.q.ListSessions(ctx)
 	if err != nil {
@@ -196,6 +216,7 @@ func (s service) fromDBItem(item db.Session) Session {
 		SummaryMessageID: item.SummaryMessageID.String,
 		Cost:             item.Cost,
 		Todos:            todos,
+		WebAccessUsed:    item.WebAccessUsed != 0,
 		CreatedAt:        item.CreatedAt,
 		UpdatedAt:        item.UpdatedAt,
 	}
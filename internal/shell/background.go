@@ -57,6 +57,11 @@ func GetBackgroundShellManager() *BackgroundShellManager {
 
 // Start creates and starts a new background shell with the given command.
 func (m *BackgroundShellManager) Start(ctx context.Context, workingDir string, blockFuncs []BlockFunc, command string, description string) (*BackgroundShell, error) {
+	return m.StartSandboxed(ctx, workingDir, blockFuncs, nil, command, description)
+}
+
+// StartSandboxed is Start with the shell confined by sandbox; a nil sandbox does not confine it.
+func (m *BackgroundShellManager) StartSandboxed(ctx context.Context, workingDir string, blockFuncs []BlockFunc, sandbox *Sandbox, command string, description string) (*BackgroundShell, error) {
 	// Check job limit
 	if m.shells.Len() >= MaxBackgroundJobs {
 		return nil, fmt.Errorf("maximum number of background jobs (%d) reached. Please terminate or wait for some jobs to complete", MaxBackgroundJobs)
@@ -67,6 +72,7 @@ func (m *BackgroundShellManager) Start(ctx context.Context, workingDir string, b
 	shell := NewShell(&Options{
 		WorkingDir: workingDir,
 		BlockFuncs: blockFuncs,
+		Sandbox:    sandbox,
 	})
 
 	shellCtx, cancel := context.WithCancel(ctx)
@@ -0,0 +1,204 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// writeFlags are the open flags that modify a file
+const writeFlags = os.O_WRONLY | os.O_RDWR | os.O_APPEND | os.O_CREATE | os.O_TRUNC
+
+// Sandbox is a read-only policy for a shell. Only the commands its rules allow run, with the
+// environment the shell started with, and redirections may only write to a temporary directory
+// that lives for one Exec (also TMPDIR and GOTMPDIR of the commands).
+//
+// The policy is enforced by the interpreter, not the OS: an allowed command is trusted not to
+// write files, so rules must deny the flags that make a command write or run other programs.
+// Network access is the exception: rules with NoNetwork run in a network namespace of their own.
+type Sandbox struct {
+	// Rules lists the commands that may run
+	Rules []CommandRule
+	// Env is set for every command and cannot be changed by the script, e.g. GOPROXY=off
+	Env []string
+}
+
+// CommandRule allows a command in a sandbox. Flags are matched by name, so "-run", "--run"
+// and "-run=Foo" are all the flag "run".
+type CommandRule struct {
+	// Command is the program name, e.g. "git"
+	Command string
+	// Subcommands, when set, lists the allowed first arguments, e.g. "log"
+	Subcommands []string
+	// RequiredFlags must all be given
+	RequiredFlags []string
+	// DeniedFlags must not be given
+	DeniedFlags []string
+	// NoNetwork runs the command without network access, in new user and network namespaces.
+	// Where the OS cannot create them the command is refused.
+	NoNetwork bool
+}
+
+// isolationCommand runs a command in new user and network namespaces, where no interface is up
+var isolationCommand = []string{"unshare", "--user", "--map-root-user", "--net", "--"}
+
+// String describes the rule, e.g. "go test -run (no network)"
+func (r CommandRule) String() string {
+	parts := []string{r.Command}
+	if len(r.Subcommands) > 0 {
+		parts = append(parts, strings.Join(r.Subcommands, "|"))
+	}
+	for _, flag := range r.RequiredFlags {
+		parts = append(parts, "-"+flag)
+	}
+	if r.NoNetwork {
+		parts = append(parts, "(no network)")
+	}
+	return strings.Join(parts, " ")
+}
+
+// check returns why the rule refuses args; empty when allowed
+func (r CommandRule) check(args []string) string {
+	if len(r.Subcommands) > 0 && (len(args) < 2 || !slices.Contains(r.Subcommands, args[1])) {
+		return fmt.Sprintf("%s only allows the subcommands %s", r.Command, strings.Join(r.Subcommands, ", "))
+	}
+	_, flags := splitArgsFlags(args[1:])
+	names := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		names = append(names, strings.TrimLeft(flag, "-"))
+	}
+	for _, required := range r.RequiredFlags {
+		if !slices.Contains(names, required) {
+			return fmt.Sprintf("%s requires -%s", r, required)
+		}
+	}
+	for _, denied := range r.DeniedFlags {
+		if slices.Contains(names, denied) {
+			return fmt.Sprintf("%s -%s is not allowed", r.Command, denied)
+		}
+	}
+	return ""
+}
+
+// SandboxError is a command or a write refused by a sandbox
+type SandboxError struct {
+	Reason string
+}
+
+func (e *SandboxError) Error() string {
+	return "not allowed in the read-only sandbox: " + e.Reason
+}
+
+// Commands lists what the sandbox allows, for tool descriptions
+func (sb *Sandbox) Commands() []string {
+	commands := make([]string, 0, len(sb.Rules))
+	for _, rule := range sb.Rules {
+		commands = append(commands, rule.String())
+	}
+	return commands
+}
+
+// match returns the rule that allows a command, or the error for a command the sandbox refuses
+func (sb *Sandbox) match(args []string) (CommandRule, error) {
+	var reasons []string
+	for _, rule := range sb.Rules {
+		if rule.Command != args[0] {
+			continue
+		}
+		reason := rule.check(args)
+		if reason == "" {
+			return rule, nil
+		}
+		reasons = append(reasons, reason)
+	}
+	if len(reasons) == 0 {
+		return CommandRule{}, &SandboxError{Reason: fmt.Sprintf("%s is not an allowed command", args[0])}
+	}
+	return CommandRule{}, &SandboxError{Reason: strings.Join(reasons, "; ")}
+}
+
+// isolate returns args wrapped to run without network access. If unshare cannot create the
+// namespaces at run time, it fails and the command does not run.
+func isolate(args []string) ([]string, error) {
+	if runtime.GOOS != "linux" {
+		return nil, &SandboxError{Reason: fmt.Sprintf("%s needs network isolation, which is only available on Linux", args[0])}
+	}
+	if _, err := exec.LookPath(isolationCommand[0]); err != nil {
+		return nil, &SandboxError{Reason: fmt.Sprintf("%s needs network isolation, but %s is not installed", args[0], isolationCommand[0])}
+	}
+	return slices.Concat(isolationCommand, args), nil
+}
+
+// environ returns the environment of a sandboxed run: env, then the sandbox's variables
+func (sb *Sandbox) environ(env []string, tempDir string) []string {
+	environ := slices.Concat(env, sb.Env)
+	return append(environ, "TMPDIR="+tempDir, "GOTMPDIR="+tempDir)
+}
+
+// execHandler refuses the commands the rules do not allow, and the commands run with a
+// modified environment, since variables like GOFLAGS or GIT_EXTERNAL_DIFF bypass the rules
+func (sb *Sandbox) execHandler(base expand.Environ) func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	return func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		return func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return next(ctx, args)
+			}
+			rule, err := sb.match(args)
+			if err != nil {
+				return err
+			}
+			if name := changedVariable(interp.HandlerCtx(ctx).Env, base); name != "" {
+				return &SandboxError{Reason: fmt.Sprintf("commands run with a fixed environment, but %s was changed", name)}
+			}
+			if rule.NoNetwork {
+				if args, err = isolate(args); err != nil {
+					return err
+				}
+			}
+			return next(ctx, args)
+		}
+	}
+}
+
+// changedVariable returns an exported variable of env that differs from base
+func changedVariable(env, base expand.Environ) string {
+	changed := ""
+	env.Each(func(name string, vr expand.Variable) bool {
+		if !vr.Exported || name == "PWD" || name == "OLDPWD" {
+			return true
+		}
+		if vr.String() != base.Get(name).String() {
+			changed = name
+			return false
+		}
+		return true
+	})
+	return changed
+}
+
+// openHandler refuses writes outside of tempDir; the null device stays writable
+func (sb *Sandbox) openHandler(tempDir string) interp.OpenHandlerFunc {
+	open := interp.DefaultOpenHandler()
+	return func(ctx context.Context, path string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+		if flag&writeFlags == 0 || path == os.DevNull {
+			return open(ctx, path, flag, perm)
+		}
+		abs := path
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(interp.HandlerCtx(ctx).Dir, abs)
+		}
+		if rel, err := filepath.Rel(tempDir, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, &SandboxError{Reason: fmt.Sprintf("cannot write %s; write to $TMPDIR (%s) instead", path, tempDir)}
+		}
+		return open(ctx, path, flag, perm)
+	}
+}
@@ -56,6 +56,7 @@ type Shell struct {
 	mu         sync.Mutex
 	logger     Logger
 	blockFuncs []BlockFunc
+	sandbox    *Sandbox
 }
 
 // Options for creating a new shell
@@ -64,6 +65,8 @@ type Options struct {
 	Env        []string
 	Logger     Logger
 	BlockFuncs []BlockFunc
+	// Sandbox, when set, confines every Exec to read-only commands
+	Sandbox *Sandbox
 }
 
 // NewShell creates a new shell instance with the given options
@@ -92,6 +95,7 @@ func NewShell(opts *Options) *Shell {
 		env:        env,
 		logger:     logger,
 		blockFuncs: opts.BlockFuncs,
+		sandbox:    opts.Sandbox,
 	}
 }
 
@@ -236,14 +240,27 @@ func (s *Shell) blockHandler() func(next interp.ExecHandlerFunc) interp.ExecHand
 	}
 }
 
-// newInterp creates a new interpreter with the current shell state
-func (s *Shell) newInterp(stdout, stderr io.Writer) (*interp.Runner, error) {
+// newInterp creates a new interpreter with the current shell state; tempDir is the
+// sandbox's writable directory, unused without a sandbox
+func (s *Shell) newInterp(stdout, stderr io.Writer, tempDir string) (*interp.Runner, error) {
+	if s.sandbox == nil {
+		return interp.New(
+			interp.StdIO(nil, stdout, stderr),
+			interp.Interactive(false),
+			interp.Env(expand.ListEnviron(s.env...)),
+			interp.Dir(s.cwd),
+			interp.ExecHandlers(s.execHandlers()...),
+		)
+	}
+
+	env := expand.ListEnviron(s.sandbox.environ(s.env, tempDir)...)
 	return interp.New(
 		interp.StdIO(nil, stdout, stderr),
 		interp.Interactive(false),
-		interp.Env(expand.ListEnviron(s.env...)),
+		interp.Env(env),
 		interp.Dir(s.cwd),
-		interp.ExecHandlers(s.execHandlers()...),
+		interp.ExecHandlers(s.execHandlers(s.sandbox.execHandler(env))...),
+		interp.OpenHandler(s.sandbox.openHandler(tempDir)),
 	)
 }
 
@@ -263,7 +280,15 @@ func (s *Shell) execCommon(ctx context.Context, command string, stdout, stderr i
 		return fmt.Errorf("could not parse command: %w", err)
 	}
 
-	runner, err := s.newInterp(stdout, stderr)
+	var tempDir string
+	if s.sandbox != nil {
+		if tempDir, err = os.MkdirTemp("", "revcli-sandbox-"); err != nil {
+			return fmt.Errorf("could not create sandbox directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+	}
+
+	runner, err := s.newInterp(stdout, stderr, tempDir)
 	if err != nil {
 		return fmt.Errorf("could not run command: %w", err)
 	}
@@ -286,10 +311,10 @@ func (s *Shell) execStream(ctx context.Context, command string, stdout, stderr i
 	return s.execCommon(ctx, command, stdout, stderr)
 }
 
-func (s *Shell) execHandlers() []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
-	handlers := []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc{
+func (s *Shell) execHandlers(extra ...func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc) []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	handlers := append([]func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc{
 		s.blockHandler(),
-	}
+	}, extra...)
 	if useGoCoreUtils {
 		handlers = append(handlers, coreutils.ExecHandler)
 	}
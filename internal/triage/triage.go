@@ -0,0 +1,118 @@
+// Package triage remembers what was decided about review findings, per repository,
+// so dismissed false positives stay out of future reviews.
+package triage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/db"
+	"github.com/trankhanh040147/revcli/internal/findings"
+)
+
+// Status is the triage decision on a finding
+type Status string
+
+const (
+	StatusDismissed    Status = "dismissed"
+	StatusAcknowledged Status = "acknowledged"
+	StatusFixed        Status = "fixed"
+)
+
+// Decision is the triage state of one finding, identified by its fingerprint
+type Decision struct {
+	Fingerprint string
+	Status      Status
+	// Reason explains a dismissal (may be empty)
+	Reason string
+	// File and Message describe the finding when it was triaged
+	File      string
+	Message   string
+	UpdatedAt int64
+}
+
+// Constraint describes a dismissed finding as a negative constraint for future reviews
+func (d Decision) Constraint() string {
+	text := d.Message
+	if d.File != "" {
+		text = fmt.Sprintf("%s (%s)", text, d.File)
+	}
+	if d.Reason != "" {
+		text = fmt.Sprintf("%s: %s", text, d.Reason)
+	}
+	return "Previously dismissed finding, do not report again: " + text
+}
+
+type Service interface {
+	// Set records the decision on a finding, replacing any earlier one
+	Set(ctx context.Context, repo string, finding findings.Finding, status Status, reason string) (Decision, error)
+	// Clear forgets the decision on a finding
+	Clear(ctx context.Context, repo, fingerprint string) error
+	// List returns the decisions of a repository, most recent first
+	List(ctx context.Context, repo string) ([]Decision, error)
+}
+
+type service struct {
+	q db.Querier
+}
+
+func NewService(q db.Querier) Service {
+	return &service{q: q}
+}
+
+func (s *service) Set(ctx context.Context, repo string, finding findings.Finding, status Status, reason string) (Decision, error) {
+	if finding.Fingerprint == "" {
+		return Decision{}, fmt.Errorf("finding has no fingerprint")
+	}
+	dbDecision, err := s.q.UpsertFindingTriage(ctx, db.UpsertFindingTriageParams{
+		Repo:        repo,
+		Fingerprint: finding.Fingerprint,
+		Status:      string(status),
+		Reason:      reason,
+		File:        finding.File,
+		Message:     finding.Message,
+	})
+	if err != nil {
+		return Decision{}, err
+	}
+	return fromDBDecision(dbDecision), nil
+}
+
+func (s *service) Clear(ctx context.Context, repo, fingerprint string) error {
+	return s.q.DeleteFindingTriage(ctx, db.DeleteFindingTriageParams{Repo: repo, Fingerprint: fingerprint})
+}
+
+func (s *service) List(ctx context.Context, repo string) ([]Decision, error) {
+	dbDecisions, err := s.q.ListFindingTriage(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(dbDecisions, func(item db.FindingTriage, _ int) Decision { return fromDBDecision(item) }), nil
+}
+
+// Dismissed returns the dismissed decisions
+func Dismissed(decisions []Decision) []Decision {
+	return lo.Filter(decisions, func(d Decision, _ int) bool {
+		return d.Status == StatusDismissed
+	})
+}
+
+// Fingerprints returns the set of fingerprints of decisions
+func Fingerprints(decisions []Decision) map[string]bool {
+	return lo.SliceToMap(decisions, func(d Decision) (string, bool) {
+		return d.Fingerprint, true
+	})
+}
+
+func fromDBDecision(item db.FindingTriage) Decision {
+	return Decision{
+		Fingerprint: item.Fingerprint,
+		Status:      Status(item.Status),
+		Reason:      item.Reason,
+		File:        item.File,
+		Message:     item.Message,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
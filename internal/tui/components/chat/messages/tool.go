@@ -187,9 +187,22 @@ func (m *toolCallCmp) View() string {
 	if m.isNested {
 		return box.Render(r.Render(m))
 	}
+	if len(m.result.Redactions) > 0 {
+		return box.Render(lipgloss.JoinVertical(lipgloss.Left, r.Render(m), m.renderRedactions()))
+	}
 	return box.Render(r.Render(m))
 }
 
+// renderRedactions notes the secrets withheld from the model in the result
+func (m *toolCallCmp) renderRedactions() string {
+	t := styles.CurrentTheme()
+	note := fmt.Sprintf("%d secret(s) withheld from the model", len(m.result.Redactions))
+	if m.result.Redactions[0].Blocked {
+		note = fmt.Sprintf("Output withheld from the model: %d secret(s)", len(m.result.Redactions))
+	}
+	return t.S().Base.PaddingLeft(2).Foreground(t.Yellow).Render("🔒 " + note)
+}
+
 // State management methods
 
 // SetCancelled marks the tool call as cancelled
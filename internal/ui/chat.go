@@ -2,10 +2,14 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/trankhanh040147/revcli/internal/agent"
 	"github.com/trankhanh040147/revcli/internal/app"
+	"github.com/trankhanh040147/revcli/internal/message"
 	"github.com/trankhanh040147/revcli/internal/prompt"
 )
 
@@ -50,6 +54,84 @@ func SendChatMessage(ctx context.Context, appInstance *app.App, sessionID, quest
 	}
 }
 
+// SendChatMessageWithContext sends a follow-up prompt, built by prunedFollowUp, in a new session
+// so pruned files are really left out
+func SendChatMessageWithContext(ctx context.Context, appInstance *app.App, followUp string, attachments []message.Attachment) tea.Cmd {
+	return func() tea.Msg {
+		session, err := appInstance.Sessions.Create(ctx, "Code Review (pruned context)")
+		if err != nil {
+			return ChatErrorMsg{Err: fmt.Errorf("failed to create session: %w", err)}
+		}
+
+		result, err := appInstance.AgentCoordinator.Run(ctx, session.ID, followUp, attachments...)
+		if err != nil {
+			return ChatErrorMsg{Err: err}
+		}
+
+		return ChatResponseMsg{Response: result.Response.Content.Text(), SessionID: session.ID}
+	}
+}
+
+// SwitchMode switches the agent mode; the session and its history are kept
+func SwitchMode(ctx context.Context, appInstance *app.App, name string) tea.Cmd {
+	return func() tea.Msg {
+		mode, err := agent.ParseMode(name)
+		if err != nil {
+			return ModeChangedMsg{Err: err}
+		}
+		if err := appInstance.AgentCoordinator.SetMode(ctx, mode); err != nil {
+			return ModeChangedMsg{Err: err}
+		}
+		return ModeChangedMsg{Mode: mode}
+	}
+}
+
+// ReviewFromMessages returns the review of a stored session
+func ReviewFromMessages(messages []message.Message) string {
+	review, _ := historyFromMessages(messages)
+	return review
+}
+
+// historyFromMessages rebuilds the review and chat of a stored session. The first turn is the review
+// and follow-up turns are the chat; other turns, like patch generation, are left out.
+func historyFromMessages(messages []message.Message) (string, []ChatMessage) {
+	var review strings.Builder
+	var chat []ChatMessage
+	turn := -1
+	keep := false
+	for _, msg := range messages {
+		text := msg.Content().Text
+		switch msg.Role {
+		case message.User:
+			turn++
+			if turn == 0 {
+				keep = true
+				continue
+			}
+			var question string
+			question, keep = prompt.FollowUpQuestion(text)
+			if keep {
+				chat = append(chat, ChatMessage{Role: ChatRoleUser, Content: question})
+			}
+		case message.Assistant:
+			if !keep || text == "" {
+				continue
+			}
+			if turn == 0 {
+				review.WriteString(text)
+				continue
+			}
+			// A turn with tool calls spans several assistant messages
+			if last := len(chat) - 1; chat[last].Role == ChatRoleAssistant {
+				chat[last].Content += text
+			} else {
+				chat = append(chat, ChatMessage{Role: ChatRoleAssistant, Content: text})
+			}
+		}
+	}
+	return review.String(), chat
+}
+
 // UpdatePromptHistory adds a question to prompt history, avoiding duplicates
 func UpdatePromptHistory(history []string, question string) []string {
 	if len(history) == 0 || history[len(history)-1] != question {
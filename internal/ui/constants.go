@@ -4,15 +4,17 @@ import "time"
 
 // UI feedback durations
 const (
-	YankFeedbackDuration      = 2 * time.Second
+	YankFeedbackDuration       = 2 * time.Second
 	PruneErrorFeedbackDuration = 3 * time.Second
-	YankChordTimeout          = 300 * time.Millisecond
+	YankChordTimeout           = 300 * time.Millisecond
 )
 
+// ModeCommand switches the agent mode from the chat input, e.g. "/mode fix"
+const ModeCommand = "/mode"
+
 // PruneFilePromptTemplate is the template for file summarization prompts
 const PruneFilePromptTemplate = `Summarize this code file in one sentence. Focus on what the file does, its main purpose, and key functionality. Be concise.
 
 File: %s
 
 %s`
-
@@ -6,12 +6,15 @@ import (
 	"charm.land/bubbles/v2/list"
 	"charm.land/lipgloss/v2"
 	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/prompt"
 )
 
 // FileListItem represents an item in the file list
 type FileListItem struct {
 	Path    string
 	Size    int
+	Change  string // Change description, e.g. "+3 -1" or "deleted"
+	Tokens  int    // Estimated prompt tokens for the file
 	Pruned  bool
 	Pruning bool // Whether file is currently being pruned
 }
@@ -27,9 +30,9 @@ func (f FileListItem) Title() string {
 	return fmt.Sprintf("%s%s", f.Path, indicator)
 }
 
-// Description returns the description (file size)
+// Description returns the description (change, file size and token estimate)
 func (f FileListItem) Description() string {
-	return formatFileSize(f.Size)
+	return fmt.Sprintf("%s · %s · ~%d tokens", f.Change, formatFileSize(f.Size), f.Tokens)
 }
 
 // FilterValue returns the value to filter by
@@ -54,19 +57,7 @@ func formatFileSize(bytes int) string {
 // NewFileListModel creates a new file list model from ReviewContext
 // pruningFiles may be nil if pruning state is not needed
 func NewFileListModel(reviewCtx *appcontext.ReviewContext, pruningFiles map[string]bool) list.Model {
-	items := make([]list.Item, 0, len(reviewCtx.FileContents))
-
-	for path, content := range reviewCtx.FileContents {
-		// PrunedFiles is always initialized in builder.go:91
-		_, pruned := reviewCtx.PrunedFiles[path]
-		pruning := pruningFiles != nil && pruningFiles[path]
-		items = append(items, FileListItem{
-			Path:    path,
-			Size:    len(content),
-			Pruned:  pruned,
-			Pruning: pruning,
-		})
-	}
+	items := fileListItems(reviewCtx, pruningFiles)
 
 	// Create list with custom styling
 	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
@@ -91,22 +82,32 @@ func NewFileListModel(reviewCtx *appcontext.ReviewContext, pruningFiles map[stri
 // UpdateFileListModel updates the file list model with current pruned state
 // pruningFiles may be nil if pruning state is not needed
 func UpdateFileListModel(l list.Model, reviewCtx *appcontext.ReviewContext, pruningFiles map[string]bool) list.Model {
-	items := make([]list.Item, 0, len(reviewCtx.FileContents))
+	items := fileListItems(reviewCtx, pruningFiles)
+
+	l.SetItems(items)
+	return l
+}
 
-	for path, content := range reviewCtx.FileContents {
-		// PrunedFiles is always initialized in builder.go:91
+// fileListItems builds one item per file in the diff, in diff order
+func fileListItems(reviewCtx *appcontext.ReviewContext, pruningFiles map[string]bool) []list.Item {
+	items := make([]list.Item, 0, len(reviewCtx.Files))
+
+	for _, f := range reviewCtx.Files {
+		path := f.Path()
+		content := reviewCtx.FileContents[path]
+		// PrunedFiles is always initialized in builder.go
 		_, pruned := reviewCtx.PrunedFiles[path]
 		pruning := pruningFiles != nil && pruningFiles[path]
 		items = append(items, FileListItem{
 			Path:    path,
 			Size:    len(content),
+			Change:  f.Describe(),
+			Tokens:  prompt.EstimateFileTokens(f, content),
 			Pruned:  pruned,
 			Pruning: pruning,
 		})
 	}
-
-	l.SetItems(items)
-	return l
+	return items
 }
 
 // GetSelectedFile returns the currently selected file path
@@ -75,18 +75,55 @@ func (h *HelpOverlay) Render() string {
 				{"Ctrl+N", "Next prompt"},
 				{"Ctrl+X", "Cancel request"},
 				{"Ctrl+W", "Toggle web search"},
+				{"/mode <name>", "Switch agent mode (review, fix, ask)"},
 				{"Esc", "Exit chat mode"},
 			},
 		},
 		{
 			title: "File List",
 			bindings: []keybinding{
-				{"i", "Enter file list / Prune selected file"},
+				{"i", "Enter file list / Prune selected file (again to cancel)"},
+				{"u", "Unprune selected file"},
 				{"j/k", "Navigate files"},
 				{"Enter", "View selected file"},
 				{"Esc", "Back to review"},
 			},
 		},
+		{
+			title: "Patches",
+			bindings: []keybinding{
+				{"p", "Review suggestions as patches (generated on first use)"},
+				{"] / [", "Next / previous patch (or Tab / Shift+Tab)"},
+				{"j/k", "Scroll the diff"},
+				{"a", "Accept patch"},
+				{"x", "Reject patch"},
+				{"e", "Edit patch in $EDITOR (accepts it)"},
+				{"w", "Apply accepted patches as one batch"},
+				{"u", "Revert the last applied batch"},
+				{"r", "Regenerate patches"},
+				{"Esc", "Back to review"},
+			},
+		},
+		{
+			title: "Finding Triage",
+			bindings: []keybinding{
+				{"t", "Triage the review's findings"},
+				{"j/k", "Select finding"},
+				{"d", "Dismiss as false positive (asks for a reason)"},
+				{"a", "Acknowledge finding"},
+				{"f", "Mark finding fixed"},
+				{"c", "Clear the decision"},
+				{"Esc", "Back to review"},
+			},
+		},
+		{
+			title: "Permissions (fix mode edits)",
+			bindings: []keybinding{
+				{"y", "Allow once"},
+				{"a", "Allow for this session"},
+				{"n / Esc", "Deny"},
+			},
+		},
 		{
 			title: "General",
 			bindings: []keybinding{
@@ -159,13 +196,19 @@ func RenderCompactHelp(state string) string {
 
 	switch state {
 	case "reviewing":
-		return helpStyle.Render("j/k: scroll • /: search • i: file list • ?: help • enter: chat • q: quit")
+		return helpStyle.Render("j/k: scroll • /: search • i: file list • p: patches • t: triage • ?: help • enter: chat • q: quit")
 	case "chatting":
 		return helpStyle.Render("enter: send • ctrl+w: toggle web search • esc: back • ?: help • q: quit")
 	case "searching":
 		return helpStyle.Render("enter: confirm • tab: mode • n/N: matches • esc: cancel")
 	case "filelist":
-		return helpStyle.Render("j/k: navigate • i: prune • Enter: view • Esc: back")
+		return helpStyle.Render("j/k: navigate • i: prune/cancel • u: unprune • Enter: view • Esc: back")
+	case "patches":
+		return helpStyle.Render("]/[: next/prev • a: accept • x: reject • e: edit • w: apply • u: revert • r: regenerate • esc: back")
+	case "triage":
+		return helpStyle.Render("j/k: select • d: dismiss • a: acknowledge • f: fixed • c: clear • esc: back")
+	case "dismissing":
+		return helpStyle.Render("enter: dismiss • esc: cancel")
 	case "help":
 		return helpStyle.Render("?: close • esc: close")
 	default:
@@ -32,12 +32,12 @@ type KeyMap struct {
 	Help key.Binding
 
 	// Chat
-	EnterChat      key.Binding
-	ExitChat       key.Binding
-	SendMessage    key.Binding
-	PrevPrompt     key.Binding
-	NextPrompt     key.Binding
-	CancelRequest  key.Binding
+	EnterChat       key.Binding
+	ExitChat        key.Binding
+	SendMessage     key.Binding
+	PrevPrompt      key.Binding
+	NextPrompt      key.Binding
+	CancelRequest   key.Binding
 	ToggleWebSearch key.Binding
 
 	// Yank
@@ -45,10 +45,34 @@ type KeyMap struct {
 	YankLast   key.Binding
 
 	// File list
-	FileList      key.Binding
-	FileListPrune key.Binding
-	SelectFile    key.Binding
-	Back          key.Binding
+	FileList        key.Binding
+	FileListPrune   key.Binding
+	FileListUnprune key.Binding
+	SelectFile      key.Binding
+	Back            key.Binding
+
+	// Patches
+	Patches         key.Binding
+	PatchNext       key.Binding
+	PatchPrev       key.Binding
+	PatchAccept     key.Binding
+	PatchReject     key.Binding
+	PatchEdit       key.Binding
+	PatchApply      key.Binding
+	PatchRevert     key.Binding
+	PatchRegenerate key.Binding
+
+	// Finding triage
+	Triage            key.Binding
+	TriageDismiss     key.Binding
+	TriageAcknowledge key.Binding
+	TriageFixed       key.Binding
+	TriageClear       key.Binding
+
+	// Tool permission prompts
+	PermissionAllow        key.Binding
+	PermissionAllowSession key.Binding
+	PermissionDeny         key.Binding
 }
 
 // DefaultKeyMap returns the default keymap
@@ -180,6 +204,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("i"),
 			key.WithHelp("i", "prune file"),
 		),
+		FileListUnprune: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "unprune file"),
+		),
 		SelectFile: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "select/view"),
@@ -188,5 +216,79 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back"),
 		),
+
+		// Patches
+		Patches: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "patches"),
+		),
+		PatchNext: key.NewBinding(
+			key.WithKeys("]", "tab"),
+			key.WithHelp("]/tab", "next patch"),
+		),
+		PatchPrev: key.NewBinding(
+			key.WithKeys("[", "shift+tab"),
+			key.WithHelp("[/shift+tab", "previous patch"),
+		),
+		PatchAccept: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "accept patch"),
+		),
+		PatchReject: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "reject patch"),
+		),
+		PatchEdit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit patch"),
+		),
+		PatchApply: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "apply accepted"),
+		),
+		PatchRevert: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "revert last batch"),
+		),
+		PatchRegenerate: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "regenerate patches"),
+		),
+
+		// Finding triage
+		Triage: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "triage findings"),
+		),
+		TriageDismiss: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "dismiss finding"),
+		),
+		TriageAcknowledge: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "acknowledge finding"),
+		),
+		TriageFixed: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "mark fixed"),
+		),
+		TriageClear: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clear triage"),
+		),
+
+		// Tool permission prompts
+		PermissionAllow: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "allow"),
+		),
+		PermissionAllowSession: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "allow for session"),
+		),
+		PermissionDeny: key.NewBinding(
+			key.WithKeys("n", "esc"),
+			key.WithHelp("n/esc", "deny"),
+		),
 	}
 }
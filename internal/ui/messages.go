@@ -4,6 +4,10 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
+	"github.com/trankhanh040147/revcli/internal/patch"
+	"github.com/trankhanh040147/revcli/internal/triage"
 )
 
 // ReviewStartMsg signals that a review has started
@@ -64,9 +68,57 @@ func streamErrorCmd(errChan chan error) tea.Cmd {
 	}
 }
 
+// ModeChangedMsg contains the result of a /mode command
+type ModeChangedMsg struct {
+	Mode agent.Mode
+	Err  error
+}
+
+// PatchesGeneratedMsg contains the patches previewed from the review's suggestions
+type PatchesGeneratedMsg struct {
+	Patches []*patch.Patch
+	// Skipped describes suggestions that could not be previewed
+	Skipped []string
+	Err     error
+}
+
+// PatchEditedMsg contains a patch's content after editing it in $EDITOR
+type PatchEditedMsg struct {
+	Index   int
+	Content string
+	Err     error
+}
+
+// PatchesAppliedMsg contains the batch of applied patches; on error, Batch is set only if a failed batch could not be reverted
+type PatchesAppliedMsg struct {
+	Batch *patch.Batch
+	Err   error
+}
+
+// PatchesRevertedMsg contains the result of reverting the last batch
+type PatchesRevertedMsg struct {
+	Err error
+}
+
+// TriageLoadedMsg contains the triage decisions of the reviewed repository
+type TriageLoadedMsg struct {
+	Repo      string
+	Decisions []triage.Decision
+	Err       error
+}
+
+// TriageSavedMsg contains a stored triage decision; Decision is nil when it was cleared
+type TriageSavedMsg struct {
+	Fingerprint string
+	Decision    *triage.Decision
+	Err         error
+}
+
 // ChatResponseMsg contains a response to a follow-up question
 type ChatResponseMsg struct {
 	Response string
+	// SessionID is set when the follow-up started a new session with the pruned context
+	SessionID string
 }
 
 // ChatErrorMsg contains an error from a chat interaction
@@ -127,7 +179,8 @@ type yankTimeoutMsg struct{}
 
 // PruneFileMsg contains the result of pruning a file
 type PruneFileMsg struct {
-	FilePath string
-	Summary  string
-	Err      error
+	FilePath    string
+	ContentHash string
+	Summary     string
+	Err         error
 }
@@ -13,9 +13,15 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/trankhanh040147/revcli/internal/agent"
 	"github.com/trankhanh040147/revcli/internal/app"
 	appcontext "github.com/trankhanh040147/revcli/internal/context"
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/message"
+	"github.com/trankhanh040147/revcli/internal/patch"
+	"github.com/trankhanh040147/revcli/internal/permission"
 	"github.com/trankhanh040147/revcli/internal/preset"
+	"github.com/trankhanh040147/revcli/internal/triage"
 )
 
 // State represents the current state of the application
@@ -28,6 +34,8 @@ const (
 	StateSearching
 	StateHelp
 	StateFileList
+	StatePatches
+	StateTriage
 	StateError
 	StateQuitting
 )
@@ -74,7 +82,9 @@ type Model struct {
 	// Flags
 	ready            bool
 	streaming        bool
-	webSearchEnabled bool // Web search toggle for follow-up questions (default: true, resets per question)
+	webSearchEnabled bool // Web search toggle for follow-up questions (defaults to the review's, resets per question)
+	webAccessUsed    bool // Whether the agent has used the web tools in the review
+	secretsWithheld  int  // Secrets redacted or blocked in the agent's tool results
 
 	// Streaming channels (set during StreamStartMsg)
 	streamChunkChan chan string
@@ -93,6 +103,29 @@ type Model struct {
 	pruningFiles    map[string]bool               // Track which files are currently being pruned
 	pruningSpinners map[string]spinner.Model      // Spinners for each file being pruned
 	pruningCancels  map[string]context.CancelFunc // Cancel functions for each pruning operation
+	pruneCache      map[string]string             // Prune summaries keyed by file content hash
+	contextChanged  bool                          // Pruned files changed since the chat session was seeded
+
+	// Tool permission request awaiting an answer (fix mode edits)
+	pendingPermission *permission.PermissionRequest
+
+	// Patches generated from the review's suggestions (nil until generated)
+	patches           []*patch.Patch
+	patchIndex        int          // Selected patch
+	patchOffset       int          // Scroll offset of the selected patch's diff
+	generatingPatches bool         // Patch generation in flight
+	lastBatch         *patch.Batch // Last applied batch, for revert
+
+	// Findings of the review and their triage decisions (nil until triage is opened)
+	triageFindings  []findings.Finding
+	triageDecisions map[string]triage.Decision // Keyed by fingerprint
+	triageIndex     int                        // Selected finding
+	triageRepo      string                     // Repository the decisions belong to
+	triageReason    textinput.Model            // Reason input while dismissing
+	dismissing      bool                       // The reason input is open
+
+	// Agent mode, mirrored from ModeChangedMsg so rendering never reads the coordinator
+	mode agent.Mode
 
 	// Keybindings
 	keys KeyMap
@@ -137,6 +170,12 @@ func NewModel(reviewCtx *appcontext.ReviewContext, appInstance *app.App, session
 	si.CharLimit = 100
 	si.SetWidth(40)
 
+	// Create dismissal reason input
+	ri := textinput.New()
+	ri.Placeholder = "Why is this a false positive? (optional)"
+	ri.CharLimit = 200
+	ri.SetWidth(60)
+
 	// Create renderer (with fallback if it fails)
 	renderer, err := NewRenderer()
 	if err != nil {
@@ -144,8 +183,8 @@ func NewModel(reviewCtx *appcontext.ReviewContext, appInstance *app.App, session
 		renderer = &Renderer{}
 	}
 
-	// Create root context
-	rootCtx := context.Background()
+	// Create root context; runs use the web tools only if the review allows them
+	rootCtx := agent.WithWebAccess(context.Background(), reviewCtx.Intent.WebAccess())
 
 	// Create file list
 	fileListModel := NewFileListModel(reviewCtx, nil)
@@ -161,23 +200,30 @@ func NewModel(reviewCtx *appcontext.ReviewContext, appInstance *app.App, session
 		spinner:            s,
 		textarea:           ta,
 		searchInput:        si,
+		triageReason:       ri,
 		fileList:           fileListModel,
 		search:             NewSearchState(),
 		renderer:           renderer,
 		ready:              false,
 		streaming:          false,
-		webSearchEnabled:   true, // Default to enabled
+		webSearchEnabled:   reviewCtx.Intent.WebAccess(),
 		promptHistory:      []string{},
 		promptHistoryIndex: -1,
 		pruningFiles:       make(map[string]bool),
 		pruningSpinners:    make(map[string]spinner.Model),
 		pruningCancels:     make(map[string]context.CancelFunc),
+		pruneCache:         make(map[string]string),
+		mode:               appInstance.AgentCoordinator.Mode(),
 		keys:               DefaultKeyMap(),
 	}
 }
 
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
+	// A resumed review already has its response
+	if m.state != StateLoading {
+		return nil
+	}
 	return tea.Batch(
 		m.spinner.Tick,
 		m.startReview(),
@@ -186,7 +232,33 @@ func (m *Model) Init() tea.Cmd {
 
 // Run starts the Bubbletea program
 func Run(reviewCtx *appcontext.ReviewContext, appInstance *app.App, sessionID string, p *preset.Preset) error {
+	return runProgram(NewModel(reviewCtx, appInstance, sessionID, p), appInstance)
+}
+
+// Resume reopens a stored review session with its review and chat history
+func Resume(reviewCtx *appcontext.ReviewContext, appInstance *app.App, sessionID string, p *preset.Preset, messages []message.Message) error {
 	model := NewModel(reviewCtx, appInstance, sessionID, p)
+	model.reviewResponse, model.chatHistory = historyFromMessages(messages)
+	if model.reviewResponse == "" {
+		return fmt.Errorf("session %s has no completed review to resume", sessionID)
+	}
+	for _, msg := range model.chatHistory {
+		if msg.Role == ChatRoleUser {
+			model.promptHistory = UpdatePromptHistory(model.promptHistory, msg.Content)
+		}
+	}
+	if sess, err := appInstance.Sessions.Get(context.Background(), sessionID); err == nil {
+		model.webAccessUsed = sess.WebAccessUsed
+	}
+	for _, msg := range messages {
+		model.secretsWithheld += countRedactions(msg)
+	}
+	model.state = StateReviewing
+	return runProgram(model, appInstance)
+}
+
+// runProgram runs the Bubbletea program for the model
+func runProgram(model *Model, appInstance *app.App) error {
 	program := tea.NewProgram(model)
 
 	// Subscribe app events to TUI
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -20,6 +21,10 @@ import (
 func buildAttachments(reviewCtx *appcontext.ReviewContext) []message.Attachment {
 	var attachments []message.Attachment
 	for filePath, content := range reviewCtx.FileContents {
+		// Pruned files are sent as summaries in the prompt only
+		if _, pruned := reviewCtx.PrunedFiles[filePath]; pruned {
+			continue
+		}
 		attachments = append(attachments, message.Attachment{
 			FilePath: filePath,
 			FileName: filepath.Base(filePath),
@@ -30,20 +35,31 @@ func buildAttachments(reviewCtx *appcontext.ReviewContext) []message.Attachment
 	return attachments
 }
 
+// prunedFollowUp builds a follow-up prompt seeded with the pruned review prompt, the review and
+// the chat so far, with its attachments; it runs in Update since the cmd goroutine must not read the model
+func (m *Model) prunedFollowUp(question string) (string, []message.Attachment) {
+	// The question is the last history entry; it is sent separately
+	var transcript strings.Builder
+	for _, msg := range m.chatHistory[:max(len(m.chatHistory)-1, 0)] {
+		fmt.Fprintf(&transcript, "**%s:** %s\n\n", msg.Role, msg.Content)
+	}
+
+	followUp := prompt.BuildFollowUpPromptWithContext(m.reviewCtx.ReviewPrompt(), m.reviewResponse, transcript.String(), question)
+	return followUp, buildAttachments(m.reviewCtx)
+}
+
 // startReview initiates the code review with streaming support
 func (m *Model) startReview() tea.Cmd {
 	// Rebuild prompt with pruned files if any
 	userPrompt := m.reviewCtx.UserPrompt
 	if len(m.reviewCtx.PrunedFiles) > 0 {
-		userPrompt = prompt.BuildReviewPromptWithPruning(
-			m.reviewCtx.RawDiff,
-			m.reviewCtx.FileContents,
-			m.reviewCtx.PrunedFiles,
-		)
+		userPrompt = m.reviewCtx.ReviewPrompt()
 	}
 
 	// Build attachments
 	attachments := buildAttachments(m.reviewCtx)
+	// The review session now holds the current pruned context
+	m.contextChanged = false
 
 	// Create new context for this command
 	ctx, cancel := context.WithCancel(m.rootCtx)
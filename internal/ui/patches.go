@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
+	"github.com/trankhanh040147/revcli/internal/config"
+	"github.com/trankhanh040147/revcli/internal/history"
+	"github.com/trankhanh040147/revcli/internal/patch"
+	"github.com/trankhanh040147/revcli/internal/tui/components/core"
+	"github.com/trankhanh040147/revcli/internal/uiutil"
+)
+
+// Styles for the patch list
+var (
+	patchSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7C3AED")).
+				Bold(true)
+	patchStatusStyles = map[patch.Status]lipgloss.Style{
+		patch.StatusPending:  lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")),
+		patch.StatusAccepted: lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")),
+		patch.StatusRejected: lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")),
+	}
+)
+
+// generatePatchesCmd asks the agent to turn the review's suggestions into edits and previews them
+func generatePatchesCmd(ctx context.Context, coordinator agent.Coordinator, sessionID, workingDir string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := coordinator.Run(ctx, sessionID, patch.Prompt)
+		if err != nil {
+			return PatchesGeneratedMsg{Err: err}
+		}
+		suggestions, err := patch.ParseSuggestions(result.Response.Content.Text())
+		if err != nil {
+			return PatchesGeneratedMsg{Err: err}
+		}
+
+		var msg PatchesGeneratedMsg
+		for _, s := range suggestions {
+			p, err := patch.Preview(workingDir, s)
+			if err != nil {
+				msg.Skipped = append(msg.Skipped, err.Error())
+				continue
+			}
+			msg.Patches = append(msg.Patches, p)
+		}
+		return msg
+	}
+}
+
+// applyPatchesCmd writes the accepted patches as one batch
+func applyPatchesCmd(ctx context.Context, files history.Service, sessionID string, patches []patch.Patch) tea.Cmd {
+	return func() tea.Msg {
+		batch, err := patch.Apply(ctx, files, sessionID, patches)
+		return PatchesAppliedMsg{Batch: batch, Err: err}
+	}
+}
+
+// revertBatchCmd restores the files of the last applied batch
+func revertBatchCmd(ctx context.Context, files history.Service, batch *patch.Batch) tea.Cmd {
+	return func() tea.Msg {
+		return PatchesRevertedMsg{Err: batch.Revert(ctx, files)}
+	}
+}
+
+// editPatchCmd opens the patched content in $EDITOR; saving it accepts the edited patch
+func editPatchCmd(ctx context.Context, index int, p *patch.Patch) tea.Cmd {
+	editor := os.Getenv(config.EnvEditor)
+	if editor == "" {
+		editor = "vi"
+	}
+
+	tmpFile, err := os.CreateTemp("", "revcli_patch_*"+filepath.Ext(p.Path))
+	if err != nil {
+		return uiutil.ReportError(err)
+	}
+	defer tmpFile.Close() //nolint:errcheck
+	if _, err := tmpFile.WriteString(p.After); err != nil {
+		return uiutil.ReportError(err)
+	}
+
+	return uiutil.ExecShell(ctx, editor+" "+tmpFile.Name(), func(err error) tea.Msg {
+		defer os.Remove(tmpFile.Name()) //nolint:errcheck
+		if err != nil {
+			return PatchEditedMsg{Index: index, Err: fmt.Errorf("editor exited with error: %w", err)}
+		}
+		content, err := os.ReadFile(tmpFile.Name())
+		if err != nil {
+			return PatchEditedMsg{Index: index, Err: err}
+		}
+		return PatchEditedMsg{Index: index, Content: string(content)}
+	})
+}
+
+// acceptedPatches copies the accepted patches so the apply command never reads the model
+func (m *Model) acceptedPatches() []patch.Patch {
+	var accepted []patch.Patch
+	for _, p := range m.patches {
+		if p.Status == patch.StatusAccepted {
+			accepted = append(accepted, *p)
+		}
+	}
+	return accepted
+}
+
+// renderPatchList renders one line per patch with its status
+func (m *Model) renderPatchList() string {
+	var sb strings.Builder
+	for i, p := range m.patches {
+		cursor := "  "
+		name := p.FilePath
+		if i == m.patchIndex {
+			cursor = "› "
+			name = patchSelectedStyle.Render(name)
+		}
+		status := p.Status.String()
+		if p.Edited {
+			status += ", edited"
+		}
+		line := fmt.Sprintf("%s%s %s", cursor, patchStatusStyles[p.Status].Render("["+status+"]"), name)
+		if p.Description != "" {
+			line += " — " + p.Description
+		}
+		if len(p.Failed) > 0 {
+			line += warningStyle.Render(fmt.Sprintf(" (%d edit(s) did not apply)", len(p.Failed)))
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderPatchDiff renders the selected patch with diffview, scrolled to patchOffset
+func (m *Model) renderPatchDiff(height int) string {
+	p := m.patches[m.patchIndex]
+	formatter := core.DiffFormatter().
+		Before(p.FilePath, p.Before).
+		After(p.FilePath, p.After).
+		Width(max(m.width-4, 20)).
+		Height(max(height, 5)).
+		YOffset(m.patchOffset)
+	if m.width > 160 {
+		formatter = formatter.Split()
+	}
+	return formatter.String()
+}
+
+// viewPatches renders the patch review state
+func (m *Model) viewPatches() string {
+	var s strings.Builder
+	s.WriteString(RenderTitle("🩹 Suggested Patches"))
+	s.WriteString("\n")
+
+	switch {
+	case m.generatingPatches:
+		s.WriteString(m.spinner.View())
+		s.WriteString(" Generating patches from the review suggestions...\n")
+	case len(m.patches) == 0:
+		s.WriteString(RenderSubtitle("No patches. Press r to generate them again."))
+		s.WriteString("\n")
+	default:
+		list := m.renderPatchList()
+		s.WriteString(list)
+		s.WriteString("\n")
+		// Title, list, feedback and footer take the rest of the screen
+		s.WriteString(m.renderPatchDiff(m.height - lipgloss.Height(list) - 6))
+		s.WriteString("\n")
+	}
+
+	if m.pendingPermission != nil {
+		s.WriteString(m.renderPermissionPrompt())
+		s.WriteString("\n")
+	}
+
+	if m.yankFeedback != "" {
+		s.WriteString("\n")
+		s.WriteString(RenderSuccess(m.yankFeedback))
+	}
+
+	s.WriteString("\n")
+	s.WriteString(RenderCompactHelp("patches"))
+	return s.String()
+}
+
+// patchesError describes a failed patch command for the feedback line
+func patchesError(action string, err error) string {
+	if errors.Is(err, context.Canceled) {
+		return action + " cancelled"
+	}
+	return fmt.Sprintf("Error %s: %v", strings.ToLower(action), err)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/trankhanh040147/revcli/internal/permission"
+	"github.com/trankhanh040147/revcli/internal/pubsub"
+)
+
+// permissionStyle highlights tool permission prompts
+var permissionStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("#F59E0B")).
+	Padding(0, 1)
+
+// handlePermissionMessages shows tool permission requests (e.g. edits in fix mode)
+func (m *Model) handlePermissionMessages(msg tea.Msg) {
+	if event, ok := msg.(pubsub.Event[permission.PermissionRequest]); ok && event.Type == pubsub.CreatedEvent {
+		request := event.Payload
+		m.pendingPermission = &request
+		m.updateViewportHeight()
+	}
+}
+
+// updateKeyMsgPermission answers the pending permission request
+func (m *Model) updateKeyMsgPermission(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	request := *m.pendingPermission
+	switch {
+	case key.Matches(msg, m.keys.PermissionAllow):
+		m.app.Permissions.Grant(request)
+	case key.Matches(msg, m.keys.PermissionAllowSession):
+		m.app.Permissions.GrantPersistent(request)
+	case key.Matches(msg, m.keys.PermissionDeny):
+		m.app.Permissions.Deny(request)
+	default:
+		return m, nil
+	}
+	m.pendingPermission = nil
+	m.updateViewportHeight()
+	return m, nil
+}
+
+// renderPermissionPrompt renders the pending permission request
+func (m *Model) renderPermissionPrompt() string {
+	request := m.pendingPermission
+	text := fmt.Sprintf("%s wants to %s", request.ToolName, request.Action)
+	if request.Path != "" {
+		text += " in " + request.Path
+	}
+	if request.Description != "" {
+		text += "\n" + request.Description
+	}
+	return permissionStyle.Render(RenderWarning(text) + "\n" + RenderHelp("y: allow • a: allow for session • n: deny"))
+}
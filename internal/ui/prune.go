@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
+)
+
+// pruneFileCmd summarizes a file with the small model so it can replace the full content
+func pruneFileCmd(ctx context.Context, coordinator agent.Coordinator, filePath, content string) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := coordinator.Complete(ctx, fmt.Sprintf(PruneFilePromptTemplate, filePath, content))
+		if err == nil && summary == "" {
+			err = fmt.Errorf("empty summary for %s", filePath)
+		}
+		return PruneFileMsg{
+			FilePath:    filePath,
+			ContentHash: contentHash(content),
+			Summary:     summary,
+			Err:         err,
+		}
+	}
+}
+
+// contentHash keys cached prune summaries, so unchanged content is never summarized twice
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/trankhanh040147/revcli/internal/event"
+)
+
+// AskTelemetryConsent asks on the first run whether, and where, usage events may be recorded
+func AskTelemetryConsent() (event.Mode, error) {
+	choice := event.ModeOff
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[event.Mode]().
+				Title("Usage Metrics").
+				Description("revcli can record usage events: commands run, models, token counts and cost. Code, prompts and responses are never included. Change this later with 'revcli telemetry'.").
+				Options(
+					huh.NewOption("Off: record nothing", event.ModeOff),
+					huh.NewOption("Local: append events to "+event.LocalFile+" in the data directory; nothing leaves this machine", event.ModeLocal),
+					huh.NewOption("Remote: send anonymous events to "+event.Endpoint(), event.ModeRemote),
+				).
+				Value(&choice),
+		),
+	).WithTheme(huh.ThemeCatppuccin()).
+		WithWidth(80).
+		WithShowHelp(true)
+
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("failed to collect telemetry consent: %w", err)
+	}
+	return choice, nil
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/trankhanh040147/revcli/internal/message"
+	"github.com/trankhanh040147/revcli/internal/pubsub"
+)
+
+// handleToolSecretMessages counts the secrets withheld from the agent's tool results, sub-agents' included
+func (m *Model) handleToolSecretMessages(msg tea.Msg) {
+	if event, ok := msg.(pubsub.Event[message.Message]); ok && event.Type == pubsub.CreatedEvent {
+		m.secretsWithheld += countRedactions(event.Payload)
+	}
+}
+
+// countRedactions returns how many secrets were withheld from a message's tool results
+func countRedactions(msg message.Message) int {
+	count := 0
+	for _, result := range msg.ToolResults() {
+		count += len(result.Redactions)
+	}
+	return count
+}
+
+// secretsStatus tells the footer that secrets were withheld from the agent; empty when none were
+func (m *Model) secretsStatus() string {
+	if m.secretsWithheld == 0 {
+		return ""
+	}
+	return " • " + RenderWarning(fmt.Sprintf("🔒 %d secret(s) withheld from the agent", m.secretsWithheld))
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/git"
+	"github.com/trankhanh040147/revcli/internal/triage"
+)
+
+// triageNew labels findings without a decision
+const triageNew = "new"
+
+// Styles for the triage list
+var triageStatusStyles = map[string]lipgloss.Style{
+	triageNew:                         lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")),
+	string(triage.StatusDismissed):    lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")),
+	string(triage.StatusAcknowledged): lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")),
+	string(triage.StatusFixed):        lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")),
+}
+
+// loadTriageCmd loads the triage decisions of the repository the review runs in
+func loadTriageCmd(ctx context.Context, decisions triage.Service) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := git.GetGitRoot()
+		if err != nil {
+			return TriageLoadedMsg{Err: err}
+		}
+		list, err := decisions.List(ctx, repo)
+		return TriageLoadedMsg{Repo: repo, Decisions: list, Err: err}
+	}
+}
+
+// setTriageCmd stores a decision on a finding
+func setTriageCmd(ctx context.Context, decisions triage.Service, repo string, finding findings.Finding, status triage.Status, reason string) tea.Cmd {
+	return func() tea.Msg {
+		decision, err := decisions.Set(ctx, repo, finding, status, reason)
+		if err != nil {
+			return TriageSavedMsg{Fingerprint: finding.Fingerprint, Err: err}
+		}
+		return TriageSavedMsg{Fingerprint: finding.Fingerprint, Decision: &decision}
+	}
+}
+
+// clearTriageCmd forgets the decision on a finding
+func clearTriageCmd(ctx context.Context, decisions triage.Service, repo, fingerprint string) tea.Cmd {
+	return func() tea.Msg {
+		return TriageSavedMsg{Fingerprint: fingerprint, Err: decisions.Clear(ctx, repo, fingerprint)}
+	}
+}
+
+// triageStatus returns the decision label of a finding
+func (m *Model) triageStatus(f findings.Finding) string {
+	if d, ok := m.triageDecisions[f.Fingerprint]; ok {
+		return string(d.Status)
+	}
+	return triageNew
+}
+
+// renderTriageList renders up to height findings, scrolled to keep the selected one visible
+func (m *Model) renderTriageList(height int) string {
+	height = max(height, 1)
+	start := max(m.triageIndex-height+1, 0)
+	end := min(start+height, len(m.triageFindings))
+
+	var sb strings.Builder
+	for i := start; i < end; i++ {
+		f := m.triageFindings[i]
+		status := m.triageStatus(f)
+		label := "[" + status + "] "
+		text := fmt.Sprintf("%s %s— %s", f.Severity, findingLocation(f), f.Message)
+		text = ansi.Truncate(text, max(m.width-len(label)-4, 20), "…")
+		cursor := "  "
+		if i == m.triageIndex {
+			cursor = "› "
+			text = patchSelectedStyle.Render(text)
+		}
+		sb.WriteString(cursor + triageStatusStyles[status].Render(label) + text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// findingLocation formats the file and line of a finding, with a trailing space
+func findingLocation(f findings.Finding) string {
+	switch {
+	case !f.HasLocation():
+		return ""
+	case f.Line > 0:
+		return fmt.Sprintf("%s:%d ", f.File, f.Line)
+	default:
+		return f.File + " "
+	}
+}
+
+// renderTriageDetail renders the selected finding in full, with its fingerprint and decision
+func (m *Model) renderTriageDetail() string {
+	f := m.triageFindings[m.triageIndex]
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Width(max(m.width-4, 20)).Render(f.Message))
+	sb.WriteString("\n")
+	sb.WriteString(RenderHelp("fingerprint " + f.Fingerprint))
+	if d, ok := m.triageDecisions[f.Fingerprint]; ok && d.Reason != "" {
+		sb.WriteString("\n")
+		sb.WriteString(RenderHelp("reason: " + d.Reason))
+	}
+	return sb.String()
+}
+
+// viewTriage renders the finding triage state
+func (m *Model) viewTriage() string {
+	var s strings.Builder
+	s.WriteString(RenderTitle("🏷  Finding Triage"))
+	s.WriteString("\n")
+
+	if len(m.triageFindings) == 0 {
+		s.WriteString(RenderSubtitle("No findings could be parsed from the review."))
+		s.WriteString("\n")
+	} else {
+		detail := m.renderTriageDetail()
+		// Title, detail, reason input, feedback and footer take the rest of the screen
+		s.WriteString(m.renderTriageList(m.height - lipgloss.Height(detail) - 8))
+		s.WriteString("\n")
+		s.WriteString(detail)
+		s.WriteString("\n")
+	}
+
+	if m.dismissing {
+		s.WriteString("\n")
+		s.WriteString(m.triageReason.View())
+		s.WriteString("\n")
+	}
+
+	if m.yankFeedback != "" {
+		s.WriteString("\n")
+		s.WriteString(RenderSuccess(m.yankFeedback))
+	}
+
+	s.WriteString("\n")
+	if m.dismissing {
+		s.WriteString(RenderCompactHelp("dismissing"))
+	} else {
+		s.WriteString(RenderCompactHelp("triage"))
+	}
+	return s.String()
+}
@@ -16,7 +16,7 @@ func (m *Model) returnToPreviousState() {
 
 // handleChatCompletion handles chat response completion (success or error)
 // Note: webSearchEnabled is reset in update_chatting.go when sending the message,
-// so it's already set to true when this handler runs
+// so it's already back to the review's setting when this handler runs
 func (m *Model) handleChatCompletion(content string, isError bool) {
 	m.streaming = false
 	m.chatHistory = append(m.chatHistory, ChatMessage{Role: ChatRoleAssistant, Content: content})
@@ -36,6 +36,10 @@ func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) {
 		m.viewport.SetHeight(CalculateViewportHeight(msg.Height, m.state, m.yankFeedback != ""))
 		m.viewport.Style = lipgloss.NewStyle().Padding(0, 2)
 		m.ready = true
+		// A resumed review has its content before the viewport exists
+		if m.reviewResponse != "" {
+			m.updateViewport()
+		}
 	} else {
 		m.viewport.SetWidth(msg.Width)
 	}
@@ -72,11 +76,35 @@ func (m *Model) updateNonKeyMsg(msg tea.Msg) (*Model, tea.Cmd) {
 	// Handle chat messages
 	m.handleChatMessages(msg)
 
+	// Handle tool permission requests
+	m.handlePermissionMessages(msg)
+
+	// Handle session updates (web access)
+	m.handleWebAccessMessages(msg)
+
+	// Handle tool results (withheld secrets)
+	m.handleToolSecretMessages(msg)
+
 	// Handle yank messages (may return early)
 	if newM, cmd, shouldReturn := m.handleYankMessages(msg); shouldReturn {
 		return newM, cmd
 	}
 
+	// Handle mode switch messages (may return early)
+	if newM, cmd, shouldReturn := m.handleModeMessages(msg); shouldReturn {
+		return newM, cmd
+	}
+
+	// Handle patch messages (may return early)
+	if newM, cmd, shouldReturn := m.handlePatchMessages(msg); shouldReturn {
+		return newM, cmd
+	}
+
+	// Handle triage messages (may return early)
+	if newM, cmd, shouldReturn := m.handleTriageMessages(msg); shouldReturn {
+		return newM, cmd
+	}
+
 	// Handle prune messages (may return early)
 	if newM, cmd, shouldReturn := m.handlePruneMessages(msg); shouldReturn {
 		return newM, cmd
@@ -108,6 +136,10 @@ func (m *Model) updateNonKeyMsg(msg tea.Msg) (*Model, tea.Cmd) {
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// A dismissal reason being typed takes every key but force quit
+		if m.dismissing && !key.Matches(msg, m.keys.ForceQuit) {
+			return m.updateKeyMsgTriage(msg)
+		}
 		// Handle quit keys globally (including during loading/streaming)
 		if key.Matches(msg, m.keys.Quit) || key.Matches(msg, m.keys.ForceQuit) {
 			if m.activeCancel != nil {
@@ -116,6 +148,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, tea.Quit
 		}
+		// A pending permission request blocks the agent, so it takes all other keys
+		if m.pendingPermission != nil {
+			return m.updateKeyMsgPermission(msg)
+		}
 		// Handle cancel request globally for all long-running operations
 		if key.Matches(msg, m.keys.CancelRequest) {
 			// Cancel main active operation
@@ -139,6 +175,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// For loading/streaming, transition to error state
 				m.transitionToErrorOnCancel()
 				return m, nil
+			} else if m.generatingPatches {
+				// The generation command reports the cancellation
+				return m, nil
 			} else if m.state == StateFileList {
 				// For file list (pruning), show feedback but stay in file list
 				m.yankFeedback = "Pruning cancelled"
@@ -162,6 +201,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateKeyMsgHelp(msg)
 		case StateFileList:
 			return m.updateKeyMsgFileList(msg)
+		case StatePatches:
+			return m.updateKeyMsgPatches(msg)
+		case StateTriage:
+			return m.updateKeyMsgTriage(msg)
 		case StateError:
 			return m.updateKeyMsgError(msg)
 		default:
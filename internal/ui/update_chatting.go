@@ -6,6 +6,8 @@ import (
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+
+	"github.com/trankhanh040147/revcli/internal/agent"
 )
 
 // navigatePromptHistory navigates through prompt history in the given direction
@@ -44,15 +46,25 @@ func (m *Model) updateKeyMsgChatting(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.SendMessage):
 		if !m.streaming {
 			question := strings.TrimSpace(m.textarea.Value())
+			if name, ok := strings.CutPrefix(question, ModeCommand); ok && (name == "" || name[0] == ' ') {
+				m.textarea.Reset()
+				return m, SwitchMode(m.rootCtx, m.app, strings.TrimSpace(name))
+			}
 			if question != "" {
 				m.promptHistory = UpdatePromptHistory(m.promptHistory, question)
 				m.promptHistoryIndex = -1
 				m.textarea.Reset()
 				m.streaming = true
 				m.chatHistory = append(m.chatHistory, ChatMessage{Role: ChatRoleUser, Content: question})
-				// Create new context for this command
-				ctx, cancel := context.WithCancel(m.rootCtx)
+				// Create new context for this command; the web toggle applies to this question only
+				ctx, cancel := context.WithCancel(agent.WithWebAccess(m.rootCtx, m.webSearchEnabled))
 				m.activeCancel = cancel
+				m.webSearchEnabled = m.reviewCtx.Intent.WebAccess()
+				if m.contextChanged {
+					// Earlier turns carry the full file contents; reseed a session with the pruned context
+					followUp, attachments := m.prunedFollowUp(question)
+					return m, SendChatMessageWithContext(ctx, m.app, followUp, attachments)
+				}
 				return m, SendChatMessage(ctx, m.app, m.sessionID, question)
 			}
 		}
@@ -47,6 +47,11 @@ func (m *Model) handleChatMessages(msg tea.Msg) {
 	case ChatResponseMsg:
 		// Clear active cancel (command completed)
 		m.activeCancel = nil
+		// Follow-ups continue in the session seeded with the pruned context
+		if msg.SessionID != "" {
+			m.sessionID = msg.SessionID
+			m.contextChanged = false
+		}
 		m.handleChatCompletion(msg.Response, false)
 
 	case ChatErrorMsg:
@@ -97,8 +102,8 @@ func (m *Model) handlePruneMessages(msg tea.Msg) (*Model, tea.Cmd, bool) {
 	delete(m.pruningSpinners, filePath)
 	if cancel, ok := m.pruningCancels[filePath]; ok {
 		delete(m.pruningCancels, filePath)
-		// Cancel function is no longer needed, but don't call it (operation is done)
-		_ = cancel
+		// The operation is done; release its context
+		cancel()
 	}
 
 	if pruneMsg.Err != nil {
@@ -112,11 +117,35 @@ func (m *Model) handlePruneMessages(msg tea.Msg) (*Model, tea.Cmd, bool) {
 		m.fileList = UpdateFileListModel(m.fileList, m.reviewCtx, m.pruningFiles)
 		return m, ClearYankFeedbackCmd(PruneErrorFeedbackDuration), true
 	}
+	m.pruneCache[pruneMsg.ContentHash] = pruneMsg.Summary
+	m.applyPrune(filePath, pruneMsg.Summary)
+	m.yankFeedback = fmt.Sprintf("✓ Pruned %s", filePath)
+	return m, ClearYankFeedbackCmd(YankFeedbackDuration), true
+}
+
+// applyPrune replaces a file's content with its summary in the prompt
+func (m *Model) applyPrune(filePath, summary string) {
 	// Update pruned files map (PrunedFiles is always initialized in builder.go)
-	m.reviewCtx.PrunedFiles[filePath] = pruneMsg.Summary
+	m.reviewCtx.PrunedFiles[filePath] = summary
+	m.contextChanged = true
 	// Update file list to show pruned indicator (and remove pruning indicator)
 	m.fileList = UpdateFileListModel(m.fileList, m.reviewCtx, m.pruningFiles)
-	m.yankFeedback = fmt.Sprintf("✓ Pruned %s", filePath)
-	return m, ClearYankFeedbackCmd(YankFeedbackDuration), true
 }
 
+// handleModeMessages handles the result of a /mode command
+// Returns (model, cmd, shouldReturnEarly)
+func (m *Model) handleModeMessages(msg tea.Msg) (*Model, tea.Cmd, bool) {
+	modeMsg, ok := msg.(ModeChangedMsg)
+	if !ok {
+		return m, nil, false
+	}
+	if modeMsg.Err != nil {
+		m.yankFeedback = fmt.Sprintf("Error switching mode: %v", modeMsg.Err)
+		m.updateViewportHeight()
+		return m, ClearYankFeedbackCmd(PruneErrorFeedbackDuration), true
+	}
+	m.mode = modeMsg.Mode
+	m.yankFeedback = fmt.Sprintf("✓ Switched to %s mode", modeMsg.Mode)
+	m.updateViewportHeight()
+	return m, ClearYankFeedbackCmd(YankFeedbackDuration), true
+}
@@ -22,20 +22,29 @@ func (m *Model) updateKeyMsgFileList(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		if !ok {
 			return m, nil
 		}
-		// Check if already pruned (PrunedFiles is always initialized in builder.go:91)
+		// Check if already pruned (PrunedFiles is always initialized in builder.go)
 		if _, pruned := m.reviewCtx.PrunedFiles[filePath]; pruned {
 			// Already pruned, skip
 			return m, nil
 		}
-		// Check if already pruning
+		// Pressing prune again cancels an in-flight prune
 		if m.pruningFiles[filePath] {
-			// Already pruning, skip
+			if cancel, ok := m.pruningCancels[filePath]; ok {
+				cancel()
+			}
 			return m, nil
 		}
 		// Check if file exists
-		if _, ok := m.reviewCtx.FileContents[filePath]; !ok {
+		content, ok := m.reviewCtx.FileContents[filePath]
+		if !ok {
 			return m, nil
 		}
+		// Reuse the summary of identical content
+		if summary, ok := m.pruneCache[contentHash(content)]; ok {
+			m.applyPrune(filePath, summary)
+			m.yankFeedback = fmt.Sprintf("✓ Pruned %s (cached)", filePath)
+			return m, ClearYankFeedbackCmd(YankFeedbackDuration)
+		}
 		// Mark file as pruning
 		m.pruningFiles[filePath] = true
 		// Create spinner for this file
@@ -44,23 +53,29 @@ func (m *Model) updateKeyMsgFileList(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		fileSpinner.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
 		m.pruningSpinners[filePath] = fileSpinner
 		// Create new context for this command
-		_, cancel := context.WithCancel(m.rootCtx)
+		ctx, cancel := context.WithCancel(m.rootCtx)
 		m.pruningCancels[filePath] = cancel
 		// Update file list to show pruning indicator
 		m.fileList = UpdateFileListModel(m.fileList, m.reviewCtx, m.pruningFiles)
 		// Start spinner tick and prune command
 		return m, tea.Batch(
 			fileSpinner.Tick,
-			// Prune functionality temporarily disabled - needs migration to coordinator
-			// pruneFileCmd(ctx, m.flashClient, filePath, content),
-			tea.Cmd(func() tea.Msg {
-				return PruneFileMsg{
-					FilePath: filePath,
-					Summary:  "",
-					Err:      fmt.Errorf("prune functionality is temporarily disabled during migration"),
-				}
-			}),
+			pruneFileCmd(ctx, m.app.AgentCoordinator, filePath, content),
 		)
+	case key.Matches(msg, m.keys.FileListUnprune):
+		// Restore the full content of the selected file
+		filePath, ok := GetSelectedFile(m.fileList)
+		if !ok {
+			return m, nil
+		}
+		if _, pruned := m.reviewCtx.PrunedFiles[filePath]; !pruned {
+			return m, nil
+		}
+		delete(m.reviewCtx.PrunedFiles, filePath)
+		m.contextChanged = true
+		m.fileList = UpdateFileListModel(m.fileList, m.reviewCtx, m.pruningFiles)
+		m.yankFeedback = fmt.Sprintf("✓ Unpruned %s", filePath)
+		return m, ClearYankFeedbackCmd(YankFeedbackDuration)
 	case key.Matches(msg, m.keys.SelectFile):
 		// View selected file (for now, just go back)
 		m.returnToPreviousState()
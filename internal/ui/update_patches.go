@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/patch"
+)
+
+// enterPatches opens the patch review, generating patches the first time
+func (m *Model) enterPatches() (*Model, tea.Cmd) {
+	// Patches are generated in the review session, which is busy while streaming
+	if m.streaming {
+		return m, m.showPatchFeedback("Wait for the response to finish")
+	}
+	m.state = StatePatches
+	if m.patches != nil || m.generatingPatches {
+		return m, nil
+	}
+	return m, m.startPatchGeneration()
+}
+
+// startPatchGeneration asks the agent for patches in the review session
+func (m *Model) startPatchGeneration() tea.Cmd {
+	ctx, cancel := context.WithCancel(m.rootCtx)
+	m.activeCancel = cancel
+	m.generatingPatches = true
+	return tea.Batch(
+		m.spinner.Tick,
+		generatePatchesCmd(ctx, m.app.AgentCoordinator, m.sessionID, m.app.Config().WorkingDir()),
+	)
+}
+
+// updateKeyMsgPatches handles key messages in the patch review
+func (m *Model) updateKeyMsgPatches(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	// Patches are opened from the review; help overwrites previousState
+	if key.Matches(msg, m.keys.Back) {
+		m.state = StateReviewing
+		m.updateViewportHeight()
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Help) {
+		m.previousState = m.state
+		m.state = StateHelp
+		return m, nil
+	}
+	if m.generatingPatches {
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.PatchRegenerate) {
+		m.patches, m.patchIndex, m.patchOffset = nil, 0, 0
+		return m, m.startPatchGeneration()
+	}
+	if key.Matches(msg, m.keys.PatchRevert) {
+		if m.lastBatch == nil {
+			return m, m.showPatchFeedback("No applied batch to revert")
+		}
+		return m, revertBatchCmd(m.rootCtx, m.app.History, m.lastBatch)
+	}
+	if len(m.patches) == 0 {
+		return m, nil
+	}
+
+	current := m.patches[m.patchIndex]
+	switch {
+	case key.Matches(msg, m.keys.PatchNext):
+		m.patchIndex = (m.patchIndex + 1) % len(m.patches)
+		m.patchOffset = 0
+	case key.Matches(msg, m.keys.PatchPrev):
+		m.patchIndex = (m.patchIndex - 1 + len(m.patches)) % len(m.patches)
+		m.patchOffset = 0
+	case key.Matches(msg, m.keys.Down):
+		m.patchOffset++
+	case key.Matches(msg, m.keys.Up):
+		m.patchOffset = max(m.patchOffset-1, 0)
+	case key.Matches(msg, m.keys.PatchAccept):
+		current.Status = patch.StatusAccepted
+	case key.Matches(msg, m.keys.PatchReject):
+		current.Status = patch.StatusRejected
+	case key.Matches(msg, m.keys.PatchEdit):
+		return m, editPatchCmd(m.rootCtx, m.patchIndex, current)
+	case key.Matches(msg, m.keys.PatchApply):
+		accepted := m.acceptedPatches()
+		if len(accepted) == 0 {
+			return m, m.showPatchFeedback("No accepted patches to apply")
+		}
+		return m, applyPatchesCmd(m.rootCtx, m.app.History, m.sessionID, accepted)
+	}
+	return m, nil
+}
+
+// handlePatchMessages handles patch generation, editing, apply and revert results
+// Returns (model, cmd, shouldReturnEarly)
+func (m *Model) handlePatchMessages(msg tea.Msg) (*Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case PatchesGeneratedMsg:
+		m.activeCancel = nil
+		m.generatingPatches = false
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(patchesError("Patch generation", msg.Err)), true
+		}
+		m.patches = msg.Patches
+		if m.patches == nil {
+			m.patches = []*patch.Patch{}
+		}
+		m.patchIndex, m.patchOffset = 0, 0
+		feedback := fmt.Sprintf("✓ %d patch(es) generated", len(m.patches))
+		if len(msg.Skipped) > 0 {
+			feedback += fmt.Sprintf(", %d skipped: %s", len(msg.Skipped), strings.Join(msg.Skipped, "; "))
+		}
+		return m, m.showPatchFeedback(feedback), true
+	case PatchEditedMsg:
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(patchesError("Editing patch", msg.Err)), true
+		}
+		if msg.Index < len(m.patches) {
+			m.patches[msg.Index].SetAfter(msg.Content)
+		}
+		return m, nil, true
+	case PatchesAppliedMsg:
+		if msg.Batch != nil && len(msg.Batch.Files) > 0 {
+			m.lastBatch = msg.Batch
+		}
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(patchesError("Applying patches", msg.Err)), true
+		}
+		// Applied patches are done; the rest stay for another batch
+		m.patches = lo.Filter(m.patches, func(p *patch.Patch, _ int) bool { return p.Status != patch.StatusAccepted })
+		m.patchIndex, m.patchOffset = 0, 0
+		return m, m.showPatchFeedback(fmt.Sprintf("✓ Applied %d file(s); u to revert", len(msg.Batch.Files))), true
+	case PatchesRevertedMsg:
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(patchesError("Reverting patches", msg.Err)), true
+		}
+		m.lastBatch = nil
+		return m, m.showPatchFeedback("✓ Reverted the last batch"), true
+	}
+	return m, nil, false
+}
+
+// showPatchFeedback shows a feedback line and clears it later
+func (m *Model) showPatchFeedback(feedback string) tea.Cmd {
+	m.yankFeedback = feedback
+	m.updateViewportHeight()
+	return ClearYankFeedbackCmd(PruneErrorFeedbackDuration)
+}
@@ -105,6 +105,10 @@ func (m *Model) updateKeyMsgReviewing(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		m.textarea.Focus()
 		m.updateViewportHeight()
 		return m, nil
+	case key.Matches(msg, m.keys.Patches):
+		return m.enterPatches()
+	case key.Matches(msg, m.keys.Triage):
+		return m.enterTriage()
 	case key.Matches(msg, m.keys.FileList):
 		m.previousState = m.state
 		m.state = StateFileList
@@ -10,7 +10,7 @@ func (m *Model) handleSpinnerTick(msg spinner.TickMsg) tea.Cmd {
 	var cmds []tea.Cmd
 
 	// Handle main spinner (loading/streaming)
-	if m.state == StateLoading || m.streaming {
+	if m.state == StateLoading || m.streaming || m.generatingPatches {
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		if cmd != nil {
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/samber/lo"
+
+	"github.com/trankhanh040147/revcli/internal/findings"
+	"github.com/trankhanh040147/revcli/internal/triage"
+)
+
+// enterTriage opens the triage of the review's findings and loads their decisions
+func (m *Model) enterTriage() (*Model, tea.Cmd) {
+	if m.streaming || m.reviewResponse == "" {
+		return m, m.showPatchFeedback("Wait for the review to finish")
+	}
+	report := findings.Parse(m.reviewResponse)
+	report.AddFingerprints(m.reviewCtx.Sources)
+	m.triageFindings = report.Findings
+	m.triageIndex = min(m.triageIndex, max(len(m.triageFindings)-1, 0))
+	m.state = StateTriage
+	return m, loadTriageCmd(m.rootCtx, m.app.Triage)
+}
+
+// updateKeyMsgTriage handles key messages in the finding triage
+func (m *Model) updateKeyMsgTriage(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	if m.dismissing {
+		return m.updateKeyMsgDismissing(msg)
+	}
+	// Triage is opened from the review; help overwrites previousState
+	if key.Matches(msg, m.keys.Back) {
+		m.state = StateReviewing
+		m.updateViewportHeight()
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Help) {
+		m.previousState = m.state
+		m.state = StateHelp
+		return m, nil
+	}
+	// Decisions are stored per repository, so wait until it is known
+	if len(m.triageFindings) == 0 || m.triageRepo == "" {
+		return m, nil
+	}
+
+	current := m.triageFindings[m.triageIndex]
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		m.triageIndex = (m.triageIndex + 1) % len(m.triageFindings)
+	case key.Matches(msg, m.keys.Up):
+		m.triageIndex = (m.triageIndex - 1 + len(m.triageFindings)) % len(m.triageFindings)
+	case key.Matches(msg, m.keys.TriageDismiss):
+		m.dismissing = true
+		m.triageReason.SetValue(m.triageDecisions[current.Fingerprint].Reason)
+		m.triageReason.Focus()
+		return m, textinput.Blink
+	case key.Matches(msg, m.keys.TriageAcknowledge):
+		return m, setTriageCmd(m.rootCtx, m.app.Triage, m.triageRepo, current, triage.StatusAcknowledged, "")
+	case key.Matches(msg, m.keys.TriageFixed):
+		return m, setTriageCmd(m.rootCtx, m.app.Triage, m.triageRepo, current, triage.StatusFixed, "")
+	case key.Matches(msg, m.keys.TriageClear):
+		if _, ok := m.triageDecisions[current.Fingerprint]; !ok {
+			return m, nil
+		}
+		return m, clearTriageCmd(m.rootCtx, m.app.Triage, m.triageRepo, current.Fingerprint)
+	}
+	return m, nil
+}
+
+// updateKeyMsgDismissing handles key messages while the dismissal reason is typed
+func (m *Model) updateKeyMsgDismissing(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.dismissing = false
+		m.triageReason.Blur()
+		return m, nil
+	case key.Matches(msg, m.keys.SelectFile):
+		m.dismissing = false
+		m.triageReason.Blur()
+		reason := strings.TrimSpace(m.triageReason.Value())
+		current := m.triageFindings[m.triageIndex]
+		return m, setTriageCmd(m.rootCtx, m.app.Triage, m.triageRepo, current, triage.StatusDismissed, reason)
+	default:
+		var cmd tea.Cmd
+		m.triageReason, cmd = m.triageReason.Update(msg)
+		return m, cmd
+	}
+}
+
+// handleTriageMessages handles loaded and stored triage decisions
+// Returns (model, cmd, shouldReturnEarly)
+func (m *Model) handleTriageMessages(msg tea.Msg) (*Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case TriageLoadedMsg:
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(fmt.Sprintf("Error loading triage: %v", msg.Err)), true
+		}
+		m.triageRepo = msg.Repo
+		m.triageDecisions = lo.SliceToMap(msg.Decisions, func(d triage.Decision) (string, triage.Decision) {
+			return d.Fingerprint, d
+		})
+		return m, nil, true
+	case TriageSavedMsg:
+		if msg.Err != nil {
+			return m, m.showPatchFeedback(fmt.Sprintf("Error saving triage: %v", msg.Err)), true
+		}
+		if msg.Decision == nil {
+			delete(m.triageDecisions, msg.Fingerprint)
+			return m, m.showPatchFeedback("✓ Decision cleared"), true
+		}
+		m.triageDecisions[msg.Fingerprint] = *msg.Decision
+		feedback := fmt.Sprintf("✓ Marked %s", msg.Decision.Status)
+		if msg.Decision.Status == triage.StatusDismissed {
+			feedback += "; it is left out of future reviews"
+		}
+		return m, m.showPatchFeedback(feedback), true
+	}
+	return m, nil, false
+}
@@ -28,6 +28,10 @@ func (m *Model) viewLoading() string {
 	s.WriteString(" Analyzing your code changes...\n\n")
 	s.WriteString(RenderSubtitle(m.reviewCtx.Summary()))
 	s.WriteString("\n")
+	if m.pendingPermission != nil {
+		s.WriteString(m.renderPermissionPrompt())
+		s.WriteString("\n")
+	}
 	s.WriteString(RenderHelp("q: quit"))
 	return s.String()
 }
@@ -69,6 +73,11 @@ func (m *Model) viewMain() string {
 		}
 	}
 
+	if m.pendingPermission != nil {
+		s.WriteString(m.renderPermissionPrompt())
+		s.WriteString("\n")
+	}
+
 	if m.state == StateSearching {
 		s.WriteString(RenderSearchInput(
 			m.searchInput.Value(),
@@ -101,7 +110,8 @@ func (m *Model) renderWebSearchIndicator() string {
 		checkbox = webSearchCheckboxDisabledStyle.Render("[ ]")
 	}
 
-	return webSearchIndicatorStyle.Render(fmt.Sprintf("%s Web Search (Ctrl+w to toggle)", checkbox))
+	return webSearchIndicatorStyle.Render(fmt.Sprintf("%s Web Search (Ctrl+w to toggle) • Mode: %s (%s review|fix|ask)",
+		checkbox, m.mode, ModeCommand))
 }
 
 // viewFooter renders the footer help text based on current state
@@ -114,9 +124,9 @@ func (m *Model) viewFooter() string {
 			return RenderHelp(fmt.Sprintf("n/N: next/prev (%d/%d) • /: search • ?: help • q: quit",
 				m.search.CurrentMatch+1, m.search.MatchCount()))
 		}
-		return RenderCompactHelp("reviewing")
+		return RenderCompactHelp("reviewing") + " • " + m.webAccessStatus() + m.secretsStatus()
 	case StateChatting:
-		return RenderCompactHelp("chatting")
+		return RenderCompactHelp("chatting") + " • " + m.webAccessStatus() + m.secretsStatus()
 	case StateFileList:
 		return RenderCompactHelp("filelist")
 	default:
@@ -143,6 +153,10 @@ func (m *Model) View() tea.View {
 		return tea.NewView(m.viewError())
 	case StateFileList:
 		return tea.NewView(m.viewFileList())
+	case StatePatches:
+		return tea.NewView(m.viewPatches())
+	case StateTriage:
+		return tea.NewView(m.viewTriage())
 	case StateReviewing, StateChatting, StateSearching:
 		return tea.NewView(m.viewMain())
 	default:
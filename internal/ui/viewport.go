@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
 )
 
 // BuildViewportContent builds the viewport content from review response and chat history
@@ -131,7 +132,11 @@ func UpdateViewportWithSearch(vp *viewport.Model, rawContent string, search *Sea
 
 // updateViewportHeight updates the viewport height based on current UI state
 func (m *Model) updateViewportHeight() {
-	m.viewport.SetHeight(CalculateViewportHeight(m.height, m.state, m.yankFeedback != ""))
+	height := CalculateViewportHeight(m.height, m.state, m.yankFeedback != "")
+	if m.pendingPermission != nil {
+		height = max(height-lipgloss.Height(m.renderPermissionPrompt()), 5)
+	}
+	m.viewport.SetHeight(height)
 }
 
 // resetYankChord resets the yank chord state
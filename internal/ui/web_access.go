@@ -0,0 +1,27 @@
+package ui
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/trankhanh040147/revcli/internal/pubsub"
+	"github.com/trankhanh040147/revcli/internal/session"
+)
+
+// handleWebAccessMessages notes when the agent used the web in the review session
+func (m *Model) handleWebAccessMessages(msg tea.Msg) {
+	if event, ok := msg.(pubsub.Event[session.Session]); ok && event.Payload.ID == m.sessionID && event.Payload.WebAccessUsed {
+		m.webAccessUsed = true
+	}
+}
+
+// webAccessStatus describes the review's web access for the footer
+func (m *Model) webAccessStatus() string {
+	status := "web: off"
+	if m.reviewCtx.Intent.WebAccess() {
+		status = "web: on"
+	}
+	if m.webAccessUsed {
+		status += " (used)"
+	}
+	return RenderHelp(status)
+}